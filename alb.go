@@ -0,0 +1,125 @@
+package lux
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// ServeALB handles an incoming HTTP request from an AWS Application Load Balancer. The
+// request is converted so that it can be routed using the same handlers & middleware as
+// ServeHTTP, and the response is converted back into the format expected by the load
+// balancer. If the target group has multi-value headers enabled, the response will
+// use multi-value headers. The source IP of the request is the last address in the
+// X-Forwarded-For header, which is appended by the load balancer.
+func (r *Router) ServeALB(ctx context.Context, req events.ALBTargetGroupRequest) (events.ALBTargetGroupResponse, error) {
+	resp, err := r.ServeHTTP(ctx, newRequestFromALB(req))
+
+	if err != nil {
+		return events.ALBTargetGroupResponse{}, err
+	}
+
+	return newALBResponse(resp, len(req.MultiValueHeaders) > 0), nil
+}
+
+// newRequestFromALB converts an application load balancer request into a Request.
+func newRequestFromALB(req events.ALBTargetGroupRequest) Request {
+	out := Request{
+		APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+			Path:                            req.Path,
+			HTTPMethod:                      req.HTTPMethod,
+			Headers:                         make(map[string]string),
+			MultiValueHeaders:               req.MultiValueHeaders,
+			QueryStringParameters:           make(map[string]string),
+			MultiValueQueryStringParameters: make(map[string][]string),
+			Body:                            req.Body,
+			IsBase64Encoded:                 req.IsBase64Encoded,
+			RequestContext: events.APIGatewayProxyRequestContext{
+				HTTPMethod: req.HTTPMethod,
+				Path:       req.Path,
+			},
+		},
+		raw: req,
+	}
+
+	for key, value := range req.Headers {
+		out.Headers[key] = value
+	}
+
+	// When multi-value headers are enabled, single value headers are not provided.
+	for key, values := range req.MultiValueHeaders {
+		if _, ok := out.Headers[key]; !ok && len(values) > 0 {
+			out.Headers[key] = values[len(values)-1]
+		}
+	}
+
+	// The load balancer does not provide the address of the client, other than by appending
+	// it to the X-Forwarded-For header, so it is used as the source IP of the request.
+	if addrs := out.forwardedHeader("X-Forwarded-For"); len(addrs) > 0 {
+		out.RequestContext.Identity.SourceIP = addrs[len(addrs)-1]
+	}
+
+	// The load balancer does not decode query parameters, so they must be unescaped.
+	for key, value := range req.QueryStringParameters {
+		out.QueryStringParameters[unescape(key)] = unescape(value)
+	}
+
+	for key, values := range req.MultiValueQueryStringParameters {
+		key = unescape(key)
+
+		for _, value := range values {
+			out.MultiValueQueryStringParameters[key] = append(out.MultiValueQueryStringParameters[key], unescape(value))
+		}
+
+		if _, ok := out.QueryStringParameters[key]; !ok && len(values) > 0 {
+			out.QueryStringParameters[key] = unescape(values[len(values)-1])
+		}
+	}
+
+	return out
+}
+
+// newALBResponse converts a Response into the format expected by an application load
+// balancer.
+func newALBResponse(resp Response, multiValue bool) events.ALBTargetGroupResponse {
+	out := events.ALBTargetGroupResponse{
+		StatusCode:        resp.StatusCode,
+		StatusDescription: fmt.Sprintf("%d %s", resp.StatusCode, http.StatusText(resp.StatusCode)),
+		Body:              resp.Body,
+		IsBase64Encoded:   resp.IsBase64Encoded,
+	}
+
+	if !multiValue {
+		out.Headers = resp.Headers
+		return out
+	}
+
+	out.MultiValueHeaders = make(map[string][]string)
+
+	for key, values := range resp.MultiValueHeaders {
+		out.MultiValueHeaders[key] = values
+	}
+
+	for key, value := range resp.Headers {
+		if _, ok := out.MultiValueHeaders[key]; !ok {
+			out.MultiValueHeaders[key] = []string{value}
+		}
+	}
+
+	return out
+}
+
+// unescape decodes a URL encoded query string value, returning the original value if
+// it cannot be decoded.
+func unescape(value string) string {
+	out, err := url.QueryUnescape(value)
+
+	if err != nil {
+		return value
+	}
+
+	return out
+}
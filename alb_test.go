@@ -0,0 +1,148 @@
+package lux_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/davidsbond/lux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouter_ServesALBRequests(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Request                   events.ALBTargetGroupRequest
+		ExpectedStatus            int
+		ExpectedStatusDescription string
+		ExpectedBody              string
+		ExpectedHeaders           map[string]string
+		ExpectedMultiValueHeaders map[string][]string
+	}{
+		// Scenario 1: Valid GET request with single value headers
+		{
+			Request: events.ALBTargetGroupRequest{
+				HTTPMethod:            "GET",
+				Path:                  "/users/42",
+				Headers:               map[string]string{"content-type": "application/json"},
+				QueryStringParameters: map[string]string{"key": "some%20value"},
+			},
+			ExpectedStatus:            http.StatusOK,
+			ExpectedStatusDescription: "200 OK",
+			ExpectedBody:              "\"hello test\"\n",
+			ExpectedHeaders:           map[string]string{"Content-Type": "application/json", "Content-Length": "13"},
+		},
+		// Scenario 2: Valid GET request with multi-value headers
+		{
+			Request: events.ALBTargetGroupRequest{
+				HTTPMethod:                      "GET",
+				Path:                            "/users/42",
+				MultiValueHeaders:               map[string][]string{"content-type": {"application/json"}},
+				MultiValueQueryStringParameters: map[string][]string{"key": {"some%20value"}},
+			},
+			ExpectedStatus:            http.StatusOK,
+			ExpectedStatusDescription: "200 OK",
+			ExpectedBody:              "\"hello test\"\n",
+			ExpectedMultiValueHeaders: map[string][]string{"Content-Type": {"application/json"}, "Content-Length": {"13"}},
+		},
+		// Scenario 3: Request with an unsupported method
+		{
+			Request: events.ALBTargetGroupRequest{
+				HTTPMethod: "DELETE",
+				Path:       "/users/42",
+			},
+			ExpectedStatus:            http.StatusMethodNotAllowed,
+			ExpectedStatusDescription: "405 Method Not Allowed",
+			ExpectedBody:              "\"not allowed\"",
+			ExpectedHeaders: map[string]string{
+				"Allow":          "GET, HEAD, OPTIONS",
+				"Content-Type":   "application/json",
+				"Content-Length": "13",
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router
+		router := lux.NewRouter()
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has a handler registered
+		router.Handler("GET", getHandler).
+			Path("/users/{id}").
+			Headers("content-type", "application/json").
+			Queries("key", "some value")
+
+		// WHEN we perform the request
+		resp, err := router.ServeALB(context.Background(), tc.Request)
+
+		// THEN there should be no error
+		assert.Nil(t, err)
+
+		// AND the response should be what we expect.
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+		assert.Equal(t, tc.ExpectedStatusDescription, resp.StatusDescription)
+		assert.Equal(t, tc.ExpectedBody, resp.Body)
+		assert.Equal(t, tc.ExpectedHeaders, resp.Headers)
+		assert.Equal(t, tc.ExpectedMultiValueHeaders, resp.MultiValueHeaders)
+	}
+}
+
+func TestRouter_ALBClients(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Middleware       lux.HandlerFunc
+		ExpectedStatuses []int
+		ExpectedBodies   []string
+	}{
+		// Scenario 1: Clients are rate limited independently
+		{
+			Middleware:       lux.RateLimit(lux.RateLimitOptions{Limit: 1, Window: time.Minute}),
+			ExpectedStatuses: []int{http.StatusOK, http.StatusOK},
+			ExpectedBodies:   []string{`"request 1"`, `"request 2"`},
+		},
+		// Scenario 2: Clients using the same idempotency key are handled independently
+		{
+			Middleware:       lux.Idempotency(lux.IdempotencyOptions{}),
+			ExpectedStatuses: []int{http.StatusOK, http.StatusOK},
+			ExpectedBodies:   []string{`"request 1"`, `"request 2"`},
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router using the middleware
+		router := lux.NewRouter().Middleware(tc.Middleware)
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has a handler that counts its invocations
+		count := 0
+		router.Handler("POST", func(w lux.ResponseWriter, r *lux.Request) {
+			count++
+			lux.JSON(w, http.StatusOK, fmt.Sprintf("request %d", count))
+		}).Path("/payments")
+
+		for i, client := range []string{"1.1.1.1", "2.2.2.2"} {
+			// WHEN a client performs a request through the load balancer
+			resp, err := router.ServeALB(context.Background(), events.ALBTargetGroupRequest{
+				HTTPMethod: "POST",
+				Path:       "/payments",
+				Headers: map[string]string{
+					"idempotency-key": "a",
+					"x-forwarded-for": "9.9.9.9, " + client,
+				},
+			})
+
+			// THEN the request should be handled separately from the other client
+			assert.Nil(t, err)
+			assert.Equal(t, tc.ExpectedStatuses[i], resp.StatusCode)
+			assert.Equal(t, tc.ExpectedBodies[i], resp.Body)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package lux
+
+// MethodAny is the method used to register routes that handle requests with any HTTP method.
+const MethodAny = "*"
+
+// Any adds a handler to the router that handles requests with any HTTP method, such as for
+// proxies or endpoints that do not depend on the method. Routes registered for a specific
+// method take precedence, so the route is only used for requests that no other route with
+// a matching path can handle.
+func (r *Router) Any(fn HandlerFunc) *Route {
+	return r.Handler(MethodAny, fn)
+}
+
+// Any adds a handler to the group that handles requests with any HTTP method, like
+// Router.Any. The route's path will be prefixed with that of the group.
+func (g *Group) Any(fn HandlerFunc) *Route {
+	return g.Handler(MethodAny, fn)
+}
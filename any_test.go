@@ -0,0 +1,108 @@
+package lux_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/davidsbond/lux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouter_Any(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Method         string
+		Path           string
+		Headers        map[string]string
+		ExpectedStatus int
+		ExpectedBody   string
+	}{
+		// Scenario 1: Method with a specific handler uses that handler
+		{
+			Method:         "GET",
+			Path:           "/proxy",
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   "\"hello test\"\n",
+		},
+		// Scenario 2: Method without a specific handler uses the any handler
+		{
+			Method:         "DELETE",
+			Path:           "/proxy",
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   `"any DELETE"`,
+		},
+		// Scenario 3: HEAD requests use the GET handler
+		{
+			Method:         "HEAD",
+			Path:           "/proxy",
+			ExpectedStatus: http.StatusOK,
+		},
+		// Scenario 4: Specific handler whose matchers fail falls through to the any handler
+		{
+			Method:         "POST",
+			Path:           "/proxy",
+			Headers:        map[string]string{"Content-Type": "text/plain"},
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   `"any POST"`,
+		},
+		// Scenario 5: Group any handler is used within the group
+		{
+			Method:         "PATCH",
+			Path:           "/v1/users",
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   `"group PATCH"`,
+		},
+		// Scenario 6: Paths without an any handler are unaffected
+		{
+			Method:         "DELETE",
+			Path:           "/health",
+			ExpectedStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router
+		router := lux.NewRouter()
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has handlers for specific methods
+		router.Handler("GET", getHandler).Path("/proxy")
+		router.Handler("POST", getHandler).Path("/proxy").Headers("Content-Type", "application/json")
+		router.Handler("GET", getHandler).Path("/health")
+
+		// AND that router has a handler for any method
+		router.Any(func(w lux.ResponseWriter, r *lux.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`"any ` + r.HTTPMethod + `"`))
+		}).Path("/proxy")
+
+		// AND that router has a group with a handler for any method
+		router.Group("/v1").Any(func(w lux.ResponseWriter, r *lux.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`"group ` + r.HTTPMethod + `"`))
+		}).Path("/users")
+
+		// WHEN we perform the request
+		resp, _ := router.ServeHTTP(context.Background(), lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+				HTTPMethod: tc.Method,
+				Path:       tc.Path,
+				Headers:    tc.Headers,
+			},
+		})
+
+		// THEN the response should be what we expect
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+
+		if tc.ExpectedBody != "" {
+			assert.Equal(t, tc.ExpectedBody, resp.Body)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package lux
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+type (
+	// The Claims type contains the claims obtained from a validated bearer token.
+	Claims map[string]interface{}
+)
+
+const claimsKey = contextKey("claims")
+
+var errUnauthorized = errors.New("unauthorized")
+
+// BasicAuth creates a middleware function that authenticates requests using HTTP basic
+// authentication. The username & password from the Authorization header are passed to
+// the validate function. If the header is missing, malformed or the credentials are
+// rejected, a 401 response is returned with a WWW-Authenticate challenge, preventing
+// execution of any further middleware & the handler.
+func BasicAuth(validate func(user, pass string) bool) HandlerFunc {
+	return func(w ResponseWriter, r *Request) {
+		user, pass, ok := r.basicAuth()
+
+		if ok && validate(user, pass) {
+			return
+		}
+
+		w.Header().Set("WWW-Authenticate", `Basic realm="Restricted", charset="UTF-8"`)
+		r.router.writeError(w, r, http.StatusUnauthorized, errUnauthorized)
+	}
+}
+
+// BearerAuth creates a middleware function that authenticates requests using bearer
+// tokens. The token from the Authorization header is passed to the validate function &
+// the returned claims are stored in the request context, where they can be obtained
+// using ClaimsFromContext. If the header is missing, malformed or the validate function
+// returns an error, a 401 response is returned with a WWW-Authenticate challenge,
+// preventing execution of any further middleware & the handler.
+func BearerAuth(validate func(token string) (Claims, error)) HandlerFunc {
+	return func(w ResponseWriter, r *Request) {
+		token, ok := r.bearerToken()
+
+		if !ok {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			r.router.writeError(w, r, http.StatusUnauthorized, errUnauthorized)
+			return
+		}
+
+		claims, err := validate(token)
+
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+			r.router.writeError(w, r, http.StatusUnauthorized, errUnauthorized)
+			return
+		}
+
+		r.SetContext(context.WithValue(r.Context(), claimsKey, claims))
+	}
+}
+
+// ClaimsFromContext returns the claims stored in the given context by the BearerAuth
+// middleware.
+func ClaimsFromContext(ctx context.Context) (Claims, bool) {
+	claims, ok := ctx.Value(claimsKey).(Claims)
+
+	return claims, ok
+}
+
+// basicAuth returns the username & password provided in the Authorization header of
+// the request, if it uses HTTP basic authentication.
+func (r *Request) basicAuth() (string, string, bool) {
+	credentials, ok := r.authorization("Basic")
+
+	if !ok {
+		return "", "", false
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(credentials)
+
+	if err != nil {
+		return "", "", false
+	}
+
+	parts := strings.SplitN(string(decoded), ":", 2)
+
+	if len(parts) != 2 {
+		return "", "", false
+	}
+
+	return parts[0], parts[1], true
+}
+
+// bearerToken returns the token provided in the Authorization header of the request,
+// if it uses the bearer scheme.
+func (r *Request) bearerToken() (string, bool) {
+	token, ok := r.authorization("Bearer")
+
+	return token, ok && token != ""
+}
+
+// authorization returns the credentials from the Authorization header of the request
+// if it uses the given scheme. Schemes are matched case-insensitively.
+func (r *Request) authorization(scheme string) (string, bool) {
+	header := r.header("Authorization")
+
+	if len(header) <= len(scheme) || !strings.EqualFold(header[:len(scheme)], scheme) || header[len(scheme)] != ' ' {
+		return "", false
+	}
+
+	return strings.TrimSpace(header[len(scheme)+1:]), true
+}
@@ -0,0 +1,148 @@
+package lux_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/davidsbond/lux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBasicAuth(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Authorization     string
+		ExpectedStatus    int
+		ExpectedChallenge string
+	}{
+		// Scenario 1: Request with valid credentials
+		{
+			Authorization:  "Basic " + base64.StdEncoding.EncodeToString([]byte("user:pass")),
+			ExpectedStatus: http.StatusOK,
+		},
+		// Scenario 2: Request with a lowercase scheme
+		{
+			Authorization:  "basic " + base64.StdEncoding.EncodeToString([]byte("user:pass")),
+			ExpectedStatus: http.StatusOK,
+		},
+		// Scenario 3: Request with invalid credentials
+		{
+			Authorization:     "Basic " + base64.StdEncoding.EncodeToString([]byte("user:wrong")),
+			ExpectedStatus:    http.StatusUnauthorized,
+			ExpectedChallenge: `Basic realm="Restricted", charset="UTF-8"`,
+		},
+		// Scenario 4: Request with malformed credentials
+		{
+			Authorization:     "Basic not-base64",
+			ExpectedStatus:    http.StatusUnauthorized,
+			ExpectedChallenge: `Basic realm="Restricted", charset="UTF-8"`,
+		},
+		// Scenario 5: Request without credentials
+		{
+			ExpectedStatus:    http.StatusUnauthorized,
+			ExpectedChallenge: `Basic realm="Restricted", charset="UTF-8"`,
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router using basic authentication
+		router := lux.NewRouter().Middleware(lux.BasicAuth(func(user, pass string) bool {
+			return user == "user" && pass == "pass"
+		}))
+
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has a handler
+		router.Handler("GET", getHandler)
+
+		// WHEN we perform a request
+		resp, _ := router.ServeHTTP(context.Background(), lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+				HTTPMethod: "GET",
+				Headers:    map[string]string{"Authorization": tc.Authorization},
+			},
+		})
+
+		// THEN the response should have the expected status & challenge
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+		assert.Equal(t, tc.ExpectedChallenge, resp.Headers["Www-Authenticate"])
+	}
+}
+
+func TestBearerAuth(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Authorization     string
+		ExpectedStatus    int
+		ExpectedChallenge string
+		ExpectedClaims    lux.Claims
+	}{
+		// Scenario 1: Request with a valid token
+		{
+			Authorization:  "Bearer valid",
+			ExpectedStatus: http.StatusOK,
+			ExpectedClaims: lux.Claims{"sub": "user"},
+		},
+		// Scenario 2: Request with an invalid token
+		{
+			Authorization:     "Bearer invalid",
+			ExpectedStatus:    http.StatusUnauthorized,
+			ExpectedChallenge: `Bearer error="invalid_token"`,
+		},
+		// Scenario 3: Request using a different scheme
+		{
+			Authorization:     "Basic valid",
+			ExpectedStatus:    http.StatusUnauthorized,
+			ExpectedChallenge: "Bearer",
+		},
+		// Scenario 4: Request without a token
+		{
+			ExpectedStatus:    http.StatusUnauthorized,
+			ExpectedChallenge: "Bearer",
+		},
+	}
+
+	for _, tc := range tt {
+		var actual lux.Claims
+
+		// GIVEN that we have a router using bearer authentication
+		router := lux.NewRouter().Middleware(lux.BearerAuth(func(token string) (lux.Claims, error) {
+			if token != "valid" {
+				return nil, errors.New("invalid token")
+			}
+
+			return lux.Claims{"sub": "user"}, nil
+		}))
+
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has a handler that reads the claims
+		router.Handler("GET", func(w lux.ResponseWriter, r *lux.Request) {
+			actual, _ = lux.ClaimsFromContext(r.Context())
+			w.WriteHeader(http.StatusOK)
+		})
+
+		// WHEN we perform a request
+		resp, _ := router.ServeHTTP(context.Background(), lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+				HTTPMethod: "GET",
+				Headers:    map[string]string{"Authorization": tc.Authorization},
+			},
+		})
+
+		// THEN the response should have the expected status & challenge
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+		assert.Equal(t, tc.ExpectedChallenge, resp.Headers["Www-Authenticate"])
+
+		// AND the handler should have access to the claims
+		assert.Equal(t, tc.ExpectedClaims, actual)
+	}
+}
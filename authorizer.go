@@ -0,0 +1,82 @@
+package lux
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// AuthorizerContext returns the context populated by the API Gateway authorizer of the
+// request, such as the values returned by a lambda authorizer. For Cognito & JWT authorizers,
+// the claims of the token are available under the "claims" key. A nil map is returned if the
+// request was not authorized by an authorizer.
+func (r *Request) AuthorizerContext() map[string]interface{} {
+	return r.RequestContext.Authorizer
+}
+
+// AuthorizerString returns the value of the given key in the authorizer context as a string.
+// If the key is not present, the claims of Cognito & JWT authorizers are checked instead. An
+// empty string is returned if the key cannot be found.
+func (r *Request) AuthorizerString(key string) string {
+	value, _ := r.authorizerValue(key)
+
+	return value
+}
+
+// AuthorizerInt returns the value of the given key in the authorizer context as an integer. If
+// the key is not present in the authorizer context, ErrMissingParam is returned.
+func (r *Request) AuthorizerInt(key string) (int, error) {
+	value, ok := r.authorizerValue(key)
+
+	if !ok {
+		return 0, ErrMissingParam
+	}
+
+	out, err := strconv.Atoi(value)
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse authorizer value %s, %v", key, err)
+	}
+
+	return out, nil
+}
+
+// AuthorizerBool returns the value of the given key in the authorizer context as a boolean. If
+// the key is not present in the authorizer context, ErrMissingParam is returned.
+func (r *Request) AuthorizerBool(key string) (bool, error) {
+	value, ok := r.authorizerValue(key)
+
+	if !ok {
+		return false, ErrMissingParam
+	}
+
+	out, err := strconv.ParseBool(value)
+
+	if err != nil {
+		return false, fmt.Errorf("failed to parse authorizer value %s, %v", key, err)
+	}
+
+	return out, nil
+}
+
+// authorizerValue returns the value of the given key in the authorizer context, or in the
+// claims of the authorizer context, formatted as a string and whether or not it was present.
+// Lambda authorizers may return numbers & booleans, which are formatted in the same way as
+// API Gateway would when passing them to the integration as strings.
+func (r *Request) authorizerValue(key string) (string, bool) {
+	auth := r.RequestContext.Authorizer
+	value, ok := auth[key]
+
+	if claims, isMap := auth["claims"].(map[string]interface{}); !ok && isMap {
+		value, ok = claims[key]
+	}
+
+	if !ok || value == nil {
+		return "", false
+	}
+
+	if s, isString := value.(string); isString {
+		return s, true
+	}
+
+	return fmt.Sprint(value), true
+}
@@ -0,0 +1,134 @@
+package lux_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/davidsbond/lux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequest_Authorizer(t *testing.T) {
+	t.Parallel()
+
+	// GIVEN that we have a request authorized by a lambda authorizer
+	lambda := lux.Request{
+		APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+			RequestContext: events.APIGatewayProxyRequestContext{
+				Authorizer: map[string]interface{}{
+					"principalId": "user-1",
+					"tenant":      "acme",
+					"level":       float64(3),
+					"admin":       true,
+					"count":       "not a number",
+				},
+			},
+		},
+	}
+
+	// AND a request authorized by a cognito authorizer
+	cognito := lux.Request{
+		APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+			RequestContext: events.APIGatewayProxyRequestContext{
+				Authorizer: map[string]interface{}{
+					"claims": map[string]interface{}{
+						"sub":            "user-2",
+						"email_verified": "true",
+					},
+				},
+			},
+		},
+	}
+
+	// AND a request without an authorizer
+	anonymous := lux.Request{}
+
+	// THEN the authorizer context should be available
+	assert.Equal(t, "acme", lambda.AuthorizerContext()["tenant"])
+	assert.Nil(t, anonymous.AuthorizerContext())
+
+	// AND values should be available as strings
+	assert.Equal(t, "user-1", lambda.AuthorizerString("principalId"))
+	assert.Equal(t, "3", lambda.AuthorizerString("level"))
+	assert.Equal(t, "user-2", cognito.AuthorizerString("sub"))
+	assert.Equal(t, "", anonymous.AuthorizerString("sub"))
+
+	// AND values should be available as integers
+	level, err := lambda.AuthorizerInt("level")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, level)
+
+	_, err = lambda.AuthorizerInt("count")
+	assert.EqualError(t, err, `failed to parse authorizer value count, strconv.Atoi: parsing "not a number": invalid syntax`)
+
+	_, err = anonymous.AuthorizerInt("level")
+	assert.Equal(t, lux.ErrMissingParam, err)
+
+	// AND values should be available as booleans
+	admin, err := lambda.AuthorizerBool("admin")
+	assert.NoError(t, err)
+	assert.True(t, admin)
+
+	verified, err := cognito.AuthorizerBool("email_verified")
+	assert.NoError(t, err)
+	assert.True(t, verified)
+
+	_, err = cognito.AuthorizerBool("admin")
+	assert.Equal(t, lux.ErrMissingParam, err)
+}
+
+func TestRouter_ServesV2Authorizers(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Authorizer  *events.APIGatewayV2HTTPRequestContextAuthorizerDescription
+		ExpectedSub string
+	}{
+		// Scenario 1: Request is authorized by a lambda authorizer
+		{
+			Authorizer: &events.APIGatewayV2HTTPRequestContextAuthorizerDescription{
+				Lambda: map[string]interface{}{"sub": "user-1"},
+			},
+			ExpectedSub: "user-1",
+		},
+		// Scenario 2: Request is authorized by a JWT authorizer
+		{
+			Authorizer: &events.APIGatewayV2HTTPRequestContextAuthorizerDescription{
+				JWT: &events.APIGatewayV2HTTPRequestContextAuthorizerJWTDescription{
+					Claims: map[string]string{"sub": "user-2"},
+					Scopes: []string{"read"},
+				},
+			},
+			ExpectedSub: "user-2",
+		},
+		// Scenario 3: Request is not authorized
+		{},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router
+		router := lux.NewRouter()
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has a handler that returns the authorized principal
+		router.Handler("GET", func(w lux.ResponseWriter, r *lux.Request) {
+			lux.Text(w, http.StatusOK, r.AuthorizerString("sub"))
+		})
+
+		// WHEN we perform a version 2.0 request
+		resp, _ := router.ServeV2(context.Background(), events.APIGatewayV2HTTPRequest{
+			RawPath: "/",
+			RequestContext: events.APIGatewayV2HTTPRequestContext{
+				HTTP:       events.APIGatewayV2HTTPRequestContextHTTPDescription{Method: "GET"},
+				Authorizer: tc.Authorizer,
+			},
+		})
+
+		// THEN the handler should have access to the authorizer context
+		assert.Equal(t, tc.ExpectedSub, resp.Body)
+	}
+}
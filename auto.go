@@ -0,0 +1,113 @@
+package lux
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+)
+
+type (
+	// eventFields contains the fields used to determine the type of a lambda event, as the
+	// payloads of each type overlap.
+	eventFields struct {
+		Version        string `json:"version"`
+		HTTPMethod     string `json:"httpMethod"`
+		RequestContext struct {
+			ELB          json.RawMessage `json:"elb"`
+			HTTP         json.RawMessage `json:"http"`
+			ConnectionID string          `json:"connectionId"`
+		} `json:"requestContext"`
+	}
+)
+
+var errUnknownEvent = errors.New("unrecognised lambda event")
+
+// StartAuto begins handling lambda events using the router like Router.Start, but detects the
+// type of each event using Router.ServeAuto. This allows a single function to be integrated
+// with API Gateway REST APIs, HTTP APIs & Application Load Balancers, such as while migrating
+// between them.
+func (r *Router) StartAuto(opts ...lambda.Option) {
+	lambda.StartWithOptions(r.ServeAuto, opts...)
+}
+
+// ServeAuto handles a lambda event of any type supported by the router. The type of the event
+// is detected from its fields, and it is handled using Router.ServeHTTP, Router.ServeV2,
+// Router.ServeALB or Router.ServeWebSocket, with the response returned in the format expected
+// by the event's source. Events that cannot be detected result in an error. Streamed responses
+// are not supported, use Router.ServeStream instead.
+func (r *Router) ServeAuto(ctx context.Context, payload json.RawMessage) (interface{}, error) {
+	event, err := detectEvent(payload)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch event {
+	case EventAPIGatewayV2:
+		var req events.APIGatewayV2HTTPRequest
+
+		if err := json.Unmarshal(payload, &req); err != nil {
+			return nil, fmt.Errorf("failed to decode %s event, %v", event, err)
+		}
+
+		return r.ServeV2(ctx, req)
+	case EventALB:
+		var req events.ALBTargetGroupRequest
+
+		if err := json.Unmarshal(payload, &req); err != nil {
+			return nil, fmt.Errorf("failed to decode %s event, %v", event, err)
+		}
+
+		return r.ServeALB(ctx, req)
+	case EventWebSocket:
+		var req events.APIGatewayWebsocketProxyRequest
+
+		if err := json.Unmarshal(payload, &req); err != nil {
+			return nil, fmt.Errorf("failed to decode %s event, %v", event, err)
+		}
+
+		return r.ServeWebSocket(ctx, req)
+	default:
+		var req Request
+
+		if err := json.Unmarshal(payload, &req); err != nil {
+			return nil, fmt.Errorf("failed to decode %s event, %v", event, err)
+		}
+
+		return r.ServeHTTP(ctx, req)
+	}
+}
+
+// detectEvent determines the type of the given lambda event. The version 2.0 payload format
+// is identified by its version & HTTP request context, load balancer events by their ELB
+// request context and WebSocket events by their connection ID, leaving API Gateway events,
+// which are identified by their HTTP method.
+func detectEvent(payload json.RawMessage) (EventType, error) {
+	var fields eventFields
+
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		return "", fmt.Errorf("failed to decode lambda event, %v", err)
+	}
+
+	switch {
+	case fields.Version == "2.0" && isObject(fields.RequestContext.HTTP):
+		return EventAPIGatewayV2, nil
+	case isObject(fields.RequestContext.ELB):
+		return EventALB, nil
+	case fields.RequestContext.ConnectionID != "":
+		return EventWebSocket, nil
+	case fields.HTTPMethod != "":
+		return EventAPIGateway, nil
+	default:
+		return "", errUnknownEvent
+	}
+}
+
+// isObject determines if the given JSON value is an object.
+func isObject(value json.RawMessage) bool {
+	return len(value) > 0 && value[0] == '{'
+}
@@ -0,0 +1,102 @@
+package lux_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/davidsbond/lux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouter_ServeAuto(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Payload                   string
+		ExpectsError              bool
+		ExpectedStatus            int
+		ExpectedBody              string
+		ExpectedStatusDescription string
+		ExpectedCookies           []string
+	}{
+		// Scenario 1: API Gateway event
+		{
+			Payload:        `{"httpMethod": "GET", "path": "/users", "requestContext": {"stage": "prod"}}`,
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   "\"hello test\"\n",
+		},
+		// Scenario 2: API Gateway version 2.0 event
+		{
+			Payload:         `{"version": "2.0", "rawPath": "/users", "requestContext": {"http": {"method": "GET", "path": "/users"}}}`,
+			ExpectedStatus:  http.StatusOK,
+			ExpectedBody:    "\"hello test\"\n",
+			ExpectedCookies: []string{"session=abc"},
+		},
+		// Scenario 3: Application Load Balancer event
+		{
+			Payload:                   `{"httpMethod": "GET", "path": "/users", "requestContext": {"elb": {"targetGroupArn": "arn"}}}`,
+			ExpectedStatus:            http.StatusOK,
+			ExpectedBody:              "\"hello test\"\n",
+			ExpectedStatusDescription: "200 OK",
+		},
+		// Scenario 4: WebSocket event
+		{
+			Payload:        `{"requestContext": {"routeKey": "$default", "connectionId": "abc", "eventType": "MESSAGE"}}`,
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   `"websocket"`,
+		},
+		// Scenario 5: Unrecognised event
+		{
+			Payload:      `{"Records": [{"eventSource": "aws:sqs"}]}`,
+			ExpectsError: true,
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router
+		router := lux.NewRouter()
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has a handler that sets a cookie
+		router.Handler("GET", func(w lux.ResponseWriter, r *lux.Request) {
+			w.Header().Add("Set-Cookie", "session=abc")
+			getHandler(w, r)
+		}).Path("/users")
+
+		// AND that router has a websocket handler
+		router.WSRoute("$default", func(w lux.ResponseWriter, r *lux.Request) {
+			lux.JSON(w, http.StatusOK, "websocket")
+		})
+
+		// WHEN the lambda runtime invokes the handler with the event
+		out, err := lambda.NewHandler(router.ServeAuto).Invoke(context.Background(), []byte(tc.Payload))
+
+		// THEN an error should be returned for unrecognised events
+		if tc.ExpectsError {
+			assert.Error(t, err)
+			continue
+		}
+
+		assert.NoError(t, err)
+
+		var resp struct {
+			StatusCode        int      `json:"statusCode"`
+			StatusDescription string   `json:"statusDescription"`
+			Body              string   `json:"body"`
+			Cookies           []string `json:"cookies"`
+		}
+
+		assert.NoError(t, json.Unmarshal(out, &resp))
+
+		// AND the response should be in the format expected by the event's source
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+		assert.Equal(t, tc.ExpectedBody, resp.Body)
+		assert.Equal(t, tc.ExpectedStatusDescription, resp.StatusDescription)
+		assert.Equal(t, tc.ExpectedCookies, resp.Cookies)
+	}
+}
@@ -0,0 +1,88 @@
+package lux
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// maxDecompressedSize is the size limit of decompressed request bodies when no body size
+// limit has been set, protecting against decompression bombs.
+const maxDecompressedSize = 10 << 20
+
+// MaxBodySize limits the size of request bodies. Requests whose body exceeds n bytes once
+// decoded from base64 receive a 413 response before any middleware or handlers are run.
+// Use Route.MaxBodySize to override the limit for a specific route. The limit also applies to
+// compressed bodies once decompressed by Request.RawBody.
+func (r *Router) MaxBodySize(n int) *Router {
+	r.maxBodySize = n
+
+	return r
+}
+
+// MaxBodySize overrides the request body size limit set using Router.MaxBodySize for this
+// route. A negative value removes the limit for the route.
+func (r *Route) MaxBodySize(n int) *Route {
+	r.maxBodySize = n
+
+	return r
+}
+
+// bodyLimit returns the size limit for request bodies of the route, or of the router when the
+// route does not override it. A value less than one means there is no limit.
+func (r *Router) bodyLimit(route *Route) int {
+	if route != nil && route.maxBodySize != 0 {
+		return route.maxBodySize
+	}
+
+	return r.maxBodySize
+}
+
+// bodySize returns the size of the request body in bytes, once decoded from base64 if
+// required.
+func bodySize(req Request) int {
+	if !req.IsBase64Encoded {
+		return len(req.Body)
+	}
+
+	return len(strings.TrimRight(req.Body, "=")) * 3 / 4
+}
+
+// RawBody returns the request body, decoded from base64 if required. Bodies with a gzip
+// Content-Encoding header are decompressed. If the decompressed body exceeds the body size
+// limit of the route, or 10MB when there is no limit, ErrBodyTooLarge is returned.
+func (r *Request) RawBody() ([]byte, error) {
+	body, err := r.body()
+
+	if err != nil || !strings.EqualFold(r.header("Content-Encoding"), "gzip") {
+		return body, err
+	}
+
+	limit := r.bodyLimit
+
+	if limit <= 0 {
+		limit = maxDecompressedSize
+	}
+
+	gz, err := gzip.NewReader(bytes.NewReader(body))
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress request body, %v", err)
+	}
+
+	defer gz.Close()
+
+	// Read one byte more than the limit, so that bodies exceeding it can be detected
+	out, err := io.ReadAll(io.LimitReader(gz, int64(limit)+1))
+
+	switch {
+	case err != nil:
+		return nil, fmt.Errorf("failed to decompress request body, %v", err)
+	case len(out) > limit:
+		return nil, ErrBodyTooLarge
+	default:
+		return out, nil
+	}
+}
@@ -0,0 +1,212 @@
+package lux_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/davidsbond/lux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouter_MaxBodySize(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Path           string
+		Body           string
+		Base64         bool
+		ExpectedStatus int
+		ExpectedBody   string
+	}{
+		// Scenario 1: Body within the router limit
+		{
+			Path:           "/small",
+			Body:           strings.Repeat("a", 10),
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   "\"hello test\"\n",
+		},
+		// Scenario 2: Body exceeding the router limit
+		{
+			Path:           "/small",
+			Body:           strings.Repeat("a", 11),
+			ExpectedStatus: http.StatusRequestEntityTooLarge,
+			ExpectedBody:   `"request body too large"`,
+		},
+		// Scenario 3: Base64 encoded body within the router limit once decoded
+		{
+			Path:           "/small",
+			Body:           base64.StdEncoding.EncodeToString([]byte(strings.Repeat("a", 10))),
+			Base64:         true,
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   "\"hello test\"\n",
+		},
+		// Scenario 4: Base64 encoded body exceeding the router limit once decoded
+		{
+			Path:           "/small",
+			Body:           base64.StdEncoding.EncodeToString([]byte(strings.Repeat("a", 11))),
+			Base64:         true,
+			ExpectedStatus: http.StatusRequestEntityTooLarge,
+			ExpectedBody:   `"request body too large"`,
+		},
+		// Scenario 5: Body within a larger route limit
+		{
+			Path:           "/large",
+			Body:           strings.Repeat("a", 20),
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   "\"hello test\"\n",
+		},
+		// Scenario 6: Body exceeding a larger route limit
+		{
+			Path:           "/large",
+			Body:           strings.Repeat("a", 21),
+			ExpectedStatus: http.StatusRequestEntityTooLarge,
+			ExpectedBody:   `"request body too large"`,
+		},
+		// Scenario 7: Body for a route without a limit
+		{
+			Path:           "/unlimited",
+			Body:           strings.Repeat("a", 100),
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   "\"hello test\"\n",
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router with a body size limit
+		router := lux.NewRouter().MaxBodySize(10)
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has middleware
+		var ran bool
+
+		router.Middleware(func(w lux.ResponseWriter, r *lux.Request) {
+			ran = true
+		})
+
+		// AND that router has routes with their own limits
+		router.Handler("POST", getHandler).Path("/small")
+		router.Handler("POST", getHandler).Path("/large").MaxBodySize(20)
+		router.Handler("POST", getHandler).Path("/unlimited").MaxBodySize(-1)
+
+		// WHEN we perform a request with a body
+		resp, _ := router.ServeHTTP(context.Background(), lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+				HTTPMethod:      "POST",
+				Path:            tc.Path,
+				Body:            tc.Body,
+				IsBase64Encoded: tc.Base64,
+			},
+		})
+
+		// THEN the response should be what we expect
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+		assert.Equal(t, tc.ExpectedBody, resp.Body)
+
+		// AND the middleware should not run for bodies that are too large
+		assert.Equal(t, tc.ExpectedStatus == http.StatusOK, ran)
+	}
+}
+
+func TestRequest_RawBody(t *testing.T) {
+	t.Parallel()
+
+	compress := func(data string) string {
+		var buf bytes.Buffer
+
+		gz := gzip.NewWriter(&buf)
+		gz.Write([]byte(data))
+		gz.Close()
+
+		return base64.StdEncoding.EncodeToString(buf.Bytes())
+	}
+
+	tt := []struct {
+		Limit          int
+		Encoding       string
+		Body           string
+		Base64         bool
+		ExpectedStatus int
+		ExpectedBody   string
+	}{
+		// Scenario 1: Body is not compressed
+		{
+			Body:           `{"name":"test"}`,
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   `{"name":"test"}`,
+		},
+		// Scenario 2: Body is gzip compressed
+		{
+			Encoding:       "gzip",
+			Body:           compress(`{"name":"test"}`),
+			Base64:         true,
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   `{"name":"test"}`,
+		},
+		// Scenario 3: Compressed body exceeds the limit once decompressed
+		{
+			Limit:          1024,
+			Encoding:       "GZIP",
+			Body:           compress(strings.Repeat("a", 2048)),
+			Base64:         true,
+			ExpectedStatus: http.StatusRequestEntityTooLarge,
+			ExpectedBody:   `"request body too large"`,
+		},
+		// Scenario 4: Compressed body exceeds the default limit once decompressed
+		{
+			Encoding:       "gzip",
+			Body:           compress(strings.Repeat("a", 11<<20)),
+			Base64:         true,
+			ExpectedStatus: http.StatusRequestEntityTooLarge,
+			ExpectedBody:   `"request body too large"`,
+		},
+		// Scenario 5: Body is not valid gzip
+		{
+			Encoding:       "gzip",
+			Body:           `{"name":"test"}`,
+			ExpectedStatus: http.StatusBadRequest,
+			ExpectedBody:   `"failed to decompress request body, gzip: invalid header"`,
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router with a body size limit
+		router := lux.NewRouter().MaxBodySize(tc.Limit)
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has a handler that reads the raw body
+		router.Handler("POST", func(w lux.ResponseWriter, r *lux.Request) {
+			body, err := r.RawBody()
+
+			switch {
+			case errors.Is(err, lux.ErrBodyTooLarge):
+				lux.JSON(w, http.StatusRequestEntityTooLarge, err.Error())
+			case err != nil:
+				lux.JSON(w, http.StatusBadRequest, err.Error())
+			default:
+				lux.Text(w, http.StatusOK, string(body))
+			}
+		})
+
+		// WHEN we perform a request
+		resp, _ := router.ServeHTTP(context.Background(), lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+				HTTPMethod:      "POST",
+				Headers:         map[string]string{"Content-Encoding": tc.Encoding},
+				Body:            tc.Body,
+				IsBase64Encoded: tc.Base64,
+			},
+		})
+
+		// THEN the response should be what we expect
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+		assert.Equal(t, tc.ExpectedBody, resp.Body)
+	}
+}
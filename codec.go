@@ -0,0 +1,58 @@
+package lux
+
+import (
+	"encoding/json"
+)
+
+type (
+	// jsonCodec contains the functions used to encode & decode JSON.
+	jsonCodec struct {
+		marshal   func(interface{}) ([]byte, error)
+		unmarshal func([]byte, interface{}) error
+	}
+)
+
+// defaultCodec encodes & decodes JSON using the encoding/json package.
+var defaultCodec = &jsonCodec{
+	marshal:   json.Marshal,
+	unmarshal: json.Unmarshal,
+}
+
+// JSONCodec sets the functions used to encode & decode JSON by the JSON response helper
+// and Request.Bind, allowing alternative encoders to be used, or the behaviour of decoding
+// to be changed, such as rejecting unknown fields. Either function may be nil, in which
+// case the encoding/json package is used. By default, the encoding/json package is used
+// for both.
+func (r *Router) JSONCodec(marshal func(interface{}) ([]byte, error), unmarshal func([]byte, interface{}) error) *Router {
+	codec := &jsonCodec{marshal: marshal, unmarshal: unmarshal}
+
+	if codec.marshal == nil {
+		codec.marshal = json.Marshal
+	}
+
+	if codec.unmarshal == nil {
+		codec.unmarshal = json.Unmarshal
+	}
+
+	r.codec = codec
+
+	return r
+}
+
+// codecOrDefault returns the given codec, or the default codec if it is nil.
+func codecOrDefault(codec *jsonCodec) *jsonCodec {
+	if codec == nil {
+		return defaultCodec
+	}
+
+	return codec
+}
+
+// codec returns the JSON codec of the router handling the request.
+func (r *Request) codec() *jsonCodec {
+	if r.router == nil {
+		return defaultCodec
+	}
+
+	return codecOrDefault(r.router.codec)
+}
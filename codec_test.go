@@ -0,0 +1,87 @@
+package lux_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/davidsbond/lux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouter_JSONCodec(t *testing.T) {
+	t.Parallel()
+
+	strict := func(data []byte, v interface{}) error {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+
+		return dec.Decode(v)
+	}
+
+	indent := func(v interface{}) ([]byte, error) {
+		return json.MarshalIndent(v, "", "  ")
+	}
+
+	tt := []struct {
+		Marshal        func(interface{}) ([]byte, error)
+		Unmarshal      func([]byte, interface{}) error
+		Body           string
+		ExpectedStatus int
+		ExpectedBody   string
+	}{
+		// Scenario 1: Router uses the default codec
+		{
+			Body:           `{"name":"test","age":42}`,
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   `{"name":"test"}`,
+		},
+		// Scenario 2: Router uses a decoder that rejects unknown fields
+		{
+			Unmarshal:      strict,
+			Body:           `{"name":"test","age":42}`,
+			ExpectedStatus: http.StatusBadRequest,
+			ExpectedBody:   `"failed to decode request body, json: unknown field \"age\""`,
+		},
+		// Scenario 3: Router uses a custom encoder
+		{
+			Marshal:        indent,
+			Body:           `{"name":"test"}`,
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   "{\n  \"name\": \"test\"\n}",
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router with a JSON codec
+		router := lux.NewRouter().JSONCodec(tc.Marshal, tc.Unmarshal)
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has a handler that binds the request body
+		router.Handler("POST", func(w lux.ResponseWriter, r *lux.Request) {
+			var user struct {
+				Name string `json:"name"`
+			}
+
+			if err := r.Bind(&user); err != nil {
+				lux.JSON(w, http.StatusBadRequest, err.Error())
+				return
+			}
+
+			lux.JSON(w, http.StatusOK, user)
+		})
+
+		// WHEN we perform a request
+		resp, _ := router.ServeHTTP(context.Background(), lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{HTTPMethod: "POST", Body: tc.Body},
+		})
+
+		// THEN the response should be encoded & decoded using the codec
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+		assert.Equal(t, tc.ExpectedBody, resp.Body)
+	}
+}
@@ -0,0 +1,121 @@
+package lux
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"mime"
+	"strconv"
+	"strings"
+)
+
+type (
+	// The CompressionOptions type contains configuration for response compression.
+	CompressionOptions struct {
+		// MinSize is the minimum size, in bytes, a response body must be before it
+		// is compressed.
+		MinSize int
+
+		// ContentTypes contains the media types that can be compressed. Wildcards can
+		// be used for subtypes, such as "text/*". Defaults to common text based media
+		// types.
+		ContentTypes []string
+	}
+)
+
+// defaultCompressibleTypes contains the media types that are compressed when no content
+// types are specified in the compression options.
+var defaultCompressibleTypes = []string{
+	"text/*",
+	"application/json",
+	"application/javascript",
+	"application/xml",
+	"image/svg+xml",
+}
+
+// Compression enables gzip compression of response bodies for requests whose
+// Accept-Encoding header allows it. Compressed bodies are base64 encoded as required
+// by the API Gateway. Responses that are already encoded, are smaller than the
+// minimum size or do not have a compressible content type are not compressed.
+func (r *Router) Compression(opts CompressionOptions) *Router {
+	if len(opts.ContentTypes) == 0 {
+		opts.ContentTypes = defaultCompressibleTypes
+	}
+
+	r.compression = &opts
+
+	return r
+}
+
+// compress gzips the response body if the request & response allow it. Accept-Encoding is
+// added to the Vary header of any response that can be compressed, regardless of whether the
+// request allows it, so that caches store compressed & uncompressed responses separately.
+func (opts *CompressionOptions) compress(req Request, resp Response) Response {
+	if resp.IsBase64Encoded || len(resp.Body) < opts.MinSize {
+		return resp
+	}
+
+	if resp.header("Content-Encoding") != "" || !opts.compressible(resp.header("Content-Type")) {
+		return resp
+	}
+
+	if vary := appendVary(resp.header("Vary"), "Accept-Encoding"); vary != "" {
+		resp.setHeader("Vary", vary)
+	}
+
+	if !acceptsGzip(req.header("Accept-Encoding")) {
+		return resp
+	}
+
+	buf := bytes.NewBuffer([]byte{})
+	gz := gzip.NewWriter(buf)
+
+	if _, err := gz.Write([]byte(resp.Body)); err != nil {
+		return resp
+	}
+
+	if err := gz.Close(); err != nil {
+		return resp
+	}
+
+	resp.Body = base64.StdEncoding.EncodeToString(buf.Bytes())
+	resp.IsBase64Encoded = true
+	resp.setHeader("Content-Encoding", "gzip")
+	resp.setHeader("Content-Length", strconv.Itoa(buf.Len()))
+
+	return resp
+}
+
+// compressible determines if the given content type can be compressed.
+func (opts *CompressionOptions) compressible(contentType string) bool {
+	media, _, err := mime.ParseMediaType(contentType)
+
+	if err != nil {
+		return false
+	}
+
+	for _, allowed := range opts.ContentTypes {
+		if allowed == media || (strings.HasSuffix(allowed, "/*") && strings.HasPrefix(media, strings.TrimSuffix(allowed, "*"))) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// acceptsGzip determines if the given Accept-Encoding header allows gzip encoding. An explicit
+// gzip entry takes precedence over a wildcard, so "gzip;q=0, *" does not allow gzip.
+func acceptsGzip(acceptEncoding string) bool {
+	wildcard := false
+
+	for _, enc := range parseAccept(acceptEncoding) {
+		switch enc.media {
+		case "gzip":
+			return enc.quality > 0
+		case "*":
+			wildcard = enc.quality > 0
+		}
+	}
+
+	return wildcard
+}
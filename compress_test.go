@@ -0,0 +1,127 @@
+package lux_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/davidsbond/lux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouter_Compression(t *testing.T) {
+	t.Parallel()
+
+	body := strings.Repeat("hello test ", 100)
+
+	tt := []struct {
+		AcceptEncoding     string
+		ContentType        string
+		Body               string
+		ExpectedCompressed bool
+		ExpectedVary       string
+	}{
+		// Scenario 1: Client accepts gzip
+		{
+			AcceptEncoding:     "gzip, deflate",
+			ContentType:        "text/plain",
+			Body:               body,
+			ExpectedCompressed: true,
+			ExpectedVary:       "Accept-Encoding",
+		},
+		// Scenario 2: Client does not accept gzip
+		{
+			AcceptEncoding: "deflate",
+			ContentType:    "text/plain",
+			Body:           body,
+			ExpectedVary:   "Accept-Encoding",
+		},
+		// Scenario 3: Body is smaller than the threshold
+		{
+			AcceptEncoding: "gzip",
+			ContentType:    "text/plain",
+			Body:           "hello test",
+		},
+		// Scenario 4: Content type is not compressible
+		{
+			AcceptEncoding: "gzip",
+			ContentType:    "application/x-www-form-urlencoded",
+			Body:           body,
+		},
+		// Scenario 5: Client explicitly rejects gzip
+		{
+			AcceptEncoding: "gzip;q=0",
+			ContentType:    "application/json",
+			Body:           body,
+			ExpectedVary:   "Accept-Encoding",
+		},
+		// Scenario 6: Client explicitly rejects gzip but accepts any other encoding
+		{
+			AcceptEncoding: "gzip;q=0, *",
+			ContentType:    "application/json",
+			Body:           body,
+			ExpectedVary:   "Accept-Encoding",
+		},
+		// Scenario 7: Client accepts any encoding
+		{
+			AcceptEncoding:     "*",
+			ContentType:        "application/json",
+			Body:               body,
+			ExpectedCompressed: true,
+			ExpectedVary:       "Accept-Encoding",
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router with compression enabled
+		router := lux.NewRouter().Compression(lux.CompressionOptions{MinSize: 100})
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has a handler registered
+		router.Handler("GET", func(w lux.ResponseWriter, r *lux.Request) {
+			w.Header().Set("Content-Type", tc.ContentType)
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(tc.Body))
+		})
+
+		// WHEN we perform the request
+		resp, _ := router.ServeHTTP(context.Background(), lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+				HTTPMethod: "GET",
+				Headers:    map[string]string{"Accept-Encoding": tc.AcceptEncoding},
+			},
+		})
+
+		// THEN the response should only be compressed when we expect
+		assert.Equal(t, tc.ExpectedCompressed, resp.IsBase64Encoded)
+
+		// AND responses that can be compressed should vary by the accepted encoding
+		assert.Equal(t, tc.ExpectedVary, resp.Headers["Vary"])
+
+		if !tc.ExpectedCompressed {
+			assert.Equal(t, tc.Body, resp.Body)
+			assert.Empty(t, resp.Headers["Content-Encoding"])
+			continue
+		}
+
+		// AND compressed responses should decode to the original body
+		assert.Equal(t, "gzip", resp.Headers["Content-Encoding"])
+
+		data, err := base64.StdEncoding.DecodeString(resp.Body)
+		assert.Nil(t, err)
+
+		gz, err := gzip.NewReader(bytes.NewReader(data))
+		assert.Nil(t, err)
+
+		actual, err := io.ReadAll(gz)
+		assert.Nil(t, err)
+		assert.Equal(t, tc.Body, string(actual))
+	}
+}
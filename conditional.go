@@ -0,0 +1,15 @@
+package lux
+
+// Unless wraps a middleware function so that it is skipped for requests matching the given
+// predicate, such as skipping authentication for a health check route. The predicate is called
+// once the request has been routed, so it can match on the route pattern using
+// Request.RoutePattern, as well as the method, path or headers of the request.
+func Unless(mw HandlerFunc, skip func(*Request) bool) HandlerFunc {
+	return func(w ResponseWriter, r *Request) {
+		if skip(r) {
+			return
+		}
+
+		mw(w, r)
+	}
+}
@@ -0,0 +1,69 @@
+package lux_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/davidsbond/lux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnless(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Skip           func(*lux.Request) bool
+		Path           string
+		ExpectedStatus int
+	}{
+		// Scenario 1: Request does not match the predicate
+		{
+			Skip:           func(r *lux.Request) bool { return r.RoutePattern() == "/health" },
+			Path:           "/users/42",
+			ExpectedStatus: http.StatusUnauthorized,
+		},
+		// Scenario 2: Request matches the predicate on the route pattern
+		{
+			Skip:           func(r *lux.Request) bool { return r.RoutePattern() == "/health" },
+			Path:           "/health",
+			ExpectedStatus: http.StatusOK,
+		},
+		// Scenario 3: Request matches the predicate on a header
+		{
+			Skip:           func(r *lux.Request) bool { return r.Header("X-Internal") == "true" },
+			Path:           "/users/42",
+			ExpectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router
+		router := lux.NewRouter()
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has a middleware that can be skipped
+		router.Middleware(lux.Unless(func(w lux.ResponseWriter, r *lux.Request) {
+			lux.JSON(w, http.StatusUnauthorized, "unauthorized")
+		}, tc.Skip))
+
+		// AND that router has handlers registered
+		router.Handler("GET", getHandler).Path("/health")
+		router.Handler("GET", getHandler).Path("/users/{id}")
+
+		// WHEN we perform a request
+		resp, _ := router.ServeHTTP(context.Background(), lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+				HTTPMethod: "GET",
+				Path:       tc.Path,
+				Headers:    map[string]string{"X-Internal": "true"},
+			},
+		})
+
+		// THEN the middleware should only run when the predicate does not match
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+	}
+}
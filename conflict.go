@@ -0,0 +1,126 @@
+package lux
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Err returns an error describing any routes with an invalid path pattern or schema, or that
+// conflict with a route registered before them, along with any route names or WebSocket route
+// keys registered more than once and any invalid trusted proxies, or nil if there are none.
+// Routes conflict when they handle the same method for the same path, differing only in the
+// names of path parameters, and have the same header, query & media type requirements. Only
+// the first of the conflicting routes is ever used. Routes that use HeaderFunc, HeaderMatch,
+// QueryFunc or QueryMatch never conflict, as their requirements cannot be compared. Call Err
+// once all routes are registered to catch mistakes during start up rather than when handling
+// requests.
+func (r *Router) Err() error {
+	seen := make(map[string]*Route)
+	var conflicts []string
+
+	for _, route := range r.routes {
+		if route.path != nil && route.path.err != nil {
+			conflicts = append(conflicts, route.path.err.Error())
+			continue
+		}
+
+		if route.schema != nil && route.schema.err != nil {
+			conflicts = append(conflicts, fmt.Sprintf("invalid schema for %s, %v", patternOrAny(route), route.schema.err))
+		}
+
+		if len(route.matchers) > 0 {
+			continue
+		}
+
+		for _, method := range route.methods {
+			key := method + " " + route.signature()
+
+			if existing, ok := seen[key]; ok {
+				conflicts = append(conflicts, fmt.Sprintf("%s %s conflicts with %s %s",
+					method, patternOrAny(route), method, patternOrAny(existing)))
+				continue
+			}
+
+			seen[key] = route
+		}
+	}
+
+	conflicts = append(conflicts, r.duplicates...)
+
+	var failures []string
+
+	if len(conflicts) > 0 {
+		failures = append(failures, "failed to register routes, "+strings.Join(conflicts, ", "))
+	}
+
+	if len(r.invalidProxies) > 0 {
+		failures = append(failures, "failed to configure trusted proxies, invalid proxies "+strings.Join(r.invalidProxies, ", "))
+	}
+
+	if len(failures) > 0 {
+		return errors.New(strings.Join(failures, "; "))
+	}
+
+	return nil
+}
+
+// signature returns a description of the requests the route can handle, independent of its
+// method. Routes with the same signature handle the same requests.
+func (r *Route) signature() string {
+	var sig strings.Builder
+
+	sig.WriteString(r.shape())
+	writePairs(&sig, "headers", r.headers)
+	writePairs(&sig, "queries", r.queries)
+
+	accepts := append([]string(nil), r.accepts...)
+	sort.Strings(accepts)
+
+	sig.WriteString(" accepts:" + strings.Join(accepts, ","))
+
+	return sig.String()
+}
+
+// shape returns the path pattern of the route with its parameter names removed, as routes that
+// differ only in the names of their parameters match the same paths. Routes without a path
+// return "*".
+func (r *Route) shape() string {
+	if r.path == nil {
+		return "*"
+	}
+
+	parts := make([]string, len(r.path.segments))
+
+	for i, seg := range r.path.segments {
+		switch {
+		case seg.catchAll:
+			parts[i] = "{...}"
+		case seg.param:
+			parts[i] = "{}"
+		default:
+			parts[i] = seg.value
+		}
+	}
+
+	return "/" + strings.Join(parts, "/")
+}
+
+// writePairs writes the given key/value pairs to the signature, sorted by key.
+func writePairs(sig *strings.Builder, name string, pairs map[string]string) {
+	sig.WriteString(" " + name + ":")
+
+	for _, key := range sortedKeys(pairs) {
+		sig.WriteString(key + "=" + pairs[key] + ",")
+	}
+}
+
+// patternOrAny returns the path pattern of the route, or "*" if the route matches any path.
+func patternOrAny(route *Route) string {
+	if pattern := route.pattern(); pattern != "" {
+		return pattern
+	}
+
+	return "*"
+}
@@ -0,0 +1,148 @@
+package lux_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/davidsbond/lux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouter_Err(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Register      func(router *lux.Router)
+		ExpectedError string
+	}{
+		// Scenario 1: Routes have different paths
+		{
+			Register: func(router *lux.Router) {
+				router.Handler("GET", getHandler).Path("/users")
+				router.Handler("GET", getHandler).Path("/users/{id}")
+			},
+		},
+		// Scenario 2: Routes have the same path with different methods
+		{
+			Register: func(router *lux.Router) {
+				router.Handler("GET", getHandler).Path("/users")
+				router.Handler("POST", getHandler).Path("/users")
+			},
+		},
+		// Scenario 3: Routes have the same path & method
+		{
+			Register: func(router *lux.Router) {
+				router.Handler("GET", getHandler).Path("/users")
+				router.Handler("GET", getHandler).Path("/users")
+			},
+			ExpectedError: "failed to register routes, GET /users conflicts with GET /users",
+		},
+		// Scenario 4: Routes differ only by parameter names
+		{
+			Register: func(router *lux.Router) {
+				router.Handler("GET", getHandler).Path("/users/{id}")
+				router.Handler("GET", getHandler).Path("/users/{name}")
+			},
+			ExpectedError: "failed to register routes, GET /users/{name} conflicts with GET /users/{id}",
+		},
+		// Scenario 5: Routes have the same path & method with different headers
+		{
+			Register: func(router *lux.Router) {
+				router.Handler("POST", getHandler).Path("/users").Headers("Content-Type", "application/json")
+				router.Handler("POST", getHandler).Path("/users").Headers("Content-Type", "application/xml")
+			},
+		},
+		// Scenario 6: Routes have the same headers in a different case
+		{
+			Register: func(router *lux.Router) {
+				router.Handler("POST", getHandler).Path("/users").Headers("content-type", "application/json")
+				router.Handler("POST", getHandler).Path("/users").Headers("Content-Type", "application/json")
+			},
+			ExpectedError: "failed to register routes, POST /users conflicts with POST /users",
+		},
+		// Scenario 7: A route shares one of multiple methods
+		{
+			Register: func(router *lux.Router) {
+				router.Handler("GET", getHandler).Path("/users").Methods("GET", "PUT")
+				router.Handler("PUT", getHandler).Path("/users")
+			},
+			ExpectedError: "failed to register routes, PUT /users conflicts with PUT /users",
+		},
+		// Scenario 8: Routes without a path
+		{
+			Register: func(router *lux.Router) {
+				router.Handler("GET", getHandler)
+				router.Handler("GET", getHandler)
+			},
+			ExpectedError: "failed to register routes, GET * conflicts with GET *",
+		},
+		// Scenario 9: Routes use custom matchers
+		{
+			Register: func(router *lux.Router) {
+				router.Handler("GET", getHandler).Path("/users").QueryFunc("page", func(string) bool { return true })
+				router.Handler("GET", getHandler).Path("/users").QueryFunc("page", func(string) bool { return false })
+			},
+		},
+		// Scenario 10: Routes are registered in a group
+		{
+			Register: func(router *lux.Router) {
+				router.Group("/api").Handler("GET", getHandler).Path("/users")
+				router.Handler("GET", getHandler).Path("/api/users")
+			},
+			ExpectedError: "failed to register routes, GET /api/users conflicts with GET /api/users",
+		},
+		// Scenario 11: Routes differ only by catch-all parameter names
+		{
+			Register: func(router *lux.Router) {
+				router.Handler("GET", getHandler).Path("/files/{id}")
+				router.Handler("GET", getHandler).Path("/files/{path...}")
+				router.Handler("GET", getHandler).Path("/files/{rest...}")
+			},
+			ExpectedError: "failed to register routes, GET /files/{rest...} conflicts with GET /files/{path...}",
+		},
+		// Scenario 12: Catch-all parameter is not the last segment
+		{
+			Register: func(router *lux.Router) {
+				router.Handler("GET", getHandler).Path("/files/{path...}/raw")
+			},
+			ExpectedError: "failed to register routes, catch-all parameter path must be the last segment of /files/{path...}/raw",
+		},
+		// Scenario 13: WebSocket routes have the same route key
+		{
+			Register: func(router *lux.Router) {
+				router.WSRoute("$connect", getHandler)
+				router.WSRoute("sendMessage", getHandler)
+				router.WSRoute("sendMessage", getHandler)
+			},
+			ExpectedError: "failed to register routes, route key sendMessage is registered more than once",
+		},
+		// Scenario 14: Routes are registered with the same name
+		{
+			Register: func(router *lux.Router) {
+				router.Handler("GET", getHandler).Path("/users").Name("users")
+				router.Handler("POST", getHandler).Path("/users").Name("users")
+			},
+			ExpectedError: "failed to register routes, route name users is registered more than once",
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router
+		router := lux.NewRouter()
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// WHEN we register routes
+		tc.Register(router)
+
+		// THEN any conflicting routes should be reported
+		err := router.Err()
+
+		if tc.ExpectedError == "" {
+			assert.NoError(t, err)
+			continue
+		}
+
+		assert.EqualError(t, err, tc.ExpectedError)
+	}
+}
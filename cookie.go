@@ -0,0 +1,43 @@
+package lux
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Cookie returns the named cookie from the request's Cookie header, with its value URL
+// unescaped. If the cookie cannot be found, http.ErrNoCookie is returned.
+func (r *Request) Cookie(name string) (*http.Cookie, error) {
+	header := make(http.Header)
+
+	for key, values := range r.MultiValueHeaders {
+		if strings.EqualFold(key, "Cookie") {
+			header["Cookie"] = append(header["Cookie"], values...)
+		}
+	}
+
+	if len(header["Cookie"]) == 0 {
+		header.Set("Cookie", r.header("Cookie"))
+	}
+
+	cookie, err := (&http.Request{Header: header}).Cookie(name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if value, err := url.QueryUnescape(cookie.Value); err == nil {
+		cookie.Value = value
+	}
+
+	return cookie, nil
+}
+
+// SetCookie adds a Set-Cookie header to the response. Invalid cookies are silently
+// dropped.
+func SetCookie(w ResponseWriter, cookie *http.Cookie) {
+	if value := cookie.String(); value != "" {
+		w.Header().Add("Set-Cookie", value)
+	}
+}
@@ -0,0 +1,101 @@
+package lux_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/davidsbond/lux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequest_Cookie(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Request       lux.Request
+		Name          string
+		ExpectedValue string
+		ExpectedError error
+	}{
+		// Scenario 1: Cookie in a header with multiple cookies
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					Headers: map[string]string{"cookie": "a=1; session=abc"},
+				},
+			},
+			Name:          "session",
+			ExpectedValue: "abc",
+		},
+		// Scenario 2: Cookie with an escaped value
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					Headers: map[string]string{"Cookie": "name=hello%20test"},
+				},
+			},
+			Name:          "name",
+			ExpectedValue: "hello test",
+		},
+		// Scenario 3: Cookie in multi-value headers
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					MultiValueHeaders: map[string][]string{"Cookie": {"a=1", "b=2"}},
+				},
+			},
+			Name:          "b",
+			ExpectedValue: "2",
+		},
+		// Scenario 4: Missing cookie
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					Headers: map[string]string{"Cookie": "a=1"},
+				},
+			},
+			Name:          "session",
+			ExpectedError: http.ErrNoCookie,
+		},
+	}
+
+	for _, tc := range tt {
+		// WHEN we obtain the cookie
+		cookie, err := tc.Request.Cookie(tc.Name)
+
+		// THEN any errors should be what we expect
+		assert.Equal(t, tc.ExpectedError, err)
+
+		// AND the cookie value should be what we expect
+		if err == nil {
+			assert.Equal(t, tc.ExpectedValue, cookie.Value)
+		}
+	}
+}
+
+func TestSetCookie(t *testing.T) {
+	t.Parallel()
+
+	// GIVEN that we have a router
+	router := lux.NewRouter()
+	router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+	// AND that router has a handler that sets cookies
+	router.Handler("GET", func(w lux.ResponseWriter, r *lux.Request) {
+		lux.SetCookie(w, &http.Cookie{Name: "a", Value: "1", HttpOnly: true})
+		lux.SetCookie(w, &http.Cookie{Name: "b", Value: "2"})
+		w.WriteHeader(http.StatusOK)
+	})
+
+	// WHEN we perform a request
+	resp, _ := router.ServeHTTP(context.Background(), lux.Request{
+		APIGatewayProxyRequest: events.APIGatewayProxyRequest{HTTPMethod: "GET"},
+	})
+
+	// THEN each cookie should be a distinct Set-Cookie header
+	assert.Equal(t, []string{"a=1; HttpOnly", "b=2"}, resp.MultiValueHeaders["Set-Cookie"])
+}
@@ -0,0 +1,121 @@
+package lux
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	// The CORSOptions type contains configuration for the CORS middleware.
+	CORSOptions struct {
+		// AllowedOrigins contains the origins that may make cross-origin requests. Use
+		// "*" to allow any origin.
+		AllowedOrigins []string
+
+		// AllowedMethods contains the methods clients may use for cross-origin requests.
+		// Defaults to GET, HEAD & POST.
+		AllowedMethods []string
+
+		// AllowedHeaders contains the headers clients may use for cross-origin requests.
+		// When empty, the headers requested in a preflight request are allowed.
+		AllowedHeaders []string
+
+		// ExposedHeaders contains the response headers clients are allowed to access.
+		ExposedHeaders []string
+
+		// MaxAge determines how long the results of a preflight request can be cached.
+		MaxAge time.Duration
+
+		// AllowCredentials determines whether or not requests can include credentials
+		// such as cookies.
+		AllowCredentials bool
+	}
+)
+
+// CORS creates a middleware function that sets cross-origin resource sharing headers on
+// responses. Preflight requests are responded to with a 204 and prevent execution of any
+// further middleware & the handler. When registered as global middleware, preflight
+// requests are handled automatically as the router responds to OPTIONS requests for
+// paths without an OPTIONS handler. Unless any origin is allowed without credentials,
+// Origin is added to the Vary header of every response, as the CORS headers then depend
+// on the origin of the request. If you register it as route specific middleware, it
+// should also be registered for an OPTIONS handler, which responds to OPTIONS requests
+// that are not preflight requests:
+//
+//	cors := lux.CORS(opts)
+//
+//	router.Handler("GET", handler).Path("/users").Middleware(cors)
+//	router.Handler("OPTIONS", func(w lux.ResponseWriter, r *lux.Request) {
+//		lux.NoContent(w)
+//	}).Path("/users").Middleware(cors)
+func CORS(opts CORSOptions) HandlerFunc {
+	methods := opts.AllowedMethods
+	wildcard := opts.allowsOrigin("*")
+	varies := !wildcard || opts.AllowCredentials
+
+	if len(methods) == 0 {
+		methods = []string{http.MethodGet, http.MethodHead, http.MethodPost}
+	}
+
+	return func(w ResponseWriter, r *Request) {
+		origin := r.header("Origin")
+		preflight := r.HTTPMethod == http.MethodOptions && r.header("Access-Control-Request-Method") != ""
+
+		headers := w.Header()
+
+		// Caches must not reuse a response for other origins, including those that
+		// are not allowed, when the headers depend on the origin.
+		if varies {
+			headers.addVary("Origin")
+		}
+
+		if origin != "" && opts.allowsOrigin(origin) {
+			// Wildcards cannot be used with credentials, so echo the origin instead.
+			if wildcard && !opts.AllowCredentials {
+				headers.Set("Access-Control-Allow-Origin", "*")
+			} else {
+				headers.Set("Access-Control-Allow-Origin", origin)
+			}
+
+			if opts.AllowCredentials {
+				headers.Set("Access-Control-Allow-Credentials", "true")
+			}
+
+			if len(opts.ExposedHeaders) > 0 && !preflight {
+				headers.Set("Access-Control-Expose-Headers", strings.Join(opts.ExposedHeaders, ", "))
+			}
+
+			if preflight {
+				headers.Set("Access-Control-Allow-Methods", strings.Join(methods, ", "))
+
+				if len(opts.AllowedHeaders) > 0 {
+					headers.Set("Access-Control-Allow-Headers", strings.Join(opts.AllowedHeaders, ", "))
+				} else if requested := r.header("Access-Control-Request-Headers"); requested != "" {
+					headers.Set("Access-Control-Allow-Headers", requested)
+				}
+
+				if opts.MaxAge > 0 {
+					headers.Set("Access-Control-Max-Age", strconv.Itoa(int(opts.MaxAge.Seconds())))
+				}
+			}
+		}
+
+		// Preflight requests do not need to reach the handler.
+		if preflight {
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}
+}
+
+// allowsOrigin determines if the given origin is allowed to make cross-origin requests.
+func (opts CORSOptions) allowsOrigin(origin string) bool {
+	for _, allowed := range opts.AllowedOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,201 @@
+package lux_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/davidsbond/lux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCORS(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Options         lux.CORSOptions
+		Request         lux.Request
+		ExpectedStatus  int
+		ExpectedHeaders map[string]string
+	}{
+		// Scenario 1: Request from any origin
+		{
+			Options: lux.CORSOptions{AllowedOrigins: []string{"*"}},
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					HTTPMethod: "GET",
+					Headers:    map[string]string{"Origin": "https://example.com"},
+				},
+			},
+			ExpectedStatus: http.StatusOK,
+			ExpectedHeaders: map[string]string{
+				"Access-Control-Allow-Origin": "*",
+				"Vary":                        "",
+			},
+		},
+		// Scenario 2: Request from a specific origin with credentials
+		{
+			Options: lux.CORSOptions{
+				AllowedOrigins:   []string{"https://example.com"},
+				ExposedHeaders:   []string{"X-Custom"},
+				AllowCredentials: true,
+			},
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					HTTPMethod: "GET",
+					Headers:    map[string]string{"origin": "https://example.com"},
+				},
+			},
+			ExpectedStatus: http.StatusOK,
+			ExpectedHeaders: map[string]string{
+				"Access-Control-Allow-Origin":      "https://example.com",
+				"Access-Control-Allow-Credentials": "true",
+				"Access-Control-Expose-Headers":    "X-Custom",
+				"Vary":                             "Origin",
+			},
+		},
+		// Scenario 3: Request from a disallowed origin
+		{
+			Options: lux.CORSOptions{AllowedOrigins: []string{"https://example.com"}},
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					HTTPMethod: "GET",
+					Headers:    map[string]string{"Origin": "https://other.com"},
+				},
+			},
+			ExpectedStatus: http.StatusOK,
+			ExpectedHeaders: map[string]string{
+				"Access-Control-Allow-Origin": "",
+				"Vary":                        "Origin",
+			},
+		},
+		// Scenario 4: Preflight request
+		{
+			Options: lux.CORSOptions{
+				AllowedOrigins: []string{"*"},
+				AllowedMethods: []string{"GET", "PUT"},
+				MaxAge:         time.Hour,
+			},
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					HTTPMethod: "OPTIONS",
+					Headers: map[string]string{
+						"Origin":                         "https://example.com",
+						"Access-Control-Request-Method":  "PUT",
+						"Access-Control-Request-Headers": "Content-Type",
+					},
+				},
+			},
+			ExpectedStatus: http.StatusNoContent,
+			ExpectedHeaders: map[string]string{
+				"Access-Control-Allow-Origin":  "*",
+				"Access-Control-Allow-Methods": "GET, PUT",
+				"Access-Control-Allow-Headers": "Content-Type",
+				"Access-Control-Max-Age":       "3600",
+			},
+		},
+		// Scenario 5: Request without an origin when specific origins are allowed
+		{
+			Options: lux.CORSOptions{AllowedOrigins: []string{"https://example.com"}},
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					HTTPMethod: "GET",
+				},
+			},
+			ExpectedStatus: http.StatusOK,
+			ExpectedHeaders: map[string]string{
+				"Access-Control-Allow-Origin": "",
+				"Vary":                        "Origin",
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router
+		router := lux.NewRouter()
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router uses the CORS middleware
+		cors := lux.CORS(tc.Options)
+		router.Middleware(cors)
+
+		// AND that router has a handler registered
+		router.Handler("GET", getHandler)
+
+		// WHEN we perform the request
+		resp, _ := router.ServeHTTP(context.Background(), tc.Request)
+
+		// THEN the status code & headers should be what we expect
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+
+		for key, value := range tc.ExpectedHeaders {
+			assert.Equal(t, value, resp.Headers[key])
+		}
+	}
+}
+
+func TestCORS_RouteSpecific(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Headers         map[string]string
+		ExpectedStatus  int
+		ExpectedHeaders map[string]string
+	}{
+		// Scenario 1: Preflight request
+		{
+			Headers: map[string]string{
+				"Origin":                        "https://example.com",
+				"Access-Control-Request-Method": "GET",
+			},
+			ExpectedStatus: http.StatusNoContent,
+			ExpectedHeaders: map[string]string{
+				"Access-Control-Allow-Origin":  "https://example.com",
+				"Access-Control-Allow-Methods": "GET, HEAD, POST",
+			},
+		},
+		// Scenario 2: OPTIONS request that is not a preflight request
+		{
+			Headers:        map[string]string{"Origin": "https://example.com"},
+			ExpectedStatus: http.StatusNoContent,
+			ExpectedHeaders: map[string]string{
+				"Access-Control-Allow-Origin":  "https://example.com",
+				"Access-Control-Allow-Methods": "",
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router
+		router := lux.NewRouter()
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router uses the CORS middleware for specific routes
+		cors := lux.CORS(lux.CORSOptions{AllowedOrigins: []string{"https://example.com"}})
+
+		router.Handler("GET", getHandler).Path("/users").Middleware(cors)
+		router.Handler("OPTIONS", func(w lux.ResponseWriter, r *lux.Request) {
+			lux.NoContent(w)
+		}).Path("/users").Middleware(cors)
+
+		// WHEN we perform an OPTIONS request
+		resp, _ := router.ServeHTTP(context.Background(), lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+				HTTPMethod: "OPTIONS",
+				Path:       "/users",
+				Headers:    tc.Headers,
+			},
+		})
+
+		// THEN the status code & headers should be what we expect
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+
+		for key, value := range tc.ExpectedHeaders {
+			assert.Equal(t, value, resp.Headers[key])
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package lux
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Debug enables logging of why requests did not match any route. When a request results
+// in a 404, 405, 406 or 415 response, a debug level log is written describing the routes
+// that were considered and which of their matchers failed. This is intended for use
+// during development and is disabled by default to avoid noisy logs. Enabling debugging
+// raises the default logger, including one set using Router.Logging, to the debug level.
+// Loggers set using Router.Logger must be configured to write debug logs themselves.
+func (r *Router) Debug(debug bool) *Router {
+	r.debug = debug
+	r.setLogLevel()
+
+	return r
+}
+
+// setLogLevel sets the level of the default logger so that debug logs are only written when
+// debugging is enabled.
+func (r *Router) setLogLevel() {
+	if r.defaultLog == nil {
+		return
+	}
+
+	r.defaultLog.Level = logrus.InfoLevel
+
+	if r.debug {
+		r.defaultLog.Level = logrus.DebugLevel
+	}
+}
+
+// logMismatch writes a debug log describing why the request did not match any route.
+func (r *Router) logMismatch(req Request, match routeMatch, err error) {
+	fields := Fields{
+		"requestId": req.RequestContext.RequestID,
+		"method":    req.HTTPMethod,
+		"path":      req.Path,
+		"error":     err.Error(),
+	}
+
+	switch err {
+	case errNotFound:
+		fields["reason"] = "no route matches the path"
+	case errNotAllowed:
+		fields["reason"] = "no route matches the method"
+		fields["allowed"] = match.allowed
+	default:
+		fields["reason"] = "no route matches the headers, query parameters or media types"
+		fields["routes"] = r.mismatches(req)
+	}
+
+	r.log.Debug("request did not match any routes", fields)
+}
+
+// mismatches describes the matchers that failed for each route with a path & method matching
+// the request.
+func (r *Router) mismatches(req Request) []string {
+	var out []string
+
+	for _, route := range r.index().lookup(req.Path) {
+		if _, ok := route.matchPath(req.Path); !ok || !route.handlesMethod(req.HTTPMethod) {
+			continue
+		}
+
+		name := req.HTTPMethod + " " + patternOrAny(route)
+
+		for _, reason := range route.mismatches(req) {
+			out = append(out, name+": "+reason)
+		}
+	}
+
+	return out
+}
+
+// handlesMethod determines if the route handles requests with the given method, including GET
+// routes that handle HEAD requests and routes that handle any method.
+func (r *Route) handlesMethod(method string) bool {
+	for _, m := range r.methods {
+		if m == method || m == MethodAny || (m == http.MethodGet && !r.noHead && method == http.MethodHead) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// mismatches describes each of the route's matchers that the request does not satisfy.
+func (r *Route) mismatches(req Request) []string {
+	var out []string
+
+	for _, key := range sortedKeys(r.headers) {
+		// Header values are left out as they may contain credentials.
+		if value, ok := req.matchHeader(key); !matchValue(r.headers[key], value, ok) {
+			out = append(out, fmt.Sprintf("header %s does not match", key))
+		}
+	}
+
+	for _, key := range sortedKeys(r.queries) {
+		if values := req.QueryValues(key); !matchAny(r.queries[key], values) {
+			out = append(out, fmt.Sprintf("query parameter %s expected %q, got %q", key, r.queries[key], strings.Join(values, ",")))
+		}
+	}
+
+	for i, match := range r.matchers {
+		if err := match(&req); err != nil {
+			out = append(out, fmt.Sprintf("matcher %d failed, %v", i, err))
+		}
+	}
+
+	if len(r.accepts) > 0 && req.Negotiate(r.accepts...) == "" {
+		out = append(out, fmt.Sprintf("accept header does not allow %s", strings.Join(r.accepts, ", ")))
+	}
+
+	return out
+}
+
+// sortedKeys returns the keys of the given map in sorted order.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
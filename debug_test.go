@@ -0,0 +1,179 @@
+package lux_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/davidsbond/lux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouter_Debug(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Debug          bool
+		Request        events.APIGatewayProxyRequest
+		ExpectedLogged bool
+		ExpectedReason string
+		ExpectedFields lux.Fields
+	}{
+		// Scenario 1: Request does not match any path
+		{
+			Debug:          true,
+			Request:        events.APIGatewayProxyRequest{HTTPMethod: "GET", Path: "/orders"},
+			ExpectedLogged: true,
+			ExpectedReason: "no route matches the path",
+		},
+		// Scenario 2: Request does not match any method
+		{
+			Debug:          true,
+			Request:        events.APIGatewayProxyRequest{HTTPMethod: "DELETE", Path: "/users"},
+			ExpectedLogged: true,
+			ExpectedReason: "no route matches the method",
+			ExpectedFields: lux.Fields{"allowed": []string{"GET", "HEAD", "POST", "OPTIONS"}},
+		},
+		// Scenario 3: Request does not match the headers & query parameters
+		{
+			Debug: true,
+			Request: events.APIGatewayProxyRequest{
+				HTTPMethod:            "GET",
+				Path:                  "/users",
+				Headers:               map[string]string{"X-Version": "1", "Accept": "text/html"},
+				QueryStringParameters: map[string]string{"page": "two"},
+			},
+			ExpectedLogged: true,
+			ExpectedReason: "no route matches the headers, query parameters or media types",
+			ExpectedFields: lux.Fields{"routes": []string{
+				"GET /users: header X-Version does not match",
+				`GET /users: query parameter page expected "1", got "two"`,
+				"GET /users: accept header does not allow application/json",
+			}},
+		},
+		// Scenario 4: Request does not match the content type
+		{
+			Debug: true,
+			Request: events.APIGatewayProxyRequest{
+				HTTPMethod: "POST",
+				Path:       "/users",
+				Headers:    map[string]string{"Content-Type": "text/plain"},
+			},
+			ExpectedLogged: true,
+			ExpectedReason: "no route matches the headers, query parameters or media types",
+			ExpectedFields: lux.Fields{"routes": []string{
+				"POST /users: matcher 0 failed, unsupported media type",
+			}},
+		},
+		// Scenario 5: Debugging is disabled
+		{
+			Request: events.APIGatewayProxyRequest{HTTPMethod: "GET", Path: "/orders"},
+		},
+	}
+
+	for _, tc := range tt {
+		log := &recordingLogger{}
+
+		// GIVEN that we have a router with debugging enabled
+		router := lux.NewRouter().Logger(log).Debug(tc.Debug)
+
+		// AND that router has handlers with matchers
+		router.Handler("GET", getHandler).
+			Path("/users").
+			Headers("X-Version", "2").
+			Queries("page", "1").
+			Accepts("application/json")
+
+		router.Handler("POST", getHandler).
+			Path("/users").
+			HeaderFunc("Content-Type", func(v string) bool { return v == "application/json" })
+
+		// WHEN we perform a request that does not match any route
+		router.ServeHTTP(context.Background(), lux.Request{APIGatewayProxyRequest: tc.Request})
+
+		var logged *entry
+
+		for i, e := range log.entries {
+			if e.Message == "request did not match any routes" {
+				logged = &log.entries[i]
+			}
+		}
+
+		// THEN the mismatch should only be logged when we expect
+		assert.Equal(t, tc.ExpectedLogged, logged != nil)
+
+		if logged == nil {
+			continue
+		}
+
+		// AND it should describe why the request did not match
+		assert.Equal(t, "debug", logged.Level)
+		assert.Equal(t, tc.ExpectedReason, logged.Fields["reason"])
+
+		for key, value := range tc.ExpectedFields {
+			assert.Equal(t, value, logged.Fields[key])
+		}
+	}
+}
+
+func TestRouter_DebugLogging(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Debug          bool
+		DebugFirst     bool
+		ExpectedLogged bool
+	}{
+		// Scenario 1: Debugging is enabled after the logging output is set
+		{
+			Debug:          true,
+			ExpectedLogged: true,
+		},
+		// Scenario 2: Debugging is enabled before the logging output is set
+		{
+			Debug:          true,
+			DebugFirst:     true,
+			ExpectedLogged: true,
+		},
+		// Scenario 3: Debugging is disabled
+		{},
+	}
+
+	for _, tc := range tt {
+		out := bytes.NewBuffer([]byte{})
+
+		// GIVEN that we have a router using the default logger
+		router := lux.NewRouter()
+
+		if tc.DebugFirst {
+			router.Debug(tc.Debug)
+		}
+
+		router.Logging(out, &logrus.JSONFormatter{})
+
+		if !tc.DebugFirst {
+			router.Debug(tc.Debug)
+		}
+
+		// AND that router has a handler that requires a header
+		router.Handler("GET", getHandler).Path("/users").Headers("Authorization", "Bearer token")
+
+		// WHEN we perform a request that does not match the route
+		router.ServeHTTP(context.Background(), lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+				HTTPMethod: "GET",
+				Path:       "/users",
+				Headers:    map[string]string{"Authorization": "Bearer secret"},
+			},
+		})
+
+		// THEN the mismatch should only be logged when debugging is enabled
+		assert.Equal(t, tc.ExpectedLogged, strings.Contains(out.String(), "request did not match any routes"))
+
+		// AND the value of the header should never be logged
+		assert.NotContains(t, out.String(), "secret")
+	}
+}
@@ -0,0 +1,101 @@
+package lux
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+type (
+	// The ServeError type is returned by ServeHTTP when Router.ReturnErrors is enabled and the
+	// request resulted in a framework-level failure, such as when no route matched the request,
+	// a handler panicked or timed out, or no response was written. Responses with error status
+	// codes written by handlers & middleware do not result in a ServeError.
+	ServeError struct {
+		// StatusCode contains the status code of the response returned alongside the error.
+		StatusCode int
+
+		// Err contains the underlying failure, such as the value passed to panic.
+		Err error
+	}
+
+	// The ErrorHandlerFunc type defines what a handler function that returns an error should
+	// look like. See Router.HandlerE.
+	ErrorHandlerFunc func(ResponseWriter, *Request) error
+
+	// The HTTPError type is an error that can be returned by an ErrorHandlerFunc to describe
+	// the status code of the response it should result in.
+	HTTPError struct {
+		// StatusCode contains the status code of the response.
+		StatusCode int
+
+		// Err contains the underlying error.
+		Err error
+	}
+)
+
+// ReturnErrors makes ServeHTTP return a ServeError for requests that result in a framework-level
+// failure. By default, ServeHTTP always returns a nil error, because the lambda runtime discards
+// the response when an error is returned & API Gateway responds with a 502 instead. Only enable
+// this when ServeHTTP is called directly, such as from tests or custom invokers that branch on
+// the error.
+func (r *Router) ReturnErrors() *Router {
+	r.returnErrors = true
+
+	return r
+}
+
+// Error returns the description of the failure.
+func (e *ServeError) Error() string {
+	return fmt.Sprintf("failed to serve request, %v", e.Err)
+}
+
+// Unwrap returns the underlying failure.
+func (e *ServeError) Unwrap() error {
+	return e.Err
+}
+
+// HandlerE adds a handler that returns an error to the router, which is otherwise the same as
+// Router.Handler. When the handler returns a non-nil error, the response is rendered by the
+// router's error handler. The status code is taken from the error if it is an HTTPError,
+// otherwise a 500 is used.
+func (r *Router) HandlerE(method string, fn ErrorHandlerFunc) *Route {
+	return r.Handler(method, r.handleError(fn))
+}
+
+// HandlerE adds a handler that returns an error to the group. See Router.HandlerE.
+func (g *Group) HandlerE(method string, fn ErrorHandlerFunc) *Route {
+	return g.Handler(method, g.router.handleError(fn))
+}
+
+// handleError converts a handler that returns an error into a HandlerFunc, rendering any
+// error it returns using the router's error handler.
+func (r *Router) handleError(fn ErrorHandlerFunc) HandlerFunc {
+	return func(w ResponseWriter, req *Request) {
+		err := fn(w, req)
+
+		if err == nil {
+			return
+		}
+
+		status := http.StatusInternalServerError
+
+		var httpErr *HTTPError
+
+		if errors.As(err, &httpErr) {
+			status = httpErr.StatusCode
+		}
+
+		r.writeError(w, req, status, err)
+	}
+}
+
+// Error returns the description of the underlying error.
+func (e *HTTPError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *HTTPError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,217 @@
+package lux_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/davidsbond/lux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouter_ReturnErrors(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Method         string
+		Path           string
+		ReturnErrors   bool
+		ExpectedStatus int
+		ExpectedError  string
+	}{
+		// Scenario 1: Request is handled successfully
+		{
+			Method:         "GET",
+			Path:           "/ok",
+			ReturnErrors:   true,
+			ExpectedStatus: http.StatusOK,
+		},
+		// Scenario 2: Handler writes an error status
+		{
+			Method:         "GET",
+			Path:           "/error",
+			ReturnErrors:   true,
+			ExpectedStatus: http.StatusInternalServerError,
+		},
+		// Scenario 3: Request does not match any route
+		{
+			Method:         "GET",
+			Path:           "/missing",
+			ReturnErrors:   true,
+			ExpectedStatus: http.StatusNotFound,
+			ExpectedError:  "failed to serve request, not found",
+		},
+		// Scenario 4: Request does not match the method of a route
+		{
+			Method:         "DELETE",
+			Path:           "/ok",
+			ReturnErrors:   true,
+			ExpectedStatus: http.StatusMethodNotAllowed,
+			ExpectedError:  "failed to serve request, not allowed",
+		},
+		// Scenario 5: OPTIONS request handled by the router
+		{
+			Method:         "OPTIONS",
+			Path:           "/ok",
+			ReturnErrors:   true,
+			ExpectedStatus: http.StatusNoContent,
+		},
+		// Scenario 6: Handler panics
+		{
+			Method:         "GET",
+			Path:           "/panic",
+			ReturnErrors:   true,
+			ExpectedStatus: http.StatusInternalServerError,
+			ExpectedError:  "failed to serve request, uh oh",
+		},
+		// Scenario 7: Handler writes no response
+		{
+			Method:         "GET",
+			Path:           "/empty",
+			ReturnErrors:   true,
+			ExpectedStatus: http.StatusInternalServerError,
+			ExpectedError:  "failed to serve request, failed to obtain response",
+		},
+		// Scenario 8: Handler times out
+		{
+			Method:         "GET",
+			Path:           "/slow",
+			ReturnErrors:   true,
+			ExpectedStatus: http.StatusGatewayTimeout,
+			ExpectedError:  "failed to serve request, timed out",
+		},
+		// Scenario 9: Request does not match any route without returning errors
+		{
+			Method:         "GET",
+			Path:           "/missing",
+			ExpectedStatus: http.StatusNotFound,
+		},
+		// Scenario 10: Handler panics without returning errors
+		{
+			Method:         "GET",
+			Path:           "/panic",
+			ExpectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router with a timeout
+		router := lux.NewRouter().Timeout(50 * time.Millisecond)
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		if tc.ReturnErrors {
+			router.ReturnErrors()
+		}
+
+		// AND that router has handlers registered
+		router.Handler("GET", getHandler).Path("/ok")
+		router.Handler("GET", getHandler).Path("/error").Middleware(errorMiddleware)
+		router.Handler("GET", panicHandler).Path("/panic")
+		router.Handler("GET", middleware).Path("/empty")
+		router.Handler("GET", func(w lux.ResponseWriter, r *lux.Request) {
+			<-r.Context().Done()
+		}).Path("/slow")
+
+		// WHEN we perform the request
+		resp, err := router.ServeHTTP(context.Background(), lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+				HTTPMethod: tc.Method,
+				Path:       tc.Path,
+			},
+		})
+
+		// THEN the response should be returned regardless of any error
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+
+		// AND an error should only be returned for framework-level failures
+		if tc.ExpectedError == "" {
+			assert.Nil(t, err)
+			continue
+		}
+
+		var serveErr *lux.ServeError
+
+		assert.True(t, errors.As(err, &serveErr))
+		assert.Equal(t, tc.ExpectedError, err.Error())
+		assert.Equal(t, tc.ExpectedStatus, serveErr.StatusCode)
+	}
+}
+
+func TestRouter_HandlerE(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Handler        lux.ErrorHandlerFunc
+		ErrorHandler   lux.ErrorFunc
+		ExpectedStatus int
+		ExpectedBody   string
+	}{
+		// Scenario 1: Handler returns no error
+		{
+			Handler: func(w lux.ResponseWriter, r *lux.Request) error {
+				lux.Text(w, http.StatusOK, "ok")
+				return nil
+			},
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   "ok",
+		},
+		// Scenario 2: Handler returns an error
+		{
+			Handler: func(w lux.ResponseWriter, r *lux.Request) error {
+				return errors.New("boom")
+			},
+			ExpectedStatus: http.StatusInternalServerError,
+			ExpectedBody:   `"boom"`,
+		},
+		// Scenario 3: Handler returns an error with a status code
+		{
+			Handler: func(w lux.ResponseWriter, r *lux.Request) error {
+				return fmt.Errorf("failed to find user, %w", &lux.HTTPError{
+					StatusCode: http.StatusNotFound,
+					Err:        errors.New("no such user"),
+				})
+			},
+			ExpectedStatus: http.StatusNotFound,
+			ExpectedBody:   `"failed to find user, no such user"`,
+		},
+		// Scenario 4: Router has a custom error handler
+		{
+			Handler: func(w lux.ResponseWriter, r *lux.Request) error {
+				return &lux.HTTPError{StatusCode: http.StatusConflict, Err: errors.New("exists")}
+			},
+			ErrorHandler: func(w lux.ResponseWriter, r *lux.Request, status int, err error) {
+				lux.JSON(w, status, map[string]string{"error": err.Error()})
+			},
+			ExpectedStatus: http.StatusConflict,
+			ExpectedBody:   `{"error":"exists"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router
+		router := lux.NewRouter()
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		if tc.ErrorHandler != nil {
+			router.ErrorHandler(tc.ErrorHandler)
+		}
+
+		// AND that router has a handler that returns an error
+		router.HandlerE("GET", tc.Handler).Path("/users/{id}")
+
+		// WHEN we perform a request
+		resp, _ := router.ServeHTTP(context.Background(), lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{HTTPMethod: "GET", Path: "/users/42"},
+		})
+
+		// THEN the error should be rendered by the error handler
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+		assert.Equal(t, tc.ExpectedBody, resp.Body)
+	}
+}
@@ -0,0 +1,95 @@
+package lux
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ServeWithETag writes the given body to the response with a 200 status code and an ETag header.
+// If etag is empty, a strong ETag is computed from the body. When the request is a GET or HEAD
+// request with an If-None-Match header matching the ETag, a 304 response is written without a
+// body instead. Set any other headers, such as the Content-Type, before calling ServeWithETag.
+func ServeWithETag(w ResponseWriter, r *Request, etag string, body []byte) {
+	if etag == "" {
+		sum := sha256.Sum256(body)
+		etag = hex.EncodeToString(sum[:16])
+	}
+
+	etag = quoteETag(etag)
+	w.Header().Set("ETag", etag)
+
+	if conditional(r) && matchETag(r.header("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
+
+// ServeWithLastModified writes the given body to the response with a 200 status code and a
+// Last-Modified header. When the request is a GET or HEAD request with an If-Modified-Since
+// header and the body has not been modified since that time, a 304 response is written
+// without a body instead. The If-Modified-Since header is ignored when the request has an
+// If-None-Match header. Set any other headers, such as the Content-Type, before calling
+// ServeWithLastModified.
+func ServeWithLastModified(w ResponseWriter, r *Request, modified time.Time, body []byte) {
+	modified = modified.UTC().Truncate(time.Second)
+	w.Header().Set("Last-Modified", modified.Format(http.TimeFormat))
+
+	if conditional(r) && r.header("If-None-Match") == "" && notModifiedSince(r.header("If-Modified-Since"), modified) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
+
+// conditional determines if the request's method allows a 304 response to conditional
+// headers.
+func conditional(r *Request) bool {
+	return r.HTTPMethod == http.MethodGet || r.HTTPMethod == http.MethodHead
+}
+
+// quoteETag wraps the given ETag in quotes if it is not already quoted.
+func quoteETag(etag string) string {
+	if strings.HasPrefix(etag, `"`) || strings.HasPrefix(etag, `W/"`) {
+		return etag
+	}
+
+	return `"` + etag + `"`
+}
+
+// matchETag determines if the given If-None-Match header matches the ETag. Comparison is
+// weak, so ETags match regardless of whether either is marked as weak.
+func matchETag(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// notModifiedSince determines if the given modification time is not after the time in the
+// If-Modified-Since header. Headers that cannot be parsed are ignored.
+func notModifiedSince(header string, modified time.Time) bool {
+	if header == "" {
+		return false
+	}
+
+	since, err := http.ParseTime(header)
+
+	if err != nil {
+		return false
+	}
+
+	return !modified.After(since)
+}
@@ -0,0 +1,179 @@
+package lux_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/davidsbond/lux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServeWithETag(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Method         string
+		ETag           string
+		IfNoneMatch    string
+		ExpectedStatus int
+		ExpectedBody   string
+		ExpectedETag   string
+	}{
+		// Scenario 1: Request without an If-None-Match header
+		{
+			Method:         "GET",
+			ETag:           "v1",
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   "hello",
+			ExpectedETag:   `"v1"`,
+		},
+		// Scenario 2: Request with a matching If-None-Match header
+		{
+			Method:         "GET",
+			ETag:           "v1",
+			IfNoneMatch:    `"v1"`,
+			ExpectedStatus: http.StatusNotModified,
+			ExpectedETag:   `"v1"`,
+		},
+		// Scenario 3: Request with a different If-None-Match header
+		{
+			Method:         "GET",
+			ETag:           "v1",
+			IfNoneMatch:    `"v2"`,
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   "hello",
+			ExpectedETag:   `"v1"`,
+		},
+		// Scenario 4: Request with a list containing a weak match
+		{
+			Method:         "GET",
+			ETag:           `"v1"`,
+			IfNoneMatch:    `"v0", W/"v1"`,
+			ExpectedStatus: http.StatusNotModified,
+			ExpectedETag:   `"v1"`,
+		},
+		// Scenario 5: Request with a wildcard If-None-Match header
+		{
+			Method:         "HEAD",
+			ETag:           "v1",
+			IfNoneMatch:    "*",
+			ExpectedStatus: http.StatusNotModified,
+			ExpectedETag:   `"v1"`,
+		},
+		// Scenario 6: Request with a matching computed ETag
+		{
+			Method:         "GET",
+			IfNoneMatch:    `"2cf24dba5fb0a30e26e83b2ac5b9e29e"`,
+			ExpectedStatus: http.StatusNotModified,
+			ExpectedETag:   `"2cf24dba5fb0a30e26e83b2ac5b9e29e"`,
+		},
+		// Scenario 7: Non-GET request with a matching If-None-Match header
+		{
+			Method:         "POST",
+			ETag:           "v1",
+			IfNoneMatch:    `"v1"`,
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   "hello",
+			ExpectedETag:   `"v1"`,
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router
+		router := lux.NewRouter()
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has a handler that serves a body with an ETag
+		router.Handler(tc.Method, func(w lux.ResponseWriter, r *lux.Request) {
+			w.Header().Set("Content-Type", "text/plain")
+			lux.ServeWithETag(w, r, tc.ETag, []byte("hello"))
+		})
+
+		// WHEN we perform a request
+		resp, _ := router.ServeHTTP(context.Background(), lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+				HTTPMethod: tc.Method,
+				Headers:    map[string]string{"If-None-Match": tc.IfNoneMatch},
+			},
+		})
+
+		// THEN the response should be what we expect
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+		assert.Equal(t, tc.ExpectedBody, resp.Body)
+		assert.Equal(t, tc.ExpectedETag, resp.Headers["Etag"])
+	}
+}
+
+func TestServeWithLastModified(t *testing.T) {
+	t.Parallel()
+
+	modified := time.Date(2020, 1, 2, 3, 4, 5, 600, time.UTC)
+
+	tt := []struct {
+		Headers        map[string]string
+		ExpectedStatus int
+		ExpectedBody   string
+	}{
+		// Scenario 1: Request without an If-Modified-Since header
+		{
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   "hello",
+		},
+		// Scenario 2: Request with an If-Modified-Since header equal to the modification time
+		{
+			Headers:        map[string]string{"If-Modified-Since": "Thu, 02 Jan 2020 03:04:05 GMT"},
+			ExpectedStatus: http.StatusNotModified,
+		},
+		// Scenario 3: Request with an If-Modified-Since header before the modification time
+		{
+			Headers:        map[string]string{"If-Modified-Since": "Thu, 02 Jan 2020 03:04:04 GMT"},
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   "hello",
+		},
+		// Scenario 4: Request with an invalid If-Modified-Since header
+		{
+			Headers:        map[string]string{"If-Modified-Since": "yesterday"},
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   "hello",
+		},
+		// Scenario 5: Request with an If-None-Match header
+		{
+			Headers: map[string]string{
+				"If-Modified-Since": "Thu, 02 Jan 2020 03:04:05 GMT",
+				"If-None-Match":     `"v1"`,
+			},
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   "hello",
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router
+		router := lux.NewRouter()
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has a handler that serves a body with a modification time
+		router.Handler("GET", func(w lux.ResponseWriter, r *lux.Request) {
+			w.Header().Set("Content-Type", "text/plain")
+			lux.ServeWithLastModified(w, r, modified, []byte("hello"))
+		})
+
+		// WHEN we perform a request
+		resp, _ := router.ServeHTTP(context.Background(), lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+				HTTPMethod: "GET",
+				Headers:    tc.Headers,
+			},
+		})
+
+		// THEN the response should be what we expect
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+		assert.Equal(t, tc.ExpectedBody, resp.Body)
+		assert.Equal(t, "Thu, 02 Jan 2020 03:04:05 GMT", resp.Headers["Last-Modified"])
+	}
+}
@@ -0,0 +1,44 @@
+package lux
+
+// Fallback sets a handler for requests to the route's path whose method is not registered
+// for that path, taking precedence over the router's MethodNotAllowed handler. This allows
+// you to return a helpful response, such as one listing the allowed methods. The Allow
+// header is set before the handler is executed, and the router's global middleware & the
+// middleware of any groups the route belongs to are executed before it. OPTIONS requests
+// are still responded to by the router.
+func (r *Route) Fallback(fn HandlerFunc) *Route {
+	r.fallback = fn
+
+	return r
+}
+
+// Fallback sets a handler for requests to the path of any route in the group, or its child
+// groups, whose method is not registered for that path. Fallback handlers of routes take
+// precedence over those of groups, and those of child groups take precedence over their
+// parents.
+func (g *Group) Fallback(fn HandlerFunc) *Group {
+	g.fallback = fn
+
+	return g
+}
+
+// fallbackRoute creates the route used to execute the fallback handler of any of the given
+// routes, whose path matches the request but whose method does not. It returns nil if none
+// of the routes, or the groups they belong to, have a fallback handler.
+func fallbackRoute(method string, matched []*Route) *Route {
+	for _, route := range matched {
+		if route.fallback != nil {
+			return &Route{methods: []string{method}, path: route.path, handler: route.fallback, group: route.group}
+		}
+	}
+
+	for _, route := range matched {
+		for g := route.group; g != nil; g = g.parent {
+			if g.fallback != nil {
+				return &Route{methods: []string{method}, path: route.path, handler: g.fallback, group: g}
+			}
+		}
+	}
+
+	return nil
+}
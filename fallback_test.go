@@ -0,0 +1,118 @@
+package lux_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/davidsbond/lux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoute_Fallback(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Method          string
+		Path            string
+		ExpectedStatus  int
+		ExpectedBody    string
+		ExpectedAllow   string
+		ExpectedPattern string
+	}{
+		// Scenario 1: Route fallback handles an unregistered method
+		{
+			Method:          "DELETE",
+			Path:            "/v1/users",
+			ExpectedStatus:  http.StatusMethodNotAllowed,
+			ExpectedBody:    `"route fallback"`,
+			ExpectedAllow:   "GET, HEAD, OPTIONS",
+			ExpectedPattern: "/v1/users",
+		},
+		// Scenario 2: Group fallback handles an unregistered method
+		{
+			Method:          "DELETE",
+			Path:            "/v1/orders",
+			ExpectedStatus:  http.StatusMethodNotAllowed,
+			ExpectedBody:    `"group fallback"`,
+			ExpectedAllow:   "POST, OPTIONS",
+			ExpectedPattern: "/v1/orders",
+		},
+		// Scenario 3: Router handler is used for routes without a fallback
+		{
+			Method:         "DELETE",
+			Path:           "/health",
+			ExpectedStatus: http.StatusMethodNotAllowed,
+			ExpectedBody:   `"router fallback"`,
+			ExpectedAllow:  "GET, HEAD, OPTIONS",
+		},
+		// Scenario 4: Registered methods are handled as normal
+		{
+			Method:         "GET",
+			Path:           "/v1/users",
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   "\"hello test\"\n",
+		},
+		// Scenario 5: OPTIONS requests are still responded to by the router
+		{
+			Method:         "OPTIONS",
+			Path:           "/v1/users",
+			ExpectedStatus: http.StatusNoContent,
+			ExpectedAllow:  "GET, HEAD, OPTIONS",
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router with a method not allowed handler
+		router := lux.NewRouter().MethodNotAllowed(func(w lux.ResponseWriter, r *lux.Request) {
+			lux.JSON(w, http.StatusMethodNotAllowed, "router fallback")
+		})
+
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has a group with a fallback handler
+		v1 := router.Group("/v1").Fallback(func(w lux.ResponseWriter, r *lux.Request) {
+			lux.JSON(w, http.StatusMethodNotAllowed, "group fallback")
+		})
+
+		// AND that group has routes, one of which has its own fallback handler
+		var pattern string
+
+		v1.Handler("GET", getHandler).Path("/users").Fallback(func(w lux.ResponseWriter, r *lux.Request) {
+			pattern = r.RoutePattern()
+			lux.JSON(w, http.StatusMethodNotAllowed, "route fallback")
+		})
+
+		v1.Handler("POST", getHandler).Path("/orders")
+
+		// AND that group has middleware that observes the route pattern
+		v1.Middleware(func(w lux.ResponseWriter, r *lux.Request) {
+			pattern = r.RoutePattern()
+		})
+
+		router.Handler("GET", getHandler).Path("/health")
+
+		// WHEN we perform a request
+		resp, _ := router.ServeHTTP(context.Background(), lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+				HTTPMethod: tc.Method,
+				Path:       tc.Path,
+			},
+		})
+
+		// THEN the response should be what we expect
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+		assert.Equal(t, tc.ExpectedBody, resp.Body)
+
+		// AND the Allow header should list the registered methods
+		assert.Equal(t, tc.ExpectedAllow, resp.Headers["Allow"])
+
+		// AND the group middleware should receive the pattern of the matched path
+		if tc.ExpectedPattern != "" {
+			assert.Equal(t, tc.ExpectedPattern, pattern)
+		}
+	}
+}
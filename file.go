@@ -0,0 +1,74 @@
+package lux
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// FileServer returns a handler that serves files from the given file system, such as an
+// embed.FS, using the request path as the name of the file. Requests for a directory are
+// served its index.html file. The content type of each file is derived from its extension,
+// or its contents when the extension is unknown, and binary files are base64 encoded. A
+// 404 response is returned for files that do not exist. Use fs.Sub to serve files from a
+// subdirectory of the file system.
+func FileServer(fsys fs.FS) HandlerFunc {
+	return func(w ResponseWriter, r *Request) {
+		name, data, err := readFile(fsys, r.Path)
+
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			r.router.writeError(w, r, http.StatusNotFound, errNotFound)
+			return
+		case err != nil:
+			r.router.writeError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+
+		contentType := mime.TypeByExtension(path.Ext(name))
+
+		if contentType == "" {
+			contentType = http.DetectContentType(data)
+		}
+
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(http.StatusOK)
+		w.Write(data)
+	}
+}
+
+// readFile reads the file for the given request path from the file system, returning its
+// name within the file system. Directories are read using their index.html file.
+func readFile(fsys fs.FS, urlPath string) (string, []byte, error) {
+	name := strings.TrimPrefix(path.Clean("/"+urlPath), "/")
+
+	if name == "" {
+		name = "."
+	}
+
+	info, err := fs.Stat(fsys, name)
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	if info.IsDir() {
+		name = path.Join(name, "index.html")
+	}
+
+	data, err := fs.ReadFile(fsys, name)
+
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil, err
+	}
+
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read file %s, %v", name, err)
+	}
+
+	return name, data, nil
+}
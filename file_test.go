@@ -0,0 +1,127 @@
+package lux_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"net/http"
+	"testing"
+	"testing/fstest"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/davidsbond/lux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileServer(t *testing.T) {
+	t.Parallel()
+
+	png := []byte{0x89, 'P', 'N', 'G', 0x0d, 0x0a, 0x1a, 0x0a}
+
+	fsys := fstest.MapFS{
+		"index.html":        {Data: []byte("<h1>home</h1>")},
+		"favicon.png":       {Data: png},
+		"docs/index.html":   {Data: []byte("<h1>docs</h1>")},
+		"docs/guide.txt":    {Data: []byte("read me")},
+		"empty/.keep":       {Data: []byte{}},
+		"assets/unknown.xx": {Data: []byte("plain text")},
+	}
+
+	tt := []struct {
+		Path                string
+		ExpectedStatus      int
+		ExpectedBody        []byte
+		ExpectedContentType string
+		ExpectedEncoding    bool
+	}{
+		// Scenario 1: Request for the root directory
+		{
+			Path:                "/",
+			ExpectedStatus:      http.StatusOK,
+			ExpectedBody:        []byte("<h1>home</h1>"),
+			ExpectedContentType: "text/html; charset=utf-8",
+		},
+		// Scenario 2: Request for a binary file
+		{
+			Path:                "/favicon.png",
+			ExpectedStatus:      http.StatusOK,
+			ExpectedBody:        png,
+			ExpectedContentType: "image/png",
+			ExpectedEncoding:    true,
+		},
+		// Scenario 3: Request for a subdirectory
+		{
+			Path:                "/docs",
+			ExpectedStatus:      http.StatusOK,
+			ExpectedBody:        []byte("<h1>docs</h1>"),
+			ExpectedContentType: "text/html; charset=utf-8",
+		},
+		// Scenario 4: Request for a file in a subdirectory
+		{
+			Path:                "/docs/guide.txt",
+			ExpectedStatus:      http.StatusOK,
+			ExpectedBody:        []byte("read me"),
+			ExpectedContentType: "text/plain; charset=utf-8",
+		},
+		// Scenario 5: Request for a file with an unknown extension
+		{
+			Path:                "/assets/unknown.xx",
+			ExpectedStatus:      http.StatusOK,
+			ExpectedBody:        []byte("plain text"),
+			ExpectedContentType: "text/plain; charset=utf-8",
+		},
+		// Scenario 6: Request for a file that does not exist
+		{
+			Path:                "/missing.html",
+			ExpectedStatus:      http.StatusNotFound,
+			ExpectedBody:        []byte(`"not found"`),
+			ExpectedContentType: "application/json",
+		},
+		// Scenario 7: Request for a directory without an index
+		{
+			Path:                "/empty",
+			ExpectedStatus:      http.StatusNotFound,
+			ExpectedBody:        []byte(`"not found"`),
+			ExpectedContentType: "application/json",
+		},
+		// Scenario 8: Request attempting to escape the file system
+		{
+			Path:                "/../docs/guide.txt",
+			ExpectedStatus:      http.StatusOK,
+			ExpectedBody:        []byte("read me"),
+			ExpectedContentType: "text/plain; charset=utf-8",
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router
+		router := lux.NewRouter()
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has a file server
+		router.Handler("GET", lux.FileServer(fsys))
+
+		// WHEN we request a file
+		resp, _ := router.ServeHTTP(context.Background(), lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+				HTTPMethod: "GET",
+				Path:       tc.Path,
+			},
+		})
+
+		body := []byte(resp.Body)
+
+		if resp.IsBase64Encoded {
+			body, _ = base64.StdEncoding.DecodeString(resp.Body)
+		}
+
+		// THEN the response should contain the file
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+		assert.Equal(t, tc.ExpectedBody, body)
+		assert.Equal(t, tc.ExpectedContentType, resp.Headers["Content-Type"])
+
+		// AND only binary files should be base64 encoded
+		assert.Equal(t, tc.ExpectedEncoding, resp.IsBase64Encoded)
+	}
+}
@@ -0,0 +1,90 @@
+package lux
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+)
+
+// Form returns the values of a form submitted in the request body, for requests with an
+// application/x-www-form-urlencoded or multipart/form-data Content-Type header. Base64 encoded
+// & compressed bodies are decoded like Request.RawBody, so the body size limit of the route
+// applies. Query parameters are not included. Requests with any other content type return
+// ErrNotForm. The form is parsed once and reused by subsequent calls to Form & FormFile.
+func (r *Request) Form() (url.Values, error) {
+	form, err := r.parseForm()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return form.Value, nil
+}
+
+// FormFile returns the first file uploaded using the given form field of a multipart/form-data
+// request body, along with its header containing the file's name, size & content type. If the
+// form has no such file, http.ErrMissingFile is returned.
+func (r *Request) FormFile(name string) (multipart.File, *multipart.FileHeader, error) {
+	form, err := r.parseForm()
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	files := form.File[name]
+
+	if len(files) == 0 {
+		return nil, nil, http.ErrMissingFile
+	}
+
+	file, err := files[0].Open()
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open form file %s, %v", name, err)
+	}
+
+	return file, files[0], nil
+}
+
+// parseForm parses the form in the request body, caching it for subsequent calls.
+func (r *Request) parseForm() (*multipart.Form, error) {
+	if r.form != nil {
+		return r.form, nil
+	}
+
+	media, params, err := mime.ParseMediaType(r.header("Content-Type"))
+
+	if err != nil || (media != "application/x-www-form-urlencoded" && media != "multipart/form-data") {
+		return nil, ErrNotForm
+	}
+
+	body, err := r.RawBody()
+
+	if err != nil {
+		return nil, err
+	}
+
+	form := &multipart.Form{Value: make(url.Values), File: make(map[string][]*multipart.FileHeader)}
+
+	if media == "application/x-www-form-urlencoded" {
+		if form.Value, err = url.ParseQuery(string(body)); err != nil {
+			return nil, fmt.Errorf("failed to parse form, %v", err)
+		}
+
+		r.form = form
+
+		return form, nil
+	}
+
+	// The body is already held in memory, so files are never written to disk
+	if form, err = multipart.NewReader(bytes.NewReader(body), params["boundary"]).ReadForm(int64(len(body)) + 1); err != nil {
+		return nil, fmt.Errorf("failed to parse multipart form, %v", err)
+	}
+
+	r.form = form
+
+	return form, nil
+}
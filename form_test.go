@@ -0,0 +1,185 @@
+package lux_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/davidsbond/lux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequest_Form(t *testing.T) {
+	t.Parallel()
+
+	multipartBody, boundary := multipartForm(t)
+
+	tt := []struct {
+		Request       lux.Request
+		ExpectedName  string
+		ExpectedError error
+	}{
+		// Scenario 1: URL encoded form
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					Headers: map[string]string{"Content-Type": "application/x-www-form-urlencoded"},
+					Body:    "name=John+Smith&age=42",
+				},
+			},
+			ExpectedName: "John Smith",
+		},
+		// Scenario 2: Base64 encoded URL encoded form
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					Headers:         map[string]string{"Content-Type": "application/x-www-form-urlencoded; charset=utf-8"},
+					Body:            base64.StdEncoding.EncodeToString([]byte("name=John+Smith")),
+					IsBase64Encoded: true,
+				},
+			},
+			ExpectedName: "John Smith",
+		},
+		// Scenario 3: Multipart form
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					Headers: map[string]string{"Content-Type": "multipart/form-data; boundary=" + boundary},
+					Body:    multipartBody,
+				},
+			},
+			ExpectedName: "John Smith",
+		},
+		// Scenario 4: Request with a JSON body
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					Headers: map[string]string{"Content-Type": "application/json"},
+					Body:    `{"name": "John Smith"}`,
+				},
+			},
+			ExpectedError: lux.ErrNotForm,
+		},
+	}
+
+	for _, tc := range tt {
+		// WHEN we obtain the form
+		form, err := tc.Request.Form()
+
+		// THEN the error should be what we expect
+		assert.Equal(t, tc.ExpectedError, err)
+
+		// AND the form values should be what we expect
+		assert.Equal(t, tc.ExpectedName, form.Get("name"))
+	}
+}
+
+func TestRequest_FormFile(t *testing.T) {
+	t.Parallel()
+
+	body, boundary := multipartForm(t)
+
+	tt := []struct {
+		Field           string
+		Base64          bool
+		MaxBodySize     int
+		ExpectedStatus  int
+		ExpectedContent string
+	}{
+		// Scenario 1: Uploaded file
+		{
+			Field:           "avatar",
+			ExpectedStatus:  http.StatusOK,
+			ExpectedContent: "avatar.png:image data",
+		},
+		// Scenario 2: Base64 encoded body
+		{
+			Field:           "avatar",
+			Base64:          true,
+			ExpectedStatus:  http.StatusOK,
+			ExpectedContent: "avatar.png:image data",
+		},
+		// Scenario 3: Missing file
+		{
+			Field:           "document",
+			ExpectedStatus:  http.StatusBadRequest,
+			ExpectedContent: http.ErrMissingFile.Error(),
+		},
+		// Scenario 4: Body exceeding the body size limit
+		{
+			Field:          "avatar",
+			MaxBodySize:    16,
+			ExpectedStatus: http.StatusRequestEntityTooLarge,
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router
+		router := lux.NewRouter().MaxBodySize(tc.MaxBodySize)
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has a handler that reads an uploaded file
+		field := tc.Field
+
+		router.Handler("POST", func(w lux.ResponseWriter, r *lux.Request) {
+			file, header, err := r.FormFile(field)
+
+			if err != nil {
+				lux.Text(w, http.StatusBadRequest, err.Error())
+				return
+			}
+
+			defer file.Close()
+
+			data, _ := io.ReadAll(file)
+			lux.Text(w, http.StatusOK, header.Filename+":"+string(data))
+		})
+
+		req := lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+				HTTPMethod: "POST",
+				Headers:    map[string]string{"Content-Type": "multipart/form-data; boundary=" + boundary},
+				Body:       body,
+			},
+		}
+
+		if tc.Base64 {
+			req.Body, req.IsBase64Encoded = base64.StdEncoding.EncodeToString([]byte(body)), true
+		}
+
+		// WHEN we perform the request
+		resp, _ := router.ServeHTTP(context.Background(), req)
+
+		// THEN the response should be what we expect
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+
+		if tc.ExpectedContent != "" {
+			assert.Equal(t, tc.ExpectedContent, resp.Body)
+		}
+	}
+}
+
+// multipartForm returns a multipart form body containing a name field & an avatar file, along
+// with its boundary.
+func multipartForm(t *testing.T) (string, string) {
+	var body bytes.Buffer
+
+	w := multipart.NewWriter(&body)
+
+	assert.NoError(t, w.WriteField("name", "John Smith"))
+
+	file, err := w.CreateFormFile("avatar", "avatar.png")
+	assert.NoError(t, err)
+
+	file.Write([]byte("image data"))
+
+	assert.NoError(t, w.Close())
+
+	return body.String(), w.Boundary()
+}
@@ -0,0 +1,77 @@
+package lux
+
+import "strings"
+
+type (
+	// The Group type allows routes to be registered under a shared path prefix and
+	// middleware. Routes registered on a group use the router's global middleware,
+	// followed by the middleware of each parent group and then the group itself.
+	Group struct {
+		router     *Router
+		parent     *Group
+		prefix     string
+		middleware []HandlerFunc
+		fallback   HandlerFunc
+	}
+)
+
+// Group creates a new group of routes that share the given path prefix.
+func (r *Router) Group(prefix string) *Group {
+	return &Group{
+		router:     r,
+		prefix:     prefix,
+		middleware: []HandlerFunc{},
+	}
+}
+
+// Group creates a child group whose prefix is appended to that of the parent. The
+// child group inherits the middleware of its parent.
+func (g *Group) Group(prefix string) *Group {
+	return &Group{
+		router:     g.router,
+		parent:     g,
+		prefix:     prefix,
+		middleware: []HandlerFunc{},
+	}
+}
+
+// Handler adds a given handler to the group. The route's path will be prefixed with
+// that of the group. Routes that do not specify a path will match the group's prefix.
+func (g *Group) Handler(method string, fn HandlerFunc) *Route {
+	route := g.router.Handler(method, fn)
+	route.group = g
+
+	return route.Path("")
+}
+
+// Middleware adds a middleware function to the group. These methods will be called
+// for any routes registered on the group or its children.
+func (g *Group) Middleware(fn ...HandlerFunc) *Group {
+	g.middleware = append(g.middleware, fn...)
+
+	return g
+}
+
+// fullPrefix returns the path prefix of the group, including those of any parent
+// groups.
+func (g *Group) fullPrefix() string {
+	prefix := strings.TrimSuffix(g.prefix, "/")
+
+	if g.parent != nil {
+		prefix = g.parent.fullPrefix() + prefix
+	}
+
+	return prefix
+}
+
+// fullMiddleware returns the middleware of the group, preceded by those of any parent
+// groups.
+func (g *Group) fullMiddleware() []HandlerFunc {
+	var out []HandlerFunc
+
+	if g.parent != nil {
+		out = append(out, g.parent.fullMiddleware()...)
+	}
+
+	return append(out, g.middleware...)
+}
@@ -0,0 +1,84 @@
+package lux_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/davidsbond/lux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroup_RoutesRequests(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Path               string
+		ExpectedStatus     int
+		ExpectedMiddleware []string
+	}{
+		// Scenario 1: Request to a group route
+		{
+			Path:               "/v1/users/42",
+			ExpectedStatus:     http.StatusOK,
+			ExpectedMiddleware: []string{"router", "v1"},
+		},
+		// Scenario 2: Request to a nested group route
+		{
+			Path:               "/v1/admin/users",
+			ExpectedStatus:     http.StatusOK,
+			ExpectedMiddleware: []string{"router", "v1", "admin", "route"},
+		},
+		// Scenario 3: Request to a group route without a path
+		{
+			Path:               "/v1",
+			ExpectedStatus:     http.StatusOK,
+			ExpectedMiddleware: []string{"router", "v1"},
+		},
+		// Scenario 4: Request to a path outside of the group
+		{
+			Path:           "/users/42",
+			ExpectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tt {
+		var called []string
+
+		record := func(name string) lux.HandlerFunc {
+			return func(w lux.ResponseWriter, r *lux.Request) {
+				called = append(called, name)
+			}
+		}
+
+		// GIVEN that we have a router with middleware
+		router := lux.NewRouter().Middleware(record("router"))
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has a group with middleware
+		v1 := router.Group("/v1/").Middleware(record("v1"))
+		v1.Handler("GET", getHandler)
+		v1.Handler("GET", getHandler).Path("/users/{id}")
+
+		// AND that group has a nested group with route specific middleware
+		admin := v1.Group("/admin").Middleware(record("admin"))
+		admin.Handler("GET", getHandler).Path("/users").Middleware(record("route"))
+
+		// WHEN we perform the request
+		resp, _ := router.ServeHTTP(context.Background(), lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+				HTTPMethod: "GET",
+				Path:       tc.Path,
+			},
+		})
+
+		// THEN the status code should be what we expect
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+
+		// AND the middleware should have been called in order
+		assert.Equal(t, tc.ExpectedMiddleware, called)
+	}
+}
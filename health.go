@@ -0,0 +1,46 @@
+package lux
+
+import (
+	"context"
+	"net/http"
+)
+
+type (
+	// The HealthCheckFunc type defines a function that checks the health of a dependency,
+	// such as a database. The returned details are included in the body of the health
+	// check response, and returning an error reports the service as unavailable.
+	HealthCheckFunc func(ctx context.Context) (map[string]interface{}, error)
+)
+
+// HealthCheck registers a GET route at the given path that responds with a 200 status code
+// and a JSON body of {"status": "ok"}. The route bypasses the router's global & group
+// middleware, such as authentication, and is not logged. Any given checks are called in
+// order, with their details added to the response body. If a check returns an error, a 503
+// response is returned with a status of "unavailable" and the error message.
+func (r *Router) HealthCheck(path string, checks ...HealthCheckFunc) *Route {
+	return r.Handler(http.MethodGet, healthHandler(checks)).Path(path).NoLog().NoMiddleware()
+}
+
+// healthHandler creates the handler used for health check routes.
+func healthHandler(checks []HealthCheckFunc) HandlerFunc {
+	return func(w ResponseWriter, r *Request) {
+		body := map[string]interface{}{"status": "ok"}
+
+		for _, check := range checks {
+			details, err := check(r.Context())
+
+			for key, value := range details {
+				body[key] = value
+			}
+
+			if err != nil {
+				body["status"], body["error"] = "unavailable", err.Error()
+				JSON(w, http.StatusServiceUnavailable, body)
+
+				return
+			}
+		}
+
+		JSON(w, http.StatusOK, body)
+	}
+}
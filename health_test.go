@@ -0,0 +1,76 @@
+package lux_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/davidsbond/lux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouter_HealthCheck(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Checks         []lux.HealthCheckFunc
+		ExpectedStatus int
+		ExpectedBody   string
+	}{
+		// Scenario 1: Health check without any checks
+		{
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   `{"status":"ok"}`,
+		},
+		// Scenario 2: Health check with passing checks
+		{
+			Checks: []lux.HealthCheckFunc{
+				func(ctx context.Context) (map[string]interface{}, error) {
+					return map[string]interface{}{"database": "ok"}, nil
+				},
+			},
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   `{"database":"ok","status":"ok"}`,
+		},
+		// Scenario 3: Health check with a failing check
+		{
+			Checks: []lux.HealthCheckFunc{
+				func(ctx context.Context) (map[string]interface{}, error) {
+					return map[string]interface{}{"database": "down"}, errors.New("connection refused")
+				},
+			},
+			ExpectedStatus: http.StatusServiceUnavailable,
+			ExpectedBody:   `{"database":"down","error":"connection refused","status":"unavailable"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router with middleware that rejects all requests
+		router := lux.NewRouter().Middleware(func(w lux.ResponseWriter, r *lux.Request) {
+			lux.JSON(w, http.StatusUnauthorized, "unauthorized")
+		})
+
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has a health check
+		router.HealthCheck("/health", tc.Checks...)
+
+		// WHEN we perform a request to the health check
+		resp, _ := router.ServeHTTP(context.Background(), lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+				HTTPMethod: "GET",
+				Path:       "/health",
+			},
+		})
+
+		// THEN the middleware should be bypassed
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+
+		// AND the body should contain the status of the checks
+		assert.Equal(t, tc.ExpectedBody, resp.Body)
+	}
+}
@@ -0,0 +1,116 @@
+package lux
+
+import (
+	"encoding/base64"
+	"io"
+	"net"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// HTTPHandler returns an http.Handler that serves requests using the router, allowing
+// handlers to be run locally using the net/http package, for example with
+// http.ListenAndServe(":8080", router.HTTPHandler()). Each request is converted into the
+// format sent by API Gateway, and the response is written back to the client. Request
+// bodies that are not textual are base64 encoded, as they would be by API Gateway.
+func (r *Router) HTTPHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		in, err := newRequestFromHTTP(req)
+
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// Any error is described by the status code of the response, so it can be ignored.
+		resp, _ := r.ServeHTTP(req.Context(), in)
+
+		writeHTTPResponse(w, resp)
+	})
+}
+
+// newRequestFromHTTP converts a net/http request into a Request.
+func newRequestFromHTTP(req *http.Request) (Request, error) {
+	body, err := io.ReadAll(req.Body)
+
+	if err != nil {
+		return Request{}, err
+	}
+
+	out := Request{
+		APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+			Path:                            req.URL.Path,
+			HTTPMethod:                      req.Method,
+			Headers:                         make(map[string]string, len(req.Header)+1),
+			MultiValueHeaders:               make(map[string][]string, len(req.Header)+1),
+			QueryStringParameters:           make(map[string]string),
+			MultiValueQueryStringParameters: make(map[string][]string),
+			Body:                            string(body),
+			RequestContext: events.APIGatewayProxyRequestContext{
+				HTTPMethod: req.Method,
+				Path:       req.URL.Path,
+				Protocol:   req.Proto,
+				DomainName: req.Host,
+				Identity: events.APIGatewayRequestIdentity{
+					SourceIP:  remoteIP(req.RemoteAddr),
+					UserAgent: req.UserAgent(),
+				},
+			},
+		},
+	}
+
+	for key, values := range req.Header {
+		out.MultiValueHeaders[key] = values
+		out.Headers[key] = values[len(values)-1]
+	}
+
+	// The host is removed from the headers of incoming requests by the net/http package.
+	if req.Host != "" {
+		out.Headers["Host"] = req.Host
+		out.MultiValueHeaders["Host"] = []string{req.Host}
+	}
+
+	for key, values := range req.URL.Query() {
+		out.MultiValueQueryStringParameters[key] = values
+		out.QueryStringParameters[key] = values[len(values)-1]
+	}
+
+	if !isText(req.Header.Get("Content-Type"), body) {
+		out.Body = base64.StdEncoding.EncodeToString(body)
+		out.IsBase64Encoded = true
+	}
+
+	return out, nil
+}
+
+// writeHTTPResponse writes the response to a net/http response writer, decoding the body
+// if it is base64 encoded.
+func writeHTTPResponse(w http.ResponseWriter, resp Response) {
+	for key, values := range resp.MultiValueHeaders {
+		for _, value := range values {
+			w.Header().Add(key, value)
+		}
+	}
+
+	for key, value := range resp.Headers {
+		if _, ok := resp.MultiValueHeaders[key]; !ok {
+			w.Header().Set(key, value)
+		}
+	}
+
+	w.WriteHeader(resp.StatusCode)
+	w.Write(responseBody(resp))
+}
+
+// remoteIP returns the IP address of a network address, such as the remote address of a
+// net/http request.
+func remoteIP(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+
+	if err != nil {
+		return addr
+	}
+
+	return host
+}
@@ -0,0 +1,86 @@
+package lux_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/davidsbond/lux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouter_HTTPHandler(t *testing.T) {
+	t.Parallel()
+
+	png := []byte{0x89, 'P', 'N', 'G', 0x0d, 0x0a, 0x1a, 0x0a}
+
+	// GIVEN that we have a router
+	router := lux.NewRouter()
+	router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+	// AND that router has a handler that echoes the request
+	router.Handler("POST", func(w lux.ResponseWriter, r *lux.Request) {
+		body := []byte(r.Body)
+
+		if r.IsBase64Encoded {
+			body, _ = base64.StdEncoding.DecodeString(r.Body)
+		}
+
+		w.Header().Add("Set-Cookie", "a=1")
+		w.Header().Add("Set-Cookie", "b=2")
+		w.Header().Set("X-Name", r.PathParam("name"))
+		w.Header().Set("X-Query", r.QueryStringParameters["q"])
+		w.Header().Set("X-Header", r.Header("X-Test"))
+		lux.Binary(w, http.StatusCreated, r.ContentType(), body)
+	}).Path("/echo/{name}")
+
+	// AND a server using the handler
+	server := httptest.NewServer(router.HTTPHandler())
+	defer server.Close()
+
+	tt := []struct {
+		ContentType string
+		Body        []byte
+	}{
+		// Scenario 1: Request has a textual body
+		{
+			ContentType: "text/plain",
+			Body:        []byte("hello test"),
+		},
+		// Scenario 2: Request has a binary body
+		{
+			ContentType: "image/png",
+			Body:        png,
+		},
+	}
+
+	for _, tc := range tt {
+		// WHEN we perform a request
+		req, _ := http.NewRequest("POST", server.URL+"/echo/test?q=a&q=b", bytes.NewReader(tc.Body))
+		req.Header.Set("Content-Type", tc.ContentType)
+		req.Header.Set("X-Test", "value")
+
+		resp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		// THEN the response should be what we expect
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		assert.Equal(t, tc.Body, body)
+		assert.Equal(t, tc.ContentType, resp.Header.Get("Content-Type"))
+
+		// AND the request should have been converted
+		assert.Equal(t, "test", resp.Header.Get("X-Name"))
+		assert.Equal(t, "b", resp.Header.Get("X-Query"))
+		assert.Equal(t, "value", resp.Header.Get("X-Header"))
+
+		// AND multi-value headers should be written
+		assert.Equal(t, []string{"a=1", "b=2"}, resp.Header.Values("Set-Cookie"))
+	}
+}
@@ -0,0 +1,262 @@
+package lux
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+type (
+	// The IdempotencyOptions type contains configuration for the idempotency middleware.
+	IdempotencyOptions struct {
+		// Header contains the name of the request header containing the idempotency key.
+		// Defaults to "Idempotency-Key".
+		Header string
+
+		// TTL contains how long responses are stored for. Defaults to 24 hours.
+		TTL time.Duration
+
+		// Key returns the value idempotency keys are scoped to, preventing clients from
+		// replaying the responses of others. Defaults to the subject of the token claims,
+		// the principal of the API Gateway authorizer or the source IP of the request.
+		Key func(*Request) string
+
+		// Store contains the backend used to store responses. As lambda functions are
+		// stateless, a shared store such as DynamoDB should be used so retries handled
+		// by other containers are replayed. Defaults to an in-memory store.
+		Store IdempotencyStore
+	}
+
+	// The IdempotencyStore interface describes a backend that stores responses for the
+	// idempotency middleware.
+	IdempotencyStore interface {
+		// Lock claims the given key for an in-flight request, returning false if the key
+		// has already been claimed. The claim should expire after the given duration.
+		Lock(ctx context.Context, key string, ttl time.Duration) (bool, error)
+
+		// Get returns the response stored for the given key, or nil if the request that
+		// claimed the key has not completed.
+		Get(ctx context.Context, key string) (*IdempotentResponse, error)
+
+		// Save stores the response for the given key, replacing its claim.
+		Save(ctx context.Context, key string, resp IdempotentResponse, ttl time.Duration) error
+
+		// Unlock releases the claim on the given key, allowing the request to be retried.
+		Unlock(ctx context.Context, key string) error
+	}
+
+	// The IdempotentResponse type contains a response stored by the idempotency middleware.
+	IdempotentResponse struct {
+		StatusCode int
+		Headers    map[string][]string
+		Body       []byte
+		Binary     bool
+	}
+
+	memoryIdempotencyStore struct {
+		mu      sync.Mutex
+		entries map[string]idempotencyEntry
+		swept   time.Time
+	}
+
+	idempotencyEntry struct {
+		resp    *IdempotentResponse
+		expires time.Time
+	}
+)
+
+var errIdempotencyInFlight = errors.New("a request with the same idempotency key is in progress")
+
+// Idempotency creates a middleware function that makes requests safe to retry when they have an
+// Idempotency-Key header. The response to the first request with a key is stored and replayed for
+// any subsequent requests from the same client with the same key, method and path, with the
+// Idempotent-Replayed header set. While the first request is in progress, a 409 response is
+// returned for any duplicates. Responses with a 5xx status code are not stored, allowing the
+// request to be retried. Requests without a key, or for which the store returns an error, are
+// handled as normal.
+func Idempotency(opts IdempotencyOptions) HandlerFunc {
+	if opts.Header == "" {
+		opts.Header = "Idempotency-Key"
+	}
+
+	if opts.TTL <= 0 {
+		opts.TTL = 24 * time.Hour
+	}
+
+	if opts.Key == nil {
+		opts.Key = idempotencyScope
+	}
+
+	if opts.Store == nil {
+		opts.Store = NewMemoryIdempotencyStore()
+	}
+
+	return func(w ResponseWriter, r *Request) {
+		header := r.header(opts.Header)
+
+		if header == "" {
+			return
+		}
+
+		// Keys are scoped to the client & endpoint so they cannot collide across either
+		ctx, key := r.Context(), r.HTTPMethod+" "+r.Path+" "+opts.Key(r)+" "+header
+		locked, err := opts.Store.Lock(ctx, key, opts.TTL)
+
+		if err != nil {
+			return
+		}
+
+		if !locked {
+			replayResponse(w, r, opts.Store, key)
+			return
+		}
+
+		w.After(func() {
+			resp, ok := storedResponse(w)
+
+			if !ok {
+				opts.Store.Unlock(ctx, key)
+				return
+			}
+
+			opts.Store.Save(ctx, key, resp, opts.TTL)
+		})
+	}
+}
+
+// idempotencyScope returns the subject of the claims used to authenticate the request, the
+// principal of its API Gateway authorizer or its source IP, in that order.
+func idempotencyScope(r *Request) string {
+	if sub, ok := r.Claims()["sub"].(string); ok && sub != "" {
+		return sub
+	}
+
+	if principal := r.AuthorizerString("principalId"); principal != "" {
+		return principal
+	}
+
+	if sub := r.AuthorizerString("sub"); sub != "" {
+		return sub
+	}
+
+	return r.RequestContext.Identity.SourceIP
+}
+
+// replayResponse writes the response stored for the given key, or a 409 response if the request
+// that claimed the key is still in progress.
+func replayResponse(w ResponseWriter, r *Request, store IdempotencyStore, key string) {
+	resp, err := store.Get(r.Context(), key)
+
+	if err != nil || resp == nil {
+		r.router.writeError(w, r, http.StatusConflict, errIdempotencyInFlight)
+		return
+	}
+
+	if rw, ok := w.(*responseWriter); ok {
+		rw.binary = resp.Binary
+	}
+
+	for key, values := range resp.Headers {
+		for _, value := range values {
+			w.Header().Add(key, value)
+		}
+	}
+
+	w.Header().Set("Idempotent-Replayed", "true")
+	w.WriteHeader(resp.StatusCode)
+	w.Write(resp.Body)
+}
+
+// storedResponse copies the response written by the handler so it can be stored. Responses that
+// have failed, or whose body has been streamed, cannot be stored.
+func storedResponse(w ResponseWriter) (IdempotentResponse, bool) {
+	rw, ok := w.(*responseWriter)
+
+	if !ok || rw.code == 0 || rw.code >= 500 || rw.sent > 0 {
+		return IdempotentResponse{}, false
+	}
+
+	resp := IdempotentResponse{
+		StatusCode: rw.code,
+		Headers:    make(map[string][]string, len(rw.headers)),
+		Body:       append([]byte{}, rw.body...),
+		Binary:     rw.binary,
+	}
+
+	for key, values := range rw.headers {
+		resp.Headers[key] = append([]string{}, values...)
+	}
+
+	return resp, true
+}
+
+// NewMemoryIdempotencyStore creates an IdempotencyStore that stores responses in memory.
+// Responses are only replayed for requests handled by the same lambda container.
+func NewMemoryIdempotencyStore() IdempotencyStore {
+	return &memoryIdempotencyStore{
+		entries: make(map[string]idempotencyEntry),
+	}
+}
+
+// Lock claims the given key if it has not been claimed, or its claim has expired.
+func (s *memoryIdempotencyStore) Lock(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+
+	// Periodically remove entries that have expired
+	if now.Sub(s.swept) > ttl {
+		for k, e := range s.entries {
+			if !now.Before(e.expires) {
+				delete(s.entries, k)
+			}
+		}
+
+		s.swept = now
+	}
+
+	if e, ok := s.entries[key]; ok && now.Before(e.expires) {
+		return false, nil
+	}
+
+	s.entries[key] = idempotencyEntry{expires: now.Add(ttl)}
+
+	return true, nil
+}
+
+// Get returns the response stored for the given key.
+func (s *memoryIdempotencyStore) Get(ctx context.Context, key string) (*IdempotentResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[key]
+
+	if !ok || !time.Now().Before(e.expires) {
+		return nil, nil
+	}
+
+	return e.resp, nil
+}
+
+// Save stores the response for the given key.
+func (s *memoryIdempotencyStore) Save(ctx context.Context, key string, resp IdempotentResponse, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries[key] = idempotencyEntry{resp: &resp, expires: time.Now().Add(ttl)}
+
+	return nil
+}
+
+// Unlock removes the claim on the given key.
+func (s *memoryIdempotencyStore) Unlock(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, key)
+
+	return nil
+}
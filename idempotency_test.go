@@ -0,0 +1,125 @@
+package lux_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/davidsbond/lux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotency(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Keys             []string
+		Clients          []string
+		Locked           string
+		Status           int
+		ExpectedStatuses []int
+		ExpectedBodies   []string
+		ExpectedReplayed []string
+	}{
+		// Scenario 1: Retried request is replayed
+		{
+			Keys:             []string{"a", "a"},
+			Status:           http.StatusCreated,
+			ExpectedStatuses: []int{http.StatusCreated, http.StatusCreated},
+			ExpectedBodies:   []string{`"payment 1"`, `"payment 1"`},
+			ExpectedReplayed: []string{"", "true"},
+		},
+		// Scenario 2: Requests with different keys are handled independently
+		{
+			Keys:             []string{"a", "b"},
+			Status:           http.StatusCreated,
+			ExpectedStatuses: []int{http.StatusCreated, http.StatusCreated},
+			ExpectedBodies:   []string{`"payment 1"`, `"payment 2"`},
+			ExpectedReplayed: []string{"", ""},
+		},
+		// Scenario 3: Requests without a key are always handled
+		{
+			Keys:             []string{"", ""},
+			Status:           http.StatusCreated,
+			ExpectedStatuses: []int{http.StatusCreated, http.StatusCreated},
+			ExpectedBodies:   []string{`"payment 1"`, `"payment 2"`},
+			ExpectedReplayed: []string{"", ""},
+		},
+		// Scenario 4: Failed requests can be retried
+		{
+			Keys:             []string{"a", "a"},
+			Status:           http.StatusInternalServerError,
+			ExpectedStatuses: []int{http.StatusInternalServerError, http.StatusInternalServerError},
+			ExpectedBodies:   []string{`"payment 1"`, `"payment 2"`},
+			ExpectedReplayed: []string{"", ""},
+		},
+		// Scenario 5: Request with a key that is in progress
+		{
+			Keys:             []string{"a"},
+			Locked:           "a",
+			Status:           http.StatusCreated,
+			ExpectedStatuses: []int{http.StatusConflict},
+			ExpectedBodies:   []string{`"a request with the same idempotency key is in progress"`},
+			ExpectedReplayed: []string{""},
+		},
+		// Scenario 6: Requests from different clients with the same key are handled independently
+		{
+			Keys:             []string{"a", "a"},
+			Clients:          []string{"1.2.3.4", "5.6.7.8"},
+			Status:           http.StatusCreated,
+			ExpectedStatuses: []int{http.StatusCreated, http.StatusCreated},
+			ExpectedBodies:   []string{`"payment 1"`, `"payment 2"`},
+			ExpectedReplayed: []string{"", ""},
+		},
+	}
+
+	for _, tc := range tt {
+		store := lux.NewMemoryIdempotencyStore()
+
+		// GIVEN that we have a store with any in-flight requests
+		if tc.Locked != "" {
+			store.Lock(context.Background(), "POST /payments 1.2.3.4 "+tc.Locked, time.Minute)
+		}
+
+		// AND that we have a router using the idempotency middleware
+		router := lux.NewRouter().Middleware(lux.Idempotency(lux.IdempotencyOptions{Store: store}))
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has a handler that counts its invocations
+		count := 0
+		router.Handler("POST", func(w lux.ResponseWriter, r *lux.Request) {
+			count++
+			lux.JSON(w, tc.Status, fmt.Sprintf("payment %d", count))
+		}).Path("/payments")
+
+		for i, key := range tc.Keys {
+			client := "1.2.3.4"
+
+			if tc.Clients != nil {
+				client = tc.Clients[i]
+			}
+
+			// WHEN we perform a request
+			resp, _ := router.ServeHTTP(context.Background(), lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					HTTPMethod: "POST",
+					Path:       "/payments",
+					Headers:    map[string]string{"Idempotency-Key": key},
+					RequestContext: events.APIGatewayProxyRequestContext{
+						Identity: events.APIGatewayRequestIdentity{SourceIP: client},
+					},
+				},
+			})
+
+			// THEN the response should be what we expect
+			assert.Equal(t, tc.ExpectedStatuses[i], resp.StatusCode)
+			assert.Equal(t, tc.ExpectedBodies[i], resp.Body)
+			assert.Equal(t, tc.ExpectedReplayed[i], resp.Headers["Idempotent-Replayed"])
+		}
+	}
+}
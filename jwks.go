@@ -0,0 +1,221 @@
+package lux
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+)
+
+type (
+	// jwks caches the keys obtained from a JSON Web Key Set.
+	jwks struct {
+		url    string
+		ttl    time.Duration
+		client *http.Client
+
+		mu      sync.Mutex
+		keys    map[string]jwk
+		fetched time.Time
+		failed  time.Time
+		err     error
+	}
+
+	jwk struct {
+		KeyType   string `json:"kty"`
+		KeyID     string `json:"kid"`
+		Algorithm string `json:"alg"`
+		Use       string `json:"use"`
+		N         string `json:"n"`
+		E         string `json:"e"`
+		Curve     string `json:"crv"`
+		X         string `json:"x"`
+		Y         string `json:"y"`
+
+		key interface{}
+	}
+)
+
+const (
+	defaultJWKSTTL = time.Hour
+
+	// jwksMinRefresh is the minimum time between refreshing the key set when a token
+	// references an unknown key or the previous attempt failed, preventing requests from
+	// forcing a refresh each time.
+	jwksMinRefresh = time.Minute
+)
+
+// curves maps the curves of EC keys to their implementations.
+var curves = map[string]elliptic.Curve{
+	"P-256": elliptic.P256(),
+	"P-384": elliptic.P384(),
+	"P-521": elliptic.P521(),
+}
+
+func newJWKS(url string, ttl time.Duration, client *http.Client) *jwks {
+	if ttl <= 0 {
+		ttl = defaultJWKSTTL
+	}
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &jwks{
+		url:    url,
+		ttl:    ttl,
+		client: client,
+	}
+}
+
+// key returns the public key with the given ID for use with the given algorithm. The
+// key set is obtained when the cache has expired, or when the key cannot be found &
+// the key set has not been refreshed recently. When obtaining the key set fails, the
+// error is returned without another attempt until jwksMinRefresh has passed.
+func (j *jwks) key(ctx context.Context, id, alg string) (interface{}, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	age := time.Since(j.fetched)
+	key, ok := j.keys[id]
+
+	if j.keys == nil || age > j.ttl || (!ok && age > jwksMinRefresh) {
+		if time.Since(j.failed) < jwksMinRefresh {
+			return nil, j.err
+		}
+
+		if err := j.refresh(ctx); err != nil {
+			j.failed = time.Now()
+			j.err = err
+
+			return nil, err
+		}
+
+		key, ok = j.keys[id]
+	}
+
+	if !ok {
+		return nil, fmt.Errorf("unknown key %s", id)
+	}
+
+	if key.Algorithm != "" && key.Algorithm != alg {
+		return nil, fmt.Errorf("key %s cannot be used with algorithm %s", id, alg)
+	}
+
+	if jwtAlgorithms[alg].kind != key.KeyType {
+		return nil, fmt.Errorf("key %s cannot be used with algorithm %s", id, alg)
+	}
+
+	return key.key, nil
+}
+
+// refresh obtains the key set from its URL.
+func (j *jwks) refresh(ctx context.Context) error {
+	req, err := http.NewRequest(http.MethodGet, j.url, nil)
+
+	if err != nil {
+		return fmt.Errorf("failed to create key set request, %v", err)
+	}
+
+	resp, err := j.client.Do(req.WithContext(ctx))
+
+	if err != nil {
+		return fmt.Errorf("failed to obtain key set, %v", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to obtain key set, unexpected status %d", resp.StatusCode)
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return fmt.Errorf("failed to decode key set, %v", err)
+	}
+
+	keys := make(map[string]jwk)
+
+	for _, key := range set.Keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+
+		// Skip keys that cannot be parsed, such as those using unsupported types
+		if err := key.parse(); err != nil {
+			continue
+		}
+
+		keys[key.KeyID] = key
+	}
+
+	j.keys = keys
+	j.fetched = time.Now()
+
+	return nil
+}
+
+// parse creates the public key described by the JSON web key.
+func (k *jwk) parse() error {
+	switch k.KeyType {
+	case "RSA":
+		n, err := decodeInt(k.N)
+
+		if err != nil {
+			return err
+		}
+
+		e, err := decodeInt(k.E)
+
+		if err != nil {
+			return err
+		}
+
+		k.key = &rsa.PublicKey{N: n, E: int(e.Int64())}
+	case "EC":
+		curve, ok := curves[k.Curve]
+
+		if !ok {
+			return fmt.Errorf("unsupported curve %s", k.Curve)
+		}
+
+		x, err := decodeInt(k.X)
+
+		if err != nil {
+			return err
+		}
+
+		y, err := decodeInt(k.Y)
+
+		if err != nil {
+			return err
+		}
+
+		k.key = &ecdsa.PublicKey{Curve: curve, X: x, Y: y}
+	default:
+		return fmt.Errorf("unsupported key type %s", k.KeyType)
+	}
+
+	return nil
+}
+
+// decodeInt decodes a base64url encoded big-endian integer.
+func decodeInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetBytes(data), nil
+}
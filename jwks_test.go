@@ -0,0 +1,176 @@
+package lux_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/davidsbond/lux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJWT_JWKS(t *testing.T) {
+	t.Parallel()
+
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	encode := func(i *big.Int) string {
+		return base64.RawURLEncoding.EncodeToString(i.Bytes())
+	}
+
+	var fetches int32
+
+	// GIVEN that we have a server providing a JSON web key set
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&fetches, 1)
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{
+				{
+					"kty": "RSA",
+					"kid": "rsa",
+					"use": "sig",
+					"n":   encode(rsaKey.N),
+					"e":   encode(big.NewInt(int64(rsaKey.E))),
+				},
+				{
+					"kty": "EC",
+					"kid": "ec",
+					"alg": "ES256",
+					"crv": "P-256",
+					"x":   encode(ecKey.X),
+					"y":   encode(ecKey.Y),
+				},
+			},
+		})
+	}))
+
+	defer server.Close()
+
+	tt := []struct {
+		Token          string
+		ExpectedStatus int
+		ExpectedBody   string
+	}{
+		// Scenario 1: Request with a token signed using an RSA key
+		{
+			Token:          signToken("RS256", rsaKey, "rsa", lux.Claims{"sub": "user"}),
+			ExpectedStatus: http.StatusOK,
+		},
+		// Scenario 2: Request with a token signed using an EC key
+		{
+			Token:          signToken("ES256", ecKey, "ec", lux.Claims{"sub": "user"}),
+			ExpectedStatus: http.StatusOK,
+		},
+		// Scenario 3: Request with a token signed using a different key
+		{
+			Token:          signToken("ES256", rsaKey, "ec", lux.Claims{"sub": "user"}),
+			ExpectedStatus: http.StatusUnauthorized,
+			ExpectedBody:   "\"invalid signature\"",
+		},
+		// Scenario 4: Request with a token using an algorithm the key is not for
+		{
+			Token:          signToken("ES384", ecKey, "ec", lux.Claims{"sub": "user"}),
+			ExpectedStatus: http.StatusUnauthorized,
+			ExpectedBody:   "\"key ec cannot be used with algorithm ES384\"",
+		},
+		// Scenario 5: Request with a token referencing an unknown key
+		{
+			Token:          signToken("RS256", rsaKey, "unknown", lux.Claims{"sub": "user"}),
+			ExpectedStatus: http.StatusUnauthorized,
+			ExpectedBody:   "\"unknown key unknown\"",
+		},
+		// Scenario 6: Request with a token signed using HMAC
+		{
+			Token:          signToken("HS256", []byte("secret"), "", lux.Claims{"sub": "user"}),
+			ExpectedStatus: http.StatusUnauthorized,
+			ExpectedBody:   "\"unsupported algorithm HS256\"",
+		},
+	}
+
+	// AND that we have a router using JWT authentication with the key set
+	router := lux.NewRouter().Middleware(lux.JWT(lux.JWTOptions{
+		JWKSURL: server.URL,
+		JWKSTTL: time.Minute,
+	}))
+
+	router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+	router.Handler("GET", getHandler)
+
+	for _, tc := range tt {
+		// WHEN we perform a request
+		resp, _ := router.ServeHTTP(context.Background(), lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+				HTTPMethod: "GET",
+				Headers:    map[string]string{"Authorization": "Bearer " + tc.Token},
+			},
+		})
+
+		// THEN the response should be what we expect
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+
+		if tc.ExpectedBody != "" {
+			assert.Equal(t, tc.ExpectedBody, resp.Body)
+		}
+	}
+
+	// AND the key set should only have been obtained once
+	assert.Equal(t, int32(1), atomic.LoadInt32(&fetches))
+}
+
+func TestJWT_JWKSFailure(t *testing.T) {
+	t.Parallel()
+
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+
+	var fetches int32
+
+	// GIVEN that we have a server that fails to provide a JSON web key set
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&fetches, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+
+	defer server.Close()
+
+	// AND that we have a router using JWT authentication with the key set
+	router := lux.NewRouter().Middleware(lux.JWT(lux.JWTOptions{
+		JWKSURL: server.URL,
+	}))
+
+	router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+	router.Handler("GET", getHandler)
+
+	token := signToken("RS256", rsaKey, "rsa", lux.Claims{"sub": "user"})
+
+	for i := 0; i < 3; i++ {
+		// WHEN we perform a request
+		resp, _ := router.ServeHTTP(context.Background(), lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+				HTTPMethod: "GET",
+				Headers:    map[string]string{"Authorization": "Bearer " + token},
+			},
+		})
+
+		// THEN the request should be unauthorized
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		assert.Equal(t, "\"failed to obtain key set, unexpected status 500\"", resp.Body)
+	}
+
+	// AND the key set should only have been requested once
+	assert.Equal(t, int32(1), atomic.LoadInt32(&fetches))
+}
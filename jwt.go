@@ -0,0 +1,279 @@
+package lux
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	// Register the hash functions used to verify signatures
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+)
+
+type (
+	// The JWTOptions type contains configuration for the JWT middleware. Either a Secret
+	// or a JWKSURL must be provided.
+	JWTOptions struct {
+		// Secret contains the key used to verify tokens signed using HMAC (HS256, HS384
+		// & HS512).
+		Secret []byte
+
+		// JWKSURL contains the URL of a JSON Web Key Set used to verify tokens signed
+		// using RSA (RS256, RS384 & RS512) or ECDSA (ES256, ES384 & ES512). Keys are
+		// selected using the "kid" header of the token.
+		JWKSURL string
+
+		// JWKSTTL determines how long keys obtained from the JWKSURL are cached.
+		// Defaults to one hour.
+		JWKSTTL time.Duration
+
+		// Client contains the HTTP client used to obtain keys from the JWKSURL. Defaults
+		// to http.DefaultClient.
+		Client *http.Client
+
+		// Audience contains the expected "aud" claim. When empty, the audience is not
+		// checked.
+		Audience string
+
+		// Issuer contains the expected "iss" claim. When empty, the issuer is not
+		// checked.
+		Issuer string
+
+		// Leeway contains the amount of clock skew allowed when checking the "exp" &
+		// "nbf" claims.
+		Leeway time.Duration
+	}
+
+	jwtHeader struct {
+		Algorithm string `json:"alg"`
+		KeyID     string `json:"kid"`
+	}
+
+	jwtAlgorithm struct {
+		hash crypto.Hash
+		kind string
+	}
+)
+
+var (
+	errMalformedToken   = errors.New("malformed token")
+	errInvalidSignature = errors.New("invalid signature")
+	errTokenExpired     = errors.New("token has expired")
+	errTokenNotValidYet = errors.New("token is not valid yet")
+	errInvalidAudience  = errors.New("invalid audience")
+	errInvalidIssuer    = errors.New("invalid issuer")
+
+	// jwtAlgorithms maps the supported signing algorithms to their hash function and
+	// the kind of key they are verified with.
+	jwtAlgorithms = map[string]jwtAlgorithm{
+		"HS256": {hash: crypto.SHA256, kind: "oct"},
+		"HS384": {hash: crypto.SHA384, kind: "oct"},
+		"HS512": {hash: crypto.SHA512, kind: "oct"},
+		"RS256": {hash: crypto.SHA256, kind: "RSA"},
+		"RS384": {hash: crypto.SHA384, kind: "RSA"},
+		"RS512": {hash: crypto.SHA512, kind: "RSA"},
+		"ES256": {hash: crypto.SHA256, kind: "EC"},
+		"ES384": {hash: crypto.SHA384, kind: "EC"},
+		"ES512": {hash: crypto.SHA512, kind: "EC"},
+	}
+)
+
+// JWT creates a middleware function that authenticates requests using JSON Web Tokens
+// provided as bearer tokens. The signature of the token is verified using the configured
+// secret or JSON Web Key Set & the "exp", "nbf", "aud" and "iss" claims are checked. The
+// claims of a valid token can be obtained using Request.Claims. If the token is missing
+// or invalid, a 401 response describing the error is returned, preventing execution of
+// any further middleware & the handler.
+func JWT(opts JWTOptions) HandlerFunc {
+	var keys *jwks
+
+	if opts.JWKSURL != "" {
+		keys = newJWKS(opts.JWKSURL, opts.JWKSTTL, opts.Client)
+	}
+
+	return func(w ResponseWriter, r *Request) {
+		token, ok := r.bearerToken()
+
+		if !ok {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			r.router.writeError(w, r, http.StatusUnauthorized, errUnauthorized)
+			return
+		}
+
+		claims, err := opts.parse(r.Context(), token, keys)
+
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer error="invalid_token", error_description=%q`, err.Error()))
+			r.router.writeError(w, r, http.StatusUnauthorized, err)
+			return
+		}
+
+		r.SetContext(context.WithValue(r.Context(), claimsKey, claims))
+	}
+}
+
+// Claims returns the claims of the token used to authenticate the request by the JWT
+// or BearerAuth middleware, or nil if the request has not been authenticated.
+func (r *Request) Claims() Claims {
+	claims, _ := ClaimsFromContext(r.Context())
+
+	return claims
+}
+
+// parse verifies the signature of the given token & validates its claims.
+func (o JWTOptions) parse(ctx context.Context, token string, keys *jwks) (Claims, error) {
+	parts := strings.Split(token, ".")
+
+	if len(parts) != 3 {
+		return nil, errMalformedToken
+	}
+
+	var header jwtHeader
+	var claims Claims
+
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, errMalformedToken
+	}
+
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, errMalformedToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+
+	if err != nil {
+		return nil, errMalformedToken
+	}
+
+	alg, ok := jwtAlgorithms[header.Algorithm]
+
+	if !ok {
+		return nil, fmt.Errorf("unsupported algorithm %s", header.Algorithm)
+	}
+
+	var key interface{}
+
+	// Only accept the algorithms the configured keys are intended for
+	switch {
+	case alg.kind == "oct" && len(o.Secret) > 0:
+		key = o.Secret
+	case alg.kind != "oct" && keys != nil:
+		if key, err = keys.key(ctx, header.KeyID, header.Algorithm); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported algorithm %s", header.Algorithm)
+	}
+
+	if !verifySignature(alg, key, parts[0]+"."+parts[1], signature) {
+		return nil, errInvalidSignature
+	}
+
+	if err := o.validate(claims, time.Now()); err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+// validate checks the registered claims of a token against the options.
+func (o JWTOptions) validate(claims Claims, now time.Time) error {
+	if exp, ok := claims.time("exp"); ok && now.After(exp.Add(o.Leeway)) {
+		return errTokenExpired
+	}
+
+	if nbf, ok := claims.time("nbf"); ok && now.Before(nbf.Add(-o.Leeway)) {
+		return errTokenNotValidYet
+	}
+
+	if o.Audience != "" && !claims.hasAudience(o.Audience) {
+		return errInvalidAudience
+	}
+
+	if iss, _ := claims["iss"].(string); o.Issuer != "" && iss != o.Issuer {
+		return errInvalidIssuer
+	}
+
+	return nil
+}
+
+// time returns the value of a numeric date claim.
+func (c Claims) time(name string) (time.Time, bool) {
+	value, ok := c[name].(float64)
+
+	if !ok {
+		return time.Time{}, false
+	}
+
+	return time.Unix(int64(value), 0), true
+}
+
+// hasAudience determines if the "aud" claim, which can be a string or an array of
+// strings, contains the given audience.
+func (c Claims) hasAudience(audience string) bool {
+	switch aud := c["aud"].(type) {
+	case string:
+		return aud == audience
+	case []interface{}:
+		for _, value := range aud {
+			if value == audience {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// verifySignature checks the signature of the signing input using the given key.
+func verifySignature(alg jwtAlgorithm, key interface{}, input string, signature []byte) bool {
+	if secret, ok := key.([]byte); ok {
+		mac := hmac.New(alg.hash.New, secret)
+		mac.Write([]byte(input))
+
+		return hmac.Equal(signature, mac.Sum(nil))
+	}
+
+	h := alg.hash.New()
+	h.Write([]byte(input))
+	digest := h.Sum(nil)
+
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, alg.hash, digest, signature) == nil
+	case *ecdsa.PublicKey:
+		size := (key.Curve.Params().BitSize + 7) / 8
+
+		if len(signature) != 2*size {
+			return false
+		}
+
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+
+		return ecdsa.Verify(key, digest, r, s)
+	default:
+		return false
+	}
+}
+
+// decodeSegment decodes a base64url encoded JSON segment of a token.
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,194 @@
+package lux_test
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/davidsbond/lux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJWT(t *testing.T) {
+	t.Parallel()
+
+	secret := []byte("secret")
+	now := time.Now().Unix()
+
+	tt := []struct {
+		Token          string
+		ExpectedStatus int
+		ExpectedBody   string
+		ExpectedClaims lux.Claims
+	}{
+		// Scenario 1: Request with a valid token
+		{
+			Token:          signToken("HS256", secret, "", lux.Claims{"sub": "user", "aud": "api", "iss": "lux", "exp": now + 60}),
+			ExpectedStatus: http.StatusOK,
+			ExpectedClaims: lux.Claims{"sub": "user", "aud": "api", "iss": "lux", "exp": float64(now + 60)},
+		},
+		// Scenario 2: Request with a token for multiple audiences
+		{
+			Token:          signToken("HS512", secret, "", lux.Claims{"aud": []string{"other", "api"}, "iss": "lux"}),
+			ExpectedStatus: http.StatusOK,
+			ExpectedClaims: lux.Claims{"aud": []interface{}{"other", "api"}, "iss": "lux"},
+		},
+		// Scenario 3: Request with an expired token
+		{
+			Token:          signToken("HS256", secret, "", lux.Claims{"aud": "api", "iss": "lux", "exp": now - 60}),
+			ExpectedStatus: http.StatusUnauthorized,
+			ExpectedBody:   "\"token has expired\"",
+		},
+		// Scenario 4: Request with a token that is not valid yet
+		{
+			Token:          signToken("HS256", secret, "", lux.Claims{"aud": "api", "iss": "lux", "nbf": now + 60}),
+			ExpectedStatus: http.StatusUnauthorized,
+			ExpectedBody:   "\"token is not valid yet\"",
+		},
+		// Scenario 5: Request with a token for a different audience
+		{
+			Token:          signToken("HS256", secret, "", lux.Claims{"aud": "other", "iss": "lux"}),
+			ExpectedStatus: http.StatusUnauthorized,
+			ExpectedBody:   "\"invalid audience\"",
+		},
+		// Scenario 6: Request with a token from a different issuer
+		{
+			Token:          signToken("HS256", secret, "", lux.Claims{"aud": "api", "iss": "other"}),
+			ExpectedStatus: http.StatusUnauthorized,
+			ExpectedBody:   "\"invalid issuer\"",
+		},
+		// Scenario 7: Request with a token signed using a different secret
+		{
+			Token:          signToken("HS256", []byte("other"), "", lux.Claims{"aud": "api", "iss": "lux"}),
+			ExpectedStatus: http.StatusUnauthorized,
+			ExpectedBody:   "\"invalid signature\"",
+		},
+		// Scenario 8: Request with an unsigned token
+		{
+			Token:          signToken("none", nil, "", lux.Claims{"aud": "api", "iss": "lux"}),
+			ExpectedStatus: http.StatusUnauthorized,
+			ExpectedBody:   "\"unsupported algorithm none\"",
+		},
+		// Scenario 9: Request with a malformed token
+		{
+			Token:          "not.a-token",
+			ExpectedStatus: http.StatusUnauthorized,
+			ExpectedBody:   "\"malformed token\"",
+		},
+		// Scenario 10: Request without a token
+		{
+			ExpectedStatus: http.StatusUnauthorized,
+			ExpectedBody:   "\"unauthorized\"",
+		},
+	}
+
+	for _, tc := range tt {
+		var actual lux.Claims
+
+		// GIVEN that we have a router using JWT authentication
+		router := lux.NewRouter().Middleware(lux.JWT(lux.JWTOptions{
+			Secret:   secret,
+			Audience: "api",
+			Issuer:   "lux",
+		}))
+
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has a handler that reads the claims
+		router.Handler("GET", func(w lux.ResponseWriter, r *lux.Request) {
+			actual = r.Claims()
+			w.WriteHeader(http.StatusOK)
+		})
+
+		headers := map[string]string{}
+
+		if tc.Token != "" {
+			headers["Authorization"] = "Bearer " + tc.Token
+		}
+
+		// WHEN we perform a request
+		resp, _ := router.ServeHTTP(context.Background(), lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+				HTTPMethod: "GET",
+				Headers:    headers,
+			},
+		})
+
+		// THEN the response should be what we expect
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+		assert.Equal(t, tc.ExpectedBody, resp.Body)
+
+		// AND the handler should have access to the claims
+		assert.Equal(t, tc.ExpectedClaims, actual)
+	}
+}
+
+func TestJWT_EmptySecret(t *testing.T) {
+	t.Parallel()
+
+	// GIVEN that we have a router using JWT authentication with an empty secret
+	router := lux.NewRouter().Middleware(lux.JWT(lux.JWTOptions{
+		Secret: []byte{},
+	}))
+
+	router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+	router.Handler("GET", getHandler)
+
+	// WHEN we perform a request with a token signed using an empty secret
+	resp, _ := router.ServeHTTP(context.Background(), lux.Request{
+		APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+			HTTPMethod: "GET",
+			Headers: map[string]string{
+				"Authorization": "Bearer " + signToken("HS256", []byte{}, "", lux.Claims{"sub": "user"}),
+			},
+		},
+	})
+
+	// THEN the request should be unauthorized
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Equal(t, "\"unsupported algorithm HS256\"", resp.Body)
+}
+
+// signToken creates a JSON web token with the given claims, signed using the given
+// algorithm & key.
+func signToken(alg string, key interface{}, kid string, claims lux.Claims) string {
+	header, _ := json.Marshal(map[string]string{"alg": alg, "typ": "JWT", "kid": kid})
+	payload, _ := json.Marshal(claims)
+
+	input := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	hashes := map[string]crypto.Hash{"256": crypto.SHA256, "384": crypto.SHA384, "512": crypto.SHA512}
+
+	var signature []byte
+
+	switch key := key.(type) {
+	case []byte:
+		mac := hmac.New(hashes[alg[2:]].New, key)
+		mac.Write([]byte(input))
+		signature = mac.Sum(nil)
+	case *rsa.PrivateKey:
+		h := hashes[alg[2:]].New()
+		h.Write([]byte(input))
+		signature, _ = rsa.SignPKCS1v15(rand.Reader, key, hashes[alg[2:]], h.Sum(nil))
+	case *ecdsa.PrivateKey:
+		h := hashes[alg[2:]].New()
+		h.Write([]byte(input))
+		r, s, _ := ecdsa.Sign(rand.Reader, key, h.Sum(nil))
+		size := (key.Curve.Params().BitSize + 7) / 8
+		signature = make([]byte, 2*size)
+		r.FillBytes(signature[:size])
+		s.FillBytes(signature[size:])
+	}
+
+	return input + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
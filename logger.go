@@ -0,0 +1,61 @@
+package lux
+
+import (
+	"github.com/sirupsen/logrus"
+)
+
+type (
+	// The Fields type contains the structured fields of a log entry.
+	Fields map[string]interface{}
+
+	// The Logger interface describes a structured logger used by the router to write
+	// logs. Implement it to use the logging package of your choice.
+	Logger interface {
+		Debug(msg string, fields Fields)
+		Info(msg string, fields Fields)
+		Warn(msg string, fields Fields)
+		Error(msg string, fields Fields)
+	}
+
+	logrusLogger struct {
+		log *logrus.Logger
+	}
+
+	nopLogger struct{}
+)
+
+// NewLogrusLogger creates a Logger that writes logs using the given logrus logger
+// (https://github.com/sirupsen/logrus).
+func NewLogrusLogger(log *logrus.Logger) Logger {
+	return &logrusLogger{log: log}
+}
+
+// NopLogger creates a Logger that discards all logs.
+func NopLogger() Logger {
+	return nopLogger{}
+}
+
+// Debug writes a debug level log entry with the given fields.
+func (l *logrusLogger) Debug(msg string, fields Fields) {
+	l.log.WithFields(logrus.Fields(fields)).Debug(msg)
+}
+
+// Info writes a info level log entry with the given fields.
+func (l *logrusLogger) Info(msg string, fields Fields) {
+	l.log.WithFields(logrus.Fields(fields)).Info(msg)
+}
+
+// Warn writes a warning level log entry with the given fields.
+func (l *logrusLogger) Warn(msg string, fields Fields) {
+	l.log.WithFields(logrus.Fields(fields)).Warn(msg)
+}
+
+// Error writes a error level log entry with the given fields.
+func (l *logrusLogger) Error(msg string, fields Fields) {
+	l.log.WithFields(logrus.Fields(fields)).Error(msg)
+}
+
+func (nopLogger) Debug(msg string, fields Fields) {}
+func (nopLogger) Info(msg string, fields Fields)  {}
+func (nopLogger) Warn(msg string, fields Fields)  {}
+func (nopLogger) Error(msg string, fields Fields) {}
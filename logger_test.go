@@ -0,0 +1,174 @@
+package lux_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/davidsbond/lux"
+	"github.com/stretchr/testify/assert"
+)
+
+type (
+	entry struct {
+		Level   string
+		Message string
+		Fields  lux.Fields
+	}
+
+	recordingLogger struct {
+		entries []entry
+	}
+)
+
+func (l *recordingLogger) Debug(msg string, fields lux.Fields) { l.record("debug", msg, fields) }
+func (l *recordingLogger) Info(msg string, fields lux.Fields)  { l.record("info", msg, fields) }
+func (l *recordingLogger) Warn(msg string, fields lux.Fields)  { l.record("warn", msg, fields) }
+func (l *recordingLogger) Error(msg string, fields lux.Fields) { l.record("error", msg, fields) }
+
+func (l *recordingLogger) record(level, msg string, fields lux.Fields) {
+	l.entries = append(l.entries, entry{Level: level, Message: msg, Fields: fields})
+}
+
+func TestRouter_Logger(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Handler          lux.HandlerFunc
+		ExpectedMessages []string
+	}{
+		// Scenario 1: Request is handled successfully
+		{
+			Handler:          getHandler,
+			ExpectedMessages: []string{"registered new handler", "handling incoming request", "finished handling request"},
+		},
+		// Scenario 2: Handler panics
+		{
+			Handler:          panicHandler,
+			ExpectedMessages: []string{"registered new handler", "handling incoming request", "recovered from panic", "finished handling request"},
+		},
+	}
+
+	for _, tc := range tt {
+		log := &recordingLogger{}
+
+		// GIVEN that we have a router with a custom logger
+		router := lux.NewRouter().Logger(log)
+
+		// AND that router has a handler
+		router.Handler("GET", tc.Handler).Path("/users/{id}")
+
+		// WHEN we perform a request
+		router.ServeHTTP(context.Background(), lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+				HTTPMethod: "GET",
+				Path:       "/users/42",
+			},
+		})
+
+		// THEN the logger should have received the expected entries
+		var messages []string
+
+		for _, e := range log.entries {
+			messages = append(messages, e.Message)
+		}
+
+		assert.Equal(t, tc.ExpectedMessages, messages)
+
+		// AND the access log should contain the request fields
+		access := log.entries[len(log.entries)-1]
+		assert.Equal(t, "info", access.Level)
+		assert.Equal(t, "GET", access.Fields["method"])
+		assert.Equal(t, "/users/{id}", access.Fields["route"])
+	}
+}
+
+func TestNopLogger(t *testing.T) {
+	t.Parallel()
+
+	// GIVEN that we have a router with logging disabled
+	router := lux.NewRouter().Logger(lux.NopLogger())
+	router.Handler("GET", getHandler)
+
+	// WHEN we perform a request
+	resp, err := router.ServeHTTP(context.Background(), lux.Request{
+		APIGatewayProxyRequest: events.APIGatewayProxyRequest{HTTPMethod: "GET"},
+	})
+
+	// THEN the request should be handled as normal
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestRoute_Logging(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Configure        func(route *lux.Route)
+		Path             string
+		ExpectedMessages []string
+		ExpectedBody     string
+	}{
+		// Scenario 1: Route uses the default logging
+		{
+			Configure:        func(route *lux.Route) {},
+			Path:             "/health",
+			ExpectedMessages: []string{"handling incoming request", "finished handling request"},
+		},
+		// Scenario 2: Route disables logging
+		{
+			Configure: func(route *lux.Route) { route.NoLog() },
+			Path:      "/health",
+		},
+		// Scenario 3: Route disables logging but the request is not routed
+		{
+			Configure:        func(route *lux.Route) { route.NoLog() },
+			Path:             "/unknown",
+			ExpectedMessages: []string{"handling incoming request", "finished handling request"},
+		},
+		// Scenario 4: Route logs the request body
+		{
+			Configure:        func(route *lux.Route) { route.LogBody() },
+			Path:             "/health",
+			ExpectedMessages: []string{"handling incoming request", "incoming request body", "finished handling request"},
+			ExpectedBody:     `{"name":"test"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		log := &recordingLogger{}
+
+		// GIVEN that we have a router with a custom logger
+		router := lux.NewRouter().Logger(log)
+
+		// AND that router has a route with logging configured
+		tc.Configure(router.Handler("POST", getHandler).Path("/health"))
+
+		log.entries = nil
+
+		// WHEN we perform a request
+		router.ServeHTTP(context.Background(), lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+				HTTPMethod: "POST",
+				Path:       tc.Path,
+				Body:       `{"name":"test"}`,
+			},
+		})
+
+		// THEN the logger should have received the expected entries
+		var messages []string
+
+		for _, e := range log.entries {
+			messages = append(messages, e.Message)
+
+			// AND any body should be logged at the debug level
+			if e.Message == "incoming request body" {
+				assert.Equal(t, "debug", e.Level)
+				assert.Equal(t, tc.ExpectedBody, e.Fields["body"])
+			}
+		}
+
+		assert.Equal(t, tc.ExpectedMessages, messages)
+	}
+}
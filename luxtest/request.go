@@ -0,0 +1,180 @@
+// Package luxtest provides utilities for testing handlers & middleware written using lux. Requests
+// are built using NewRequest, and can be passed to Router.ServeHTTP or Router.ServeV2 directly.
+package luxtest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/davidsbond/lux"
+)
+
+type (
+	// The RequestBuilder type is used to build requests in the format sent by API Gateway.
+	RequestBuilder struct {
+		method  string
+		path    string
+		headers http.Header
+		query   url.Values
+		params  map[string]string
+		body    string
+		base64  bool
+	}
+)
+
+// NewRequest creates a builder for a request with the given method and target. The target is
+// the path of the request, and may include a query string, such as "/users?page=2". NewRequest
+// panics if the target cannot be parsed.
+func NewRequest(method, target string) *RequestBuilder {
+	u, err := url.Parse(target)
+
+	if err != nil {
+		panic(fmt.Sprintf("failed to parse request target, %v", err))
+	}
+
+	return &RequestBuilder{
+		method:  method,
+		path:    u.Path,
+		headers: make(http.Header),
+		query:   u.Query(),
+		params:  make(map[string]string),
+	}
+}
+
+// WithHeader adds a header to the request. Calling WithHeader multiple times with the same key
+// adds multiple values for the header.
+func (b *RequestBuilder) WithHeader(key, value string) *RequestBuilder {
+	b.headers.Add(key, value)
+
+	return b
+}
+
+// WithQuery adds a query string parameter to the request. Calling WithQuery multiple times with
+// the same key adds multiple values for the parameter.
+func (b *RequestBuilder) WithQuery(key, value string) *RequestBuilder {
+	b.query.Add(key, value)
+
+	return b
+}
+
+// WithPathParam sets a path parameter of the request. Path parameters are populated by the router
+// when the request is routed, so this is only required when calling a handler directly.
+func (b *RequestBuilder) WithPathParam(key, value string) *RequestBuilder {
+	b.params[key] = value
+
+	return b
+}
+
+// WithBody sets the body of the request.
+func (b *RequestBuilder) WithBody(body string) *RequestBuilder {
+	b.body, b.base64 = body, false
+
+	return b
+}
+
+// WithJSONBody sets the body of the request to the JSON encoding of v, with an "application/json"
+// content type. WithJSONBody panics if v cannot be encoded.
+func (b *RequestBuilder) WithJSONBody(v interface{}) *RequestBuilder {
+	data, err := json.Marshal(v)
+
+	if err != nil {
+		panic(fmt.Sprintf("failed to encode request body, %v", err))
+	}
+
+	b.headers.Set("Content-Type", "application/json")
+
+	return b.WithBody(string(data))
+}
+
+// WithBinaryBody sets the body of the request to the given data with the given content type. The
+// body is base64 encoded, as it would be by API Gateway.
+func (b *RequestBuilder) WithBinaryBody(contentType string, data []byte) *RequestBuilder {
+	b.headers.Set("Content-Type", contentType)
+	b.body, b.base64 = base64.StdEncoding.EncodeToString(data), true
+
+	return b
+}
+
+// Request returns the request in the format expected by Router.ServeHTTP.
+func (b *RequestBuilder) Request() lux.Request {
+	req := lux.Request{
+		APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+			Path:                            b.path,
+			HTTPMethod:                      b.method,
+			Headers:                         make(map[string]string, len(b.headers)),
+			MultiValueHeaders:               make(map[string][]string, len(b.headers)),
+			QueryStringParameters:           make(map[string]string, len(b.query)),
+			MultiValueQueryStringParameters: make(map[string][]string, len(b.query)),
+			PathParameters:                  make(map[string]string, len(b.params)),
+			Body:                            b.body,
+			IsBase64Encoded:                 b.base64,
+			RequestContext: events.APIGatewayProxyRequestContext{
+				HTTPMethod: b.method,
+				Path:       b.path,
+			},
+		},
+	}
+
+	for key, values := range b.headers {
+		req.Headers[key] = values[len(values)-1]
+		req.MultiValueHeaders[key] = append([]string(nil), values...)
+	}
+
+	for key, values := range b.query {
+		req.QueryStringParameters[key] = values[len(values)-1]
+		req.MultiValueQueryStringParameters[key] = append([]string(nil), values...)
+	}
+
+	for key, value := range b.params {
+		req.PathParameters[key] = value
+	}
+
+	return req
+}
+
+// V2 returns the request in the version 2.0 payload format expected by Router.ServeV2. Multiple
+// values of headers & query string parameters are joined with commas, and cookies are provided
+// separately from the headers.
+func (b *RequestBuilder) V2() events.APIGatewayV2HTTPRequest {
+	req := events.APIGatewayV2HTTPRequest{
+		Version:               "2.0",
+		RouteKey:              "$default",
+		RawPath:               b.path,
+		RawQueryString:        b.query.Encode(),
+		Headers:               make(map[string]string, len(b.headers)),
+		QueryStringParameters: make(map[string]string, len(b.query)),
+		PathParameters:        make(map[string]string, len(b.params)),
+		Body:                  b.body,
+		IsBase64Encoded:       b.base64,
+		RequestContext: events.APIGatewayV2HTTPRequestContext{
+			HTTP: events.APIGatewayV2HTTPRequestContextHTTPDescription{
+				Method: b.method,
+				Path:   b.path,
+			},
+		},
+	}
+
+	for key, values := range b.headers {
+		if key == "Cookie" {
+			req.Cookies = strings.Split(strings.Join(values, "; "), "; ")
+			continue
+		}
+
+		req.Headers[strings.ToLower(key)] = strings.Join(values, ",")
+	}
+
+	for key, values := range b.query {
+		req.QueryStringParameters[key] = strings.Join(values, ",")
+	}
+
+	for key, value := range b.params {
+		req.PathParameters[key] = value
+	}
+
+	return req
+}
@@ -0,0 +1,120 @@
+package luxtest_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"net/http"
+	"testing"
+
+	"github.com/davidsbond/lux"
+	"github.com/davidsbond/lux/luxtest"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRequest(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Request        *luxtest.RequestBuilder
+		ExpectedStatus int
+		ExpectedBody   string
+	}{
+		// Scenario 1: Request with a path parameter & query string
+		{
+			Request:        luxtest.NewRequest("GET", "/users/42?fields=name&fields=email"),
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   `{"fields":["name","email"],"id":"42"}`,
+		},
+		// Scenario 2: Request with a header & query parameter
+		{
+			Request:        luxtest.NewRequest("GET", "/users/42").WithHeader("X-Tenant", "test").WithQuery("fields", "name"),
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   `{"fields":["name"],"id":"42","tenant":"test"}`,
+		},
+		// Scenario 3: Request with a JSON body
+		{
+			Request:        luxtest.NewRequest("POST", "/users").WithJSONBody(map[string]string{"name": "test"}),
+			ExpectedStatus: http.StatusCreated,
+			ExpectedBody:   `{"name":"test"}`,
+		},
+		// Scenario 4: Request with a binary body
+		{
+			Request:        luxtest.NewRequest("POST", "/users").WithBinaryBody("application/json", []byte(`{"name":"test"}`)),
+			ExpectedStatus: http.StatusCreated,
+			ExpectedBody:   `{"name":"test"}`,
+		},
+		// Scenario 5: Request with an unsupported content type
+		{
+			Request:        luxtest.NewRequest("POST", "/users").WithHeader("Content-Type", "text/plain").WithBody("test"),
+			ExpectedStatus: http.StatusUnsupportedMediaType,
+			ExpectedBody:   `"unsupported media type"`,
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router
+		router := lux.NewRouter()
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has handlers registered
+		router.Handler("GET", func(w lux.ResponseWriter, r *lux.Request) {
+			out := map[string]interface{}{"id": r.PathParam("id"), "fields": r.QueryValues("fields")}
+
+			if tenant := r.Header("X-Tenant"); tenant != "" {
+				out["tenant"] = tenant
+			}
+
+			lux.JSON(w, http.StatusOK, out)
+		}).Path("/users/{id}")
+
+		router.Handler("POST", func(w lux.ResponseWriter, r *lux.Request) {
+			var user map[string]string
+
+			if err := r.Bind(&user); err != nil {
+				lux.JSON(w, http.StatusBadRequest, err.Error())
+				return
+			}
+
+			lux.JSON(w, http.StatusCreated, user)
+		}).Path("/users").Headers("Content-Type", "application/json")
+
+		// WHEN we perform the request using each payload format
+		resp, _ := router.ServeHTTP(context.Background(), tc.Request.Request())
+		v2, _ := router.ServeV2(context.Background(), tc.Request.V2())
+
+		// THEN the responses should be what we expect
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+		assert.Equal(t, tc.ExpectedBody, resp.Body)
+		assert.Equal(t, tc.ExpectedStatus, v2.StatusCode)
+		assert.Equal(t, tc.ExpectedBody, v2.Body)
+	}
+}
+
+func TestRequestBuilder_WithPathParam(t *testing.T) {
+	t.Parallel()
+
+	// GIVEN that we have a request with a path parameter & binary body
+	req := luxtest.NewRequest("PUT", "/users/42").
+		WithPathParam("id", "42").
+		WithBinaryBody("image/png", []byte{0x89, 'P', 'N', 'G'}).
+		Request()
+
+	// WHEN we call a handler directly
+	var id string
+
+	handler := func(w lux.ResponseWriter, r *lux.Request) {
+		id = r.PathParam("id")
+	}
+
+	handler(nil, &req)
+
+	// THEN the handler should have the path parameter
+	assert.Equal(t, "42", id)
+
+	// AND the body should be base64 encoded
+	assert.True(t, req.IsBase64Encoded)
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte{0x89, 'P', 'N', 'G'}), req.Body)
+	assert.Equal(t, "image/png", req.Header("Content-Type"))
+}
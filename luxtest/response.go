@@ -0,0 +1,107 @@
+package luxtest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/davidsbond/lux"
+)
+
+// AssertStatus reports an error if the response does not have the given status code.
+func AssertStatus(t testing.TB, resp lux.Response, status int) {
+	t.Helper()
+
+	if resp.StatusCode != status {
+		t.Errorf("expected status code %d, got %d", status, resp.StatusCode)
+	}
+}
+
+// AssertHeader reports an error if the response does not have the given value for a header. Header
+// names are case-insensitive, and multi-value headers match if any of their values match.
+func AssertHeader(t testing.TB, resp lux.Response, key, value string) {
+	t.Helper()
+
+	values := Header(resp).Values(key)
+
+	for _, v := range values {
+		if v == value {
+			return
+		}
+	}
+
+	t.Errorf("expected header %s to be %q, got %q", key, value, values)
+}
+
+// AssertBody reports an error if the body of the response is not the given value. Base64 encoded
+// bodies are decoded before they are compared.
+func AssertBody(t testing.TB, resp lux.Response, body string) {
+	t.Helper()
+
+	if actual := string(Body(resp)); actual != body {
+		t.Errorf("expected body %q, got %q", body, actual)
+	}
+}
+
+// AssertJSON reports an error if the body of the response is not the JSON encoding of v. The body
+// is compared by value, so the formatting of the JSON & the order of object keys do not matter.
+func AssertJSON(t testing.TB, resp lux.Response, v interface{}) {
+	t.Helper()
+
+	data, err := json.Marshal(v)
+
+	if err != nil {
+		t.Fatalf("failed to encode expected body, %v", err)
+	}
+
+	var expected, actual interface{}
+
+	if err := json.Unmarshal(data, &expected); err != nil {
+		t.Fatalf("failed to decode expected body, %v", err)
+	}
+
+	if err := json.Unmarshal(Body(resp), &actual); err != nil {
+		t.Errorf("failed to decode response body, %v", err)
+		return
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected body %s, got %s", data, Body(resp))
+	}
+}
+
+// Header returns the headers of the response, combining its single & multi-value headers.
+func Header(resp lux.Response) http.Header {
+	headers := make(http.Header)
+
+	for key, values := range resp.MultiValueHeaders {
+		for _, value := range values {
+			headers.Add(key, value)
+		}
+	}
+
+	for key, value := range resp.Headers {
+		if _, ok := resp.MultiValueHeaders[key]; !ok {
+			headers.Add(key, value)
+		}
+	}
+
+	return headers
+}
+
+// Body returns the body of the response, decoding it if it is base64 encoded.
+func Body(resp lux.Response) []byte {
+	if !resp.IsBase64Encoded {
+		return []byte(resp.Body)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(resp.Body)
+
+	if err != nil {
+		return []byte(resp.Body)
+	}
+
+	return data
+}
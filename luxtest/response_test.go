@@ -0,0 +1,78 @@
+package luxtest_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/davidsbond/lux"
+	"github.com/davidsbond/lux/luxtest"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+type (
+	// recorder records whether an assertion failed, without failing the test.
+	recorder struct {
+		testing.TB
+		failed bool
+	}
+)
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.failed = true
+}
+
+func (r *recorder) Fatalf(format string, args ...interface{}) {
+	r.failed = true
+}
+
+func TestAssertions(t *testing.T) {
+	t.Parallel()
+
+	// GIVEN that we have a router
+	router := lux.NewRouter()
+	router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+	// AND that router has handlers registered
+	router.Handler("GET", func(w lux.ResponseWriter, r *lux.Request) {
+		w.Header().Add("Set-Cookie", "a=1")
+		w.Header().Add("Set-Cookie", "b=2")
+		lux.JSON(w, http.StatusOK, map[string]interface{}{"name": "test", "age": 42})
+	}).Path("/json")
+
+	router.Handler("GET", func(w lux.ResponseWriter, r *lux.Request) {
+		lux.Binary(w, http.StatusOK, "image/png", []byte("png"))
+	}).Path("/binary")
+
+	// WHEN we perform requests
+	resp, _ := router.ServeHTTP(context.Background(), luxtest.NewRequest("GET", "/json").Request())
+	binary, _ := router.ServeHTTP(context.Background(), luxtest.NewRequest("GET", "/binary").Request())
+
+	// THEN the assertions should pass for the expected responses
+	luxtest.AssertStatus(t, resp, http.StatusOK)
+	luxtest.AssertHeader(t, resp, "content-type", "application/json")
+	luxtest.AssertHeader(t, resp, "Set-Cookie", "b=2")
+	luxtest.AssertJSON(t, resp, map[string]interface{}{"age": 42, "name": "test"})
+	luxtest.AssertBody(t, binary, "png")
+
+	// AND should fail for unexpected responses
+	tb := &recorder{}
+	luxtest.AssertStatus(tb, resp, http.StatusNotFound)
+	assert.True(t, tb.failed)
+
+	tb = &recorder{}
+	luxtest.AssertHeader(tb, resp, "Set-Cookie", "c=3")
+	assert.True(t, tb.failed)
+
+	tb = &recorder{}
+	luxtest.AssertJSON(tb, resp, map[string]interface{}{"name": "test"})
+	assert.True(t, tb.failed)
+
+	tb = &recorder{}
+	luxtest.AssertBody(tb, binary, "jpeg")
+	assert.True(t, tb.failed)
+}
@@ -0,0 +1,105 @@
+package lux
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type (
+	// requestMetrics contains the prometheus collectors used by the metrics middleware.
+	requestMetrics struct {
+		requests *prometheus.CounterVec
+		duration *prometheus.HistogramVec
+		inFlight *prometheus.GaugeVec
+	}
+)
+
+var (
+	// MetricsRegistry contains the prometheus metrics recorded by the Metrics middleware. It
+	// can be served by a handler using the promhttp package, or sent to a push gateway using
+	// the push package.
+	MetricsRegistry = prometheus.NewRegistry()
+
+	metricsOnce sync.Once
+	metrics     *requestMetrics
+)
+
+// Metrics creates a middleware function that records prometheus metrics for each request in
+// MetricsRegistry. The following metrics are recorded, labeled by the method & route pattern of
+// the request. The route pattern is used rather than the path of the request, & non-standard
+// methods are labeled as "OTHER", so that the number of label values is bounded by the number of
+// routes. Requests that did not write a status code are recorded with a 500 status code.
+//
+//   - lux_requests_total: The number of requests handled, also labeled by status code
+//   - lux_request_duration_seconds: A histogram of request durations, also labeled by status code
+//   - lux_requests_in_flight: The number of requests currently being handled
+func Metrics() HandlerFunc {
+	metricsOnce.Do(func() {
+		metrics = newRequestMetrics(MetricsRegistry)
+	})
+
+	return func(w ResponseWriter, r *Request) {
+		start := time.Now()
+		method, route := metricsMethod(r.HTTPMethod), r.RoutePattern()
+
+		inFlight := metrics.inFlight.WithLabelValues(method, route)
+		inFlight.Inc()
+
+		w.After(func() {
+			code := w.Status()
+
+			if code == 0 {
+				code = http.StatusInternalServerError
+			}
+
+			status := strconv.Itoa(code)
+
+			inFlight.Dec()
+			metrics.requests.WithLabelValues(method, route, status).Inc()
+			metrics.duration.WithLabelValues(method, route, status).Observe(time.Since(start).Seconds())
+		})
+	}
+}
+
+// metricsMethod returns the label used for the given request method. Clients can send any method,
+// so only the methods defined by net/http are used as labels.
+func metricsMethod(method string) string {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+		http.MethodDelete, http.MethodConnect, http.MethodOptions, http.MethodTrace:
+		return method
+	default:
+		return "OTHER"
+	}
+}
+
+// newRequestMetrics creates the collectors used by the metrics middleware & registers them with
+// the given registry.
+func newRequestMetrics(reg prometheus.Registerer) *requestMetrics {
+	m := &requestMetrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "lux",
+			Name:      "requests_total",
+			Help:      "The number of requests handled.",
+		}, []string{"method", "route", "status"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "lux",
+			Name:      "request_duration_seconds",
+			Help:      "The time taken to handle requests.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		inFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "lux",
+			Name:      "requests_in_flight",
+			Help:      "The number of requests currently being handled.",
+		}, []string{"method", "route"}),
+	}
+
+	reg.MustRegister(m.requests, m.duration, m.inFlight)
+
+	return m
+}
@@ -0,0 +1,81 @@
+package lux_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/davidsbond/lux"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics(t *testing.T) {
+	// GIVEN that we have a router using the metrics middleware
+	router := lux.NewRouter()
+	router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+	router.Middleware(lux.Metrics())
+
+	// AND that router has handlers registered
+	router.Handler("GET", getHandler).Path("/metrics-test/{id}")
+	router.Handler("POST", func(w lux.ResponseWriter, r *lux.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}).Path("/metrics-test/{id}")
+
+	// AND that router has a handler for any method that never writes a response
+	router.Any(func(w lux.ResponseWriter, r *lux.Request) {}).Path("/metrics-test/{id}")
+
+	// WHEN we perform requests
+	for _, method := range []string{"GET", "GET", "POST", "PURGE"} {
+		router.ServeHTTP(context.Background(), lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{HTTPMethod: method, Path: "/metrics-test/42"},
+		})
+	}
+
+	// THEN requests should be counted by method, route pattern & status, with non-standard
+	// methods & missing status codes grouped together
+	assert.Equal(t, 3, countMetrics(t, "lux_requests_total"))
+
+	expected := `
+# HELP lux_requests_total The number of requests handled.
+# TYPE lux_requests_total counter
+lux_requests_total{method="GET",route="/metrics-test/{id}",status="200"} 2
+lux_requests_total{method="OTHER",route="/metrics-test/{id}",status="500"} 1
+lux_requests_total{method="POST",route="/metrics-test/{id}",status="400"} 1
+`
+
+	err := testutil.GatherAndCompare(lux.MetricsRegistry, bytes.NewBufferString(expected), "lux_requests_total")
+	assert.NoError(t, err)
+
+	// AND no requests should be in flight
+	expected = `
+# HELP lux_requests_in_flight The number of requests currently being handled.
+# TYPE lux_requests_in_flight gauge
+lux_requests_in_flight{method="GET",route="/metrics-test/{id}"} 0
+lux_requests_in_flight{method="OTHER",route="/metrics-test/{id}"} 0
+lux_requests_in_flight{method="POST",route="/metrics-test/{id}"} 0
+`
+
+	err = testutil.GatherAndCompare(lux.MetricsRegistry, bytes.NewBufferString(expected), "lux_requests_in_flight")
+	assert.NoError(t, err)
+
+	// AND durations should be observed
+	assert.Equal(t, 3, countMetrics(t, "lux_request_duration_seconds"))
+}
+
+// countMetrics returns the number of metrics with the given name in the metrics registry.
+func countMetrics(t *testing.T, name string) int {
+	families, err := lux.MetricsRegistry.Gather()
+	assert.NoError(t, err)
+
+	for _, family := range families {
+		if family.GetName() == name {
+			return len(family.GetMetric())
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,138 @@
+package lux
+
+import (
+	"strconv"
+	"strings"
+)
+
+type (
+	// acceptRange represents a single media range from an Accept header, such as
+	// "application/*;q=0.8".
+	acceptRange struct {
+		media   string
+		quality float64
+	}
+)
+
+// Negotiate returns the best media type from those offered, based on the request's
+// Accept header & any quality values it contains. If the request has no Accept header,
+// the first offered media type is returned. An empty string is returned if none of the
+// offered media types are acceptable. Accept is added to the Vary header of the response,
+// so that caches store a response for each media type.
+func (r *Request) Negotiate(offered ...string) string {
+	if r.response != nil {
+		r.response.headers.addVary("Accept")
+	}
+
+	return negotiate(r.header("Accept"), offered)
+}
+
+// addVary adds the given request header to the Vary header, unless it is already present.
+func (h Headers) addVary(name string) {
+	if vary := appendVary(h.Get("Vary"), name); vary != "" {
+		h.Set("Vary", vary)
+	}
+}
+
+// appendVary appends the given request header to the value of a Vary header. An empty
+// string is returned if the header is already present.
+func appendVary(vary, name string) string {
+	if strings.TrimSpace(vary) == "" {
+		return name
+	}
+
+	for _, existing := range strings.Split(vary, ",") {
+		if existing = strings.TrimSpace(existing); existing == "*" || strings.EqualFold(existing, name) {
+			return ""
+		}
+	}
+
+	return vary + ", " + name
+}
+
+// negotiate returns the offered media type with the highest quality in the given
+// Accept header. When multiple media types have the same quality, the first offered
+// is preferred.
+func negotiate(accept string, offered []string) string {
+	if len(offered) == 0 {
+		return ""
+	}
+
+	if strings.TrimSpace(accept) == "" {
+		return offered[0]
+	}
+
+	ranges := parseAccept(accept)
+	best, bestQuality := "", 0.0
+
+	for _, offer := range offered {
+		if quality := acceptQuality(ranges, offer); quality > bestQuality {
+			best, bestQuality = offer, quality
+		}
+	}
+
+	return best
+}
+
+// acceptQuality returns the quality value of the most specific range that matches the
+// given media type. A quality of zero is returned if no range matches.
+func acceptQuality(ranges []acceptRange, media string) float64 {
+	media = strings.ToLower(media)
+	mainType := strings.SplitN(media, "/", 2)[0]
+
+	quality, specificity := 0.0, -1
+
+	for _, rng := range ranges {
+		var spec int
+
+		switch {
+		case rng.media == media:
+			spec = 2
+		case rng.media == mainType+"/*":
+			spec = 1
+		case rng.media == "*/*":
+			spec = 0
+		default:
+			continue
+		}
+
+		if spec > specificity {
+			quality, specificity = rng.quality, spec
+		}
+	}
+
+	return quality
+}
+
+// parseAccept parses the media ranges from an Accept header.
+func parseAccept(accept string) []acceptRange {
+	var out []acceptRange
+
+	for _, part := range strings.Split(accept, ",") {
+		params := strings.Split(part, ";")
+		rng := acceptRange{
+			media:   strings.ToLower(strings.TrimSpace(params[0])),
+			quality: 1,
+		}
+
+		if rng.media == "" {
+			continue
+		}
+
+		for _, param := range params[1:] {
+			kv := strings.SplitN(strings.TrimSpace(param), "=", 2)
+
+			if len(kv) != 2 || strings.ToLower(kv[0]) != "q" {
+				continue
+			}
+
+			if q, err := strconv.ParseFloat(kv[1], 64); err == nil {
+				rng.quality = q
+			}
+		}
+
+		out = append(out, rng)
+	}
+
+	return out
+}
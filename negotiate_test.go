@@ -0,0 +1,174 @@
+package lux_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/davidsbond/lux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequest_Negotiate(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Accept        string
+		Offered       []string
+		ExpectedMedia string
+	}{
+		// Scenario 1: No accept header
+		{
+			Offered:       []string{"application/json", "application/xml"},
+			ExpectedMedia: "application/json",
+		},
+		// Scenario 2: Exact match
+		{
+			Accept:        "application/xml",
+			Offered:       []string{"application/json", "application/xml"},
+			ExpectedMedia: "application/xml",
+		},
+		// Scenario 3: Quality values
+		{
+			Accept:        "application/json;q=0.5, application/xml;q=0.9",
+			Offered:       []string{"application/json", "application/xml"},
+			ExpectedMedia: "application/xml",
+		},
+		// Scenario 4: Wildcards with a more specific exclusion
+		{
+			Accept:        "application/*, application/json;q=0",
+			Offered:       []string{"application/json", "application/xml"},
+			ExpectedMedia: "application/xml",
+		},
+		// Scenario 5: No acceptable media type
+		{
+			Accept:        "text/html",
+			Offered:       []string{"application/json", "application/xml"},
+			ExpectedMedia: "",
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a request with an accept header
+		req := lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+				Headers: map[string]string{"Accept": tc.Accept},
+			},
+		}
+
+		// WHEN we negotiate the media type
+		media := req.Negotiate(tc.Offered...)
+
+		// THEN the media type should be what we expect
+		assert.Equal(t, tc.ExpectedMedia, media)
+	}
+}
+
+func TestRoute_Accepts(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Accept         string
+		ExpectedStatus int
+	}{
+		// Scenario 1: Acceptable media type
+		{
+			Accept:         "application/json",
+			ExpectedStatus: http.StatusOK,
+		},
+		// Scenario 2: Unacceptable media type
+		{
+			Accept:         "text/html",
+			ExpectedStatus: http.StatusNotAcceptable,
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router
+		router := lux.NewRouter()
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has a handler with acceptable media types
+		router.Handler("GET", getHandler).Accepts("application/json", "application/xml")
+
+		// WHEN we perform the request
+		resp, _ := router.ServeHTTP(context.Background(), lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+				HTTPMethod: "GET",
+				Headers:    map[string]string{"Accept": tc.Accept},
+			},
+		})
+
+		// THEN the status code should be what we expect
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+	}
+}
+
+func TestRouter_VaryAccept(t *testing.T) {
+	t.Parallel()
+
+	negotiateHandler := func(w lux.ResponseWriter, r *lux.Request) {
+		lux.Text(w, http.StatusOK, r.Negotiate("application/json", "text/plain"))
+	}
+
+	tt := []struct {
+		Handler      lux.HandlerFunc
+		Accepts      []string
+		Middleware   []lux.HandlerFunc
+		ExpectedVary string
+	}{
+		// Scenario 1: Handler negotiates the media type
+		{
+			Handler:      negotiateHandler,
+			ExpectedVary: "Accept",
+		},
+		// Scenario 2: Route negotiates the media type
+		{
+			Handler:      getHandler,
+			Accepts:      []string{"application/json"},
+			ExpectedVary: "Accept",
+		},
+		// Scenario 3: Route & handler both negotiate the media type
+		{
+			Handler:      negotiateHandler,
+			Accepts:      []string{"application/json"},
+			ExpectedVary: "Accept",
+		},
+		// Scenario 4: Negotiated response with CORS middleware
+		{
+			Handler:      negotiateHandler,
+			Middleware:   []lux.HandlerFunc{lux.CORS(lux.CORSOptions{AllowedOrigins: []string{"https://example.com"}})},
+			ExpectedVary: "Origin, Accept",
+		},
+		// Scenario 5: Handler does not negotiate the media type
+		{
+			Handler: getHandler,
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router with middleware
+		router := lux.NewRouter().Middleware(tc.Middleware...)
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has a handler
+		router.Handler("GET", tc.Handler).Accepts(tc.Accepts...)
+
+		// WHEN we perform the request
+		resp, _ := router.ServeHTTP(context.Background(), lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+				HTTPMethod: "GET",
+				Headers: map[string]string{
+					"Accept": "application/json",
+					"Origin": "https://example.com",
+				},
+			},
+		})
+
+		// THEN the response should vary by the headers we expect
+		assert.Equal(t, tc.ExpectedVary, resp.Headers["Vary"])
+	}
+}
@@ -0,0 +1,253 @@
+package lux
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type (
+	openAPIDocument struct {
+		OpenAPI string                                 `json:"openapi"`
+		Info    openAPIInfo                            `json:"info"`
+		Paths   map[string]map[string]openAPIOperation `json:"paths"`
+	}
+
+	openAPIInfo struct {
+		Title   string `json:"title"`
+		Version string `json:"version"`
+	}
+
+	openAPIOperation struct {
+		Summary     string                     `json:"summary,omitempty"`
+		Description string                     `json:"description,omitempty"`
+		OperationID string                     `json:"operationId,omitempty"`
+		Parameters  []openAPIParameter         `json:"parameters,omitempty"`
+		RequestBody *openAPIBody               `json:"requestBody,omitempty"`
+		Responses   map[string]openAPIResponse `json:"responses"`
+	}
+
+	openAPIParameter struct {
+		Name     string        `json:"name"`
+		In       string        `json:"in"`
+		Required bool          `json:"required"`
+		Schema   openAPISchema `json:"schema"`
+	}
+
+	openAPISchema struct {
+		Type string   `json:"type"`
+		Enum []string `json:"enum,omitempty"`
+	}
+
+	openAPIBody struct {
+		Required bool                        `json:"required"`
+		Content  map[string]openAPIMediaType `json:"content"`
+	}
+
+	openAPIResponse struct {
+		Description string                      `json:"description"`
+		Content     map[string]openAPIMediaType `json:"content,omitempty"`
+	}
+
+	openAPIMediaType struct {
+		Schema json.RawMessage `json:"schema,omitempty"`
+	}
+
+	routeDoc struct {
+		summary     string
+		description string
+		responses   map[int]responseDoc
+	}
+
+	responseDoc struct {
+		description string
+		schema      string
+	}
+)
+
+// Doc attaches a summary & description to the route, which are included in the document
+// generated by Router.OpenAPI.
+func (r *Route) Doc(summary, description string) *Route {
+	r.doc.summary, r.doc.description = summary, description
+
+	return r
+}
+
+// DocResponse describes a response the route can return with the given status code for the
+// document generated by Router.OpenAPI. The schema is a JSON schema describing the body of
+// the response, and may be empty for responses without a body.
+func (r *Route) DocResponse(status int, description, schema string) *Route {
+	if r.doc.responses == nil {
+		r.doc.responses = make(map[int]responseDoc)
+	}
+
+	r.doc.responses[status] = responseDoc{description: description, schema: schema}
+
+	return r
+}
+
+// OpenAPI generates a JSON encoded OpenAPI 3 document describing the registered routes with
+// the given title & version. Each route's method, path parameters, required headers & query
+// parameters are included, along with its request body schema set using Route.Schema and any
+// documentation provided using Route.Doc & Route.DocResponse. Routes without a path, such as
+// websocket routes, and routes registered using Router.Any are not included. When multiple
+// routes handle the same method & path, only the first is included. The document can be
+// served using a route or written to a file at build time.
+func (r *Router) OpenAPI(title, version string) ([]byte, error) {
+	doc := openAPIDocument{
+		OpenAPI: "3.0.3",
+		Info:    openAPIInfo{Title: title, Version: version},
+		Paths:   make(map[string]map[string]openAPIOperation),
+	}
+
+	for _, route := range r.routes {
+		if route.path == nil || route.routeKey != "" {
+			continue
+		}
+
+		path := route.path.openAPIPath()
+
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = make(map[string]openAPIOperation)
+		}
+
+		for _, method := range route.methods {
+			// OpenAPI has no way to describe an operation for any method
+			if method == MethodAny {
+				continue
+			}
+
+			method = strings.ToLower(method)
+
+			if _, ok := doc.Paths[path][method]; !ok {
+				doc.Paths[path][method] = route.openAPIOperation()
+			}
+		}
+	}
+
+	out, err := json.Marshal(doc)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode openapi document, %v", err)
+	}
+
+	return out, nil
+}
+
+// openAPIPath returns the path pattern in the format used by OpenAPI, where catch-all
+// parameters are written as regular parameters.
+func (p *pathPattern) openAPIPath() string {
+	parts := make([]string, len(p.segments))
+
+	for i, seg := range p.segments {
+		parts[i] = seg.value
+
+		if seg.param {
+			parts[i] = "{" + seg.value + "}"
+		}
+	}
+
+	return "/" + strings.Join(parts, "/")
+}
+
+// openAPIOperation describes the route as an OpenAPI operation.
+func (r *Route) openAPIOperation() openAPIOperation {
+	op := openAPIOperation{
+		Summary:     r.doc.summary,
+		Description: r.doc.description,
+		OperationID: r.name,
+		Responses:   make(map[string]openAPIResponse),
+	}
+
+	for _, seg := range r.path.segments {
+		if seg.param {
+			op.Parameters = append(op.Parameters, openAPIParam(seg.value, "path", "*"))
+		}
+	}
+
+	// The Content-Type & Accept headers are described by the request & response content
+	for _, key := range sortedKeys(r.headers) {
+		if key != "Content-Type" && key != "Accept" {
+			op.Parameters = append(op.Parameters, openAPIParam(key, "header", r.headers[key]))
+		}
+	}
+
+	for _, key := range sortedKeys(r.queries) {
+		op.Parameters = append(op.Parameters, openAPIParam(key, "query", r.queries[key]))
+	}
+
+	if r.schema != nil || r.headers["Content-Type"] != "" {
+		op.RequestBody = &openAPIBody{
+			Required: r.schema != nil,
+			Content:  map[string]openAPIMediaType{r.requestMediaType(): {Schema: r.requestSchema()}},
+		}
+	}
+
+	for status, resp := range r.doc.responses {
+		out := openAPIResponse{Description: resp.description}
+
+		if resp.schema != "" && json.Valid([]byte(resp.schema)) {
+			out.Content = make(map[string]openAPIMediaType)
+
+			for _, media := range r.responseMediaTypes() {
+				out.Content[media] = openAPIMediaType{Schema: json.RawMessage(resp.schema)}
+			}
+		}
+
+		op.Responses[strconv.Itoa(status)] = out
+	}
+
+	// OpenAPI requires every operation to describe at least one response
+	if len(op.Responses) == 0 {
+		op.Responses["200"] = openAPIResponse{Description: http.StatusText(http.StatusOK)}
+	}
+
+	return op
+}
+
+// openAPIParam creates a required string parameter. Parameters that must have a specific
+// value list it as the only allowed value.
+func openAPIParam(name, in, value string) openAPIParameter {
+	param := openAPIParameter{
+		Name:     name,
+		In:       in,
+		Required: true,
+		Schema:   openAPISchema{Type: "string"},
+	}
+
+	if value != "*" {
+		param.Schema.Enum = []string{value}
+	}
+
+	return param
+}
+
+// requestMediaType returns the media type of request bodies handled by the route.
+func (r *Route) requestMediaType() string {
+	if media := r.headers["Content-Type"]; media != "" && media != "*" {
+		return media
+	}
+
+	return "application/json"
+}
+
+// requestSchema returns the JSON schema of request bodies handled by the route, or nil if it
+// does not have one.
+func (r *Route) requestSchema() json.RawMessage {
+	if r.schema == nil || r.schema.err != nil {
+		return nil
+	}
+
+	return json.RawMessage(r.schema.raw)
+}
+
+// responseMediaTypes returns the media types the route can respond with.
+func (r *Route) responseMediaTypes() []string {
+	if len(r.accepts) > 0 {
+		return r.accepts
+	}
+
+	return []string{"application/json"}
+}
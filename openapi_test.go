@@ -0,0 +1,87 @@
+package lux_test
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/davidsbond/lux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouter_OpenAPI(t *testing.T) {
+	t.Parallel()
+
+	// GIVEN that we have a router
+	router := lux.NewRouter()
+	router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+	// AND that router has documented handlers
+	router.Handler("GET", getHandler).
+		Path("/users/{id}").
+		Name("getUser").
+		Headers("X-Api-Key", "*").
+		Queries("fields", "all").
+		Doc("Get a user", "Returns a single user.").
+		DocResponse(http.StatusOK, "The user", `{"type":"object"}`).
+		DocResponse(http.StatusNotFound, "User not found", "")
+
+	router.Handler("POST", getHandler).
+		Path("/users").
+		Schema(`{"type":"object","required":["name"]}`)
+
+	// AND that router has handlers that cannot be documented
+	router.Handler("GET", getHandler)
+	router.Handler("DELETE", getHandler).Path("/users/{id}").Methods("DELETE", "PUT")
+	router.Handler("DELETE", getHandler).Path("/users/{id}").Headers("X-Force", "true")
+
+	// WHEN we generate the OpenAPI document
+	doc, err := router.OpenAPI("Users", "1.0.0")
+
+	// THEN the document should describe the documented routes
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"openapi": "3.0.3",
+		"info": {"title": "Users", "version": "1.0.0"},
+		"paths": {
+			"/users/{id}": {
+				"get": {
+					"summary": "Get a user",
+					"description": "Returns a single user.",
+					"operationId": "getUser",
+					"parameters": [
+						{"name": "id", "in": "path", "required": true, "schema": {"type": "string"}},
+						{"name": "X-Api-Key", "in": "header", "required": true, "schema": {"type": "string"}},
+						{"name": "fields", "in": "query", "required": true, "schema": {"type": "string", "enum": ["all"]}}
+					],
+					"responses": {
+						"200": {"description": "The user", "content": {"application/json": {"schema": {"type": "object"}}}},
+						"404": {"description": "User not found"}
+					}
+				},
+				"delete": {
+					"parameters": [
+						{"name": "id", "in": "path", "required": true, "schema": {"type": "string"}}
+					],
+					"responses": {"200": {"description": "OK"}}
+				},
+				"put": {
+					"parameters": [
+						{"name": "id", "in": "path", "required": true, "schema": {"type": "string"}}
+					],
+					"responses": {"200": {"description": "OK"}}
+				}
+			},
+			"/users": {
+				"post": {
+					"requestBody": {
+						"required": true,
+						"content": {"application/json": {"schema": {"type": "object", "required": ["name"]}}}
+					},
+					"responses": {"200": {"description": "OK"}}
+				}
+			}
+		}
+	}`, string(doc))
+}
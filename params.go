@@ -0,0 +1,165 @@
+package lux
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// BindQuery decodes the query parameters of the request into the struct pointed to by v. Fields
+// are mapped to parameters using their "query" tag, such as `query:"page"`, and parameters can
+// be marked as required using `query:"page,required"`. Strings, booleans, integers, floats &
+// times in the RFC 3339 format are supported, along with slices of them for parameters provided
+// multiple times. Once bound, the struct is validated using Validate. If a required parameter
+// is missing, an error wrapping ErrMissingParam is returned.
+func (r *Request) BindQuery(v interface{}) error {
+	return bindParams(v, "query", "query parameter", r.QueryValues)
+}
+
+// BindPath decodes the path parameters of the request into the struct pointed to by v like
+// BindQuery. Fields are mapped to parameters using their "path" tag, such as `path:"id"`.
+func (r *Request) BindPath(v interface{}) error {
+	return bindParams(v, "path", "path parameter", func(name string) []string {
+		if value, ok := r.pathParam(name); ok {
+			return []string{value}
+		}
+
+		return nil
+	})
+}
+
+// bindParams sets the fields of the struct pointed to by v with the given tag to the values
+// returned by the lookup function, then validates the struct.
+func bindParams(v interface{}, tag, kind string, lookup func(string) []string) error {
+	val := reflect.ValueOf(v)
+
+	if val.Kind() != reflect.Ptr || val.IsNil() {
+		return fmt.Errorf("cannot bind to non-pointer type %T", v)
+	}
+
+	val = val.Elem()
+
+	if val.Kind() != reflect.Struct {
+		return fmt.Errorf("cannot bind to non-struct type %s", val.Type())
+	}
+
+	typ := val.Type()
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		opts := strings.Split(field.Tag.Get(tag), ",")
+		name := opts[0]
+
+		// Unexported & untagged fields cannot be bound
+		if field.PkgPath != "" || name == "" || name == "-" {
+			continue
+		}
+
+		values := lookup(name)
+
+		if len(values) == 0 {
+			if hasOption(opts[1:], "required") {
+				return fmt.Errorf("missing %s %s, %w", kind, name, ErrMissingParam)
+			}
+
+			continue
+		}
+
+		if err := setField(val.Field(i), values); err != nil {
+			return fmt.Errorf("failed to parse %s %s, %v", kind, name, err)
+		}
+	}
+
+	return Validate(v)
+}
+
+// hasOption determines if the given tag options contain the option.
+func hasOption(opts []string, option string) bool {
+	for _, opt := range opts {
+		if strings.TrimSpace(opt) == option {
+			return true
+		}
+	}
+
+	return false
+}
+
+// setField sets the given field to the given values. Slices are set to all of the values,
+// while other types are set to the last value.
+func setField(field reflect.Value, values []string) error {
+	if field.Kind() != reflect.Slice {
+		return setValue(field, values[len(values)-1])
+	}
+
+	out := reflect.MakeSlice(field.Type(), len(values), len(values))
+
+	for i, value := range values {
+		if err := setValue(out.Index(i), value); err != nil {
+			return err
+		}
+	}
+
+	field.Set(out)
+
+	return nil
+}
+
+// setValue parses the given string into the field based on its type.
+func setValue(field reflect.Value, value string) error {
+	if field.Type() == timeType {
+		t, err := time.Parse(time.RFC3339, value)
+
+		if err != nil {
+			return err
+		}
+
+		field.Set(reflect.ValueOf(t))
+
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+
+		if err != nil {
+			return err
+		}
+
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, field.Type().Bits())
+
+		if err != nil {
+			return err
+		}
+
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, field.Type().Bits())
+
+		if err != nil {
+			return err
+		}
+
+		field.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, field.Type().Bits())
+
+		if err != nil {
+			return err
+		}
+
+		field.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported type %s", field.Type())
+	}
+
+	return nil
+}
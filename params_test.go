@@ -0,0 +1,165 @@
+package lux_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/davidsbond/lux"
+	"github.com/stretchr/testify/assert"
+)
+
+type (
+	listUsersQuery struct {
+		Page    int       `query:"page,required"`
+		Limit   uint8     `query:"limit"`
+		Active  bool      `query:"active"`
+		Score   float64   `query:"score"`
+		Since   time.Time `query:"since"`
+		Tags    []string  `query:"tag"`
+		IDs     []int     `query:"id"`
+		Sort    string    `query:"sort" validate:"omitempty,oneof=name age"`
+		Ignored string
+	}
+
+	userPath struct {
+		ID   int    `path:"id"`
+		Name string `path:"name,required"`
+	}
+)
+
+func TestRequest_BindQuery(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Query         map[string]string
+		MultiQuery    map[string][]string
+		ExpectedValue listUsersQuery
+		ExpectedError string
+		ExpectMissing bool
+	}{
+		// Scenario 1: Query parameters of each type
+		{
+			Query: map[string]string{
+				"page":   "2",
+				"limit":  "10",
+				"active": "true",
+				"score":  "4.5",
+				"since":  "2020-01-02T03:04:05Z",
+				"sort":   "name",
+			},
+			MultiQuery: map[string][]string{
+				"tag": {"a", "b"},
+				"id":  {"1", "2"},
+			},
+			ExpectedValue: listUsersQuery{
+				Page:   2,
+				Limit:  10,
+				Active: true,
+				Score:  4.5,
+				Since:  time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+				Tags:   []string{"a", "b"},
+				IDs:    []int{1, 2},
+				Sort:   "name",
+			},
+		},
+		// Scenario 2: Missing required query parameter
+		{
+			Query:         map[string]string{"limit": "10"},
+			ExpectedError: "missing query parameter page, parameter not found",
+			ExpectMissing: true,
+		},
+		// Scenario 3: Query parameter cannot be parsed
+		{
+			Query:         map[string]string{"page": "two"},
+			ExpectedError: `failed to parse query parameter page, strconv.ParseInt: parsing "two": invalid syntax`,
+		},
+		// Scenario 4: Query parameter overflows its type
+		{
+			Query:         map[string]string{"page": "1", "limit": "256"},
+			ExpectedError: `failed to parse query parameter limit, strconv.ParseUint: parsing "256": value out of range`,
+		},
+		// Scenario 5: Query parameter fails validation
+		{
+			Query:         map[string]string{"page": "1", "sort": "email"},
+			ExpectedError: "validation failed for Sort (oneof)",
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a request with query parameters
+		req := lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+				QueryStringParameters:           tc.Query,
+				MultiValueQueryStringParameters: tc.MultiQuery,
+			},
+		}
+
+		// WHEN we bind the query parameters
+		var out listUsersQuery
+		err := req.BindQuery(&out)
+
+		// THEN the error should be what we expect
+		if tc.ExpectedError != "" {
+			assert.EqualError(t, err, tc.ExpectedError)
+			assert.Equal(t, tc.ExpectMissing, errors.Is(err, lux.ErrMissingParam))
+			continue
+		}
+
+		// AND the struct should contain the parameters
+		assert.NoError(t, err)
+		assert.Equal(t, tc.ExpectedValue, out)
+	}
+}
+
+func TestRequest_BindPath(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Params        map[string]string
+		Target        interface{}
+		ExpectedValue interface{}
+		ExpectedError string
+	}{
+		// Scenario 1: Path parameters are bound
+		{
+			Params:        map[string]string{"id": "42", "name": "test"},
+			Target:        &userPath{},
+			ExpectedValue: &userPath{ID: 42, Name: "test"},
+		},
+		// Scenario 2: Missing required path parameter
+		{
+			Params:        map[string]string{"id": "42"},
+			Target:        &userPath{},
+			ExpectedError: "missing path parameter name, parameter not found",
+		},
+		// Scenario 3: Binding to a non-pointer
+		{
+			Params:        map[string]string{"id": "42"},
+			Target:        userPath{},
+			ExpectedError: "cannot bind to non-pointer type lux_test.userPath",
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a request with path parameters
+		req := lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+				PathParameters: tc.Params,
+			},
+		}
+
+		// WHEN we bind the path parameters
+		err := req.BindPath(tc.Target)
+
+		// THEN the result should be what we expect
+		if tc.ExpectedError != "" {
+			assert.EqualError(t, err, tc.ExpectedError)
+			continue
+		}
+
+		assert.NoError(t, err)
+		assert.Equal(t, tc.ExpectedValue, tc.Target)
+	}
+}
@@ -0,0 +1,141 @@
+package lux
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+type (
+	// pathPattern represents a parsed route path such as "/users/{id}". Each segment is
+	// either a static value that must match exactly, or a named parameter wrapped in
+	// braces that matches any non-empty value. The last segment may be a catch-all
+	// parameter such as "{path...}", which matches the remainder of the path.
+	pathPattern struct {
+		raw      string
+		segments []pathSegment
+		err      error
+	}
+
+	pathSegment struct {
+		value    string
+		param    bool
+		catchAll bool
+	}
+)
+
+// newPathPattern parses the given path into its segments.
+func newPathPattern(path string) *pathPattern {
+	pattern := &pathPattern{raw: path}
+
+	parts := splitPath(path)
+
+	for i, part := range parts {
+		seg := pathSegment{value: part}
+
+		// Segments wrapped in braces are named parameters
+		if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
+			seg.value = part[1 : len(part)-1]
+			seg.param = true
+		}
+
+		// Parameters ending with an ellipsis capture the remainder of the path
+		if seg.param && strings.HasSuffix(seg.value, "...") {
+			seg.value = strings.TrimSuffix(seg.value, "...")
+			seg.catchAll = true
+		}
+
+		if seg.catchAll && i != len(parts)-1 {
+			pattern.err = fmt.Errorf("catch-all parameter %s must be the last segment of %s", seg.value, path)
+		}
+
+		pattern.segments = append(pattern.segments, seg)
+	}
+
+	return pattern
+}
+
+// match determines if the given path matches the pattern. If it does, the values of
+// any named parameters are returned. Patterns without named parameters return a nil
+// map, to avoid allocating for static paths.
+func (p *pathPattern) match(path string) (map[string]string, bool) {
+	parts := splitPath(path)
+
+	if p.err != nil || !p.matchLength(len(parts)) {
+		return nil, false
+	}
+
+	var params map[string]string
+
+	for i, seg := range p.segments {
+		// Catch-all parameters are always last, and capture the remaining segments
+		if seg.catchAll {
+			parts[i] = strings.Join(parts[i:], "/")
+		}
+
+		switch {
+		case seg.param && parts[i] != "" && params == nil:
+			params = map[string]string{seg.value: parts[i]}
+		case seg.param && parts[i] != "":
+			params[seg.value] = parts[i]
+		case seg.param, seg.value != parts[i]:
+			return nil, false
+		}
+	}
+
+	return params, true
+}
+
+// matchLength determines if a path with the given number of segments can match the pattern.
+// Patterns ending with a catch-all parameter match paths with at least as many segments.
+func (p *pathPattern) matchLength(n int) bool {
+	if last := len(p.segments) - 1; last >= 0 && p.segments[last].catchAll {
+		return n >= len(p.segments)
+	}
+
+	return n == len(p.segments)
+}
+
+// splitPath splits a path into its segments, ignoring the leading slash.
+func splitPath(path string) []string {
+	return strings.Split(strings.TrimPrefix(path, "/"), "/")
+}
+
+// build creates a path from the pattern, replacing named parameters with the given
+// values.
+func (p *pathPattern) build(params map[string]string) (string, error) {
+	parts := make([]string, len(p.segments))
+
+	for i, seg := range p.segments {
+		if !seg.param {
+			parts[i] = seg.value
+			continue
+		}
+
+		value, ok := params[seg.value]
+
+		if !ok || value == "" {
+			return "", fmt.Errorf("missing value for path parameter %s", seg.value)
+		}
+
+		parts[i] = url.PathEscape(value)
+
+		// Catch-all parameters may contain multiple segments, each escaped separately
+		if seg.catchAll {
+			parts[i] = escapeSegments(value)
+		}
+	}
+
+	return "/" + strings.Join(parts, "/"), nil
+}
+
+// escapeSegments escapes each segment of the given path.
+func escapeSegments(path string) string {
+	parts := strings.Split(path, "/")
+
+	for i, part := range parts {
+		parts[i] = url.PathEscape(part)
+	}
+
+	return strings.Join(parts, "/")
+}
@@ -0,0 +1,113 @@
+package lux
+
+import (
+	"net"
+	"strings"
+)
+
+// TrustedProxies sets the proxies whose entries in the X-Forwarded-For & X-Forwarded-Proto
+// headers are skipped by Request.ClientIP & Request.Scheme, such as a CloudFront distribution
+// in front of the API. Proxies are given as CIDR ranges or IP addresses.
+//
+// API Gateway & Application Load Balancers append the address of the client connecting to
+// them to the X-Forwarded-For header, so by default only the last entry is trusted, preventing
+// clients from spoofing their address. Entries are read from right to left, skipping those
+// added by the given proxies. Invalid proxies are reported by Router.Err.
+func (r *Router) TrustedProxies(cidrs ...string) *Router {
+	r.trustedProxies = make([]*net.IPNet, 0, len(cidrs))
+
+	for _, cidr := range cidrs {
+		network, err := parseProxy(cidr)
+
+		if err != nil {
+			r.invalidProxies = append(r.invalidProxies, cidr)
+			continue
+		}
+
+		r.trustedProxies = append(r.trustedProxies, network)
+	}
+
+	return r
+}
+
+// parseProxy parses the given CIDR range, or IP address, into the network it describes.
+func parseProxy(cidr string) (*net.IPNet, error) {
+	if strings.Contains(cidr, "/") {
+		_, network, err := net.ParseCIDR(cidr)
+
+		return network, err
+	}
+
+	ip := net.ParseIP(cidr)
+
+	if ip == nil {
+		return nil, &net.ParseError{Type: "IP address", Text: cidr}
+	}
+
+	if ip4 := ip.To4(); ip4 != nil {
+		return &net.IPNet{IP: ip4, Mask: net.CIDRMask(32, 32)}, nil
+	}
+
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}, nil
+}
+
+// trustsProxy determines if the given address belongs to a trusted proxy.
+func (r *Router) trustsProxy(addr string) bool {
+	ip := net.ParseIP(addr)
+
+	if ip == nil {
+		return false
+	}
+
+	for _, network := range r.trustedProxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// forwardedHop returns the index of the entry in the given X-Forwarded-For addresses that
+// describes the client, or -1 if there are no addresses. The last address, which is added by
+// API Gateway or the load balancer, is used unless it belongs to a trusted proxy.
+func (r *Request) forwardedHop(addrs []string) int {
+	if len(addrs) == 0 {
+		return -1
+	}
+
+	for i := len(addrs) - 1; i > 0; i-- {
+		if r.router == nil || !r.router.trustsProxy(addrs[i]) {
+			return i
+		}
+	}
+
+	return 0
+}
+
+// forwardedHeader returns the entries of the given forwarded header, including those of each
+// value when it is provided multiple times.
+func (r *Request) forwardedHeader(name string) []string {
+	for key, values := range r.MultiValueHeaders {
+		if strings.EqualFold(key, name) && len(values) > 0 {
+			return forwardedValues(strings.Join(values, ","))
+		}
+	}
+
+	return forwardedValues(r.header(name))
+}
+
+// forwardedValues splits the given comma separated forwarded header into its entries.
+func forwardedValues(header string) []string {
+	if strings.TrimSpace(header) == "" {
+		return nil
+	}
+
+	values := strings.Split(header, ",")
+
+	for i, value := range values {
+		values[i] = strings.TrimSpace(value)
+	}
+
+	return values
+}
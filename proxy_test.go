@@ -0,0 +1,125 @@
+package lux_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/davidsbond/lux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouter_TrustedProxies(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Proxies        []string
+		Trusted        bool
+		Headers        map[string]string
+		SourceIP       string
+		ExpectedIP     string
+		ExpectedScheme string
+	}{
+		// Scenario 1: Only the entries added by API Gateway are trusted by default
+		{
+			Headers:        map[string]string{"X-Forwarded-For": "6.6.6.6, 2.2.2.2", "X-Forwarded-Proto": "http, HTTPS"},
+			SourceIP:       "2.2.2.2",
+			ExpectedIP:     "2.2.2.2",
+			ExpectedScheme: "https",
+		},
+		// Scenario 2: Only the address added by API Gateway is trusted without any proxies
+		{
+			Trusted:        true,
+			Headers:        map[string]string{"X-Forwarded-For": "6.6.6.6, 2.2.2.2", "X-Forwarded-Proto": "https"},
+			SourceIP:       "2.2.2.2",
+			ExpectedIP:     "2.2.2.2",
+			ExpectedScheme: "https",
+		},
+		// Scenario 3: Addresses added by trusted proxies are skipped
+		{
+			Proxies:        []string{"10.0.0.0/8", "3.3.3.3"},
+			Trusted:        true,
+			Headers:        map[string]string{"X-Forwarded-For": "6.6.6.6, 2.2.2.2, 10.1.2.3, 3.3.3.3", "X-Forwarded-Proto": "http, https, https"},
+			SourceIP:       "3.3.3.3",
+			ExpectedIP:     "2.2.2.2",
+			ExpectedScheme: "http",
+		},
+		// Scenario 4: First address is used when every proxy is trusted
+		{
+			Proxies:        []string{"10.0.0.0/8"},
+			Trusted:        true,
+			Headers:        map[string]string{"X-Forwarded-For": "10.0.0.1, 10.0.0.2"},
+			SourceIP:       "10.0.0.2",
+			ExpectedIP:     "10.0.0.1",
+			ExpectedScheme: "https",
+		},
+		// Scenario 5: Source IP is used without forwarded headers
+		{
+			Proxies:        []string{"10.0.0.0/8"},
+			Trusted:        true,
+			SourceIP:       "2.2.2.2",
+			ExpectedIP:     "2.2.2.2",
+			ExpectedScheme: "https",
+		},
+		// Scenario 6: IPv6 proxies
+		{
+			Proxies:        []string{"2001:db8::/32"},
+			Trusted:        true,
+			Headers:        map[string]string{"X-Forwarded-For": "6.6.6.6, 2001:db8::2, 2001:db8::1"},
+			ExpectedIP:     "6.6.6.6",
+			ExpectedScheme: "https",
+		},
+	}
+
+	for _, tc := range tt {
+		var ip, scheme string
+
+		// GIVEN that we have a router
+		router := lux.NewRouter()
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router trusts the proxies
+		if tc.Trusted {
+			router.TrustedProxies(tc.Proxies...)
+		}
+
+		// AND that router has a handler that reads the client IP & scheme
+		router.Handler("GET", func(w lux.ResponseWriter, r *lux.Request) {
+			ip, scheme = r.ClientIP(), r.Scheme()
+			w.WriteHeader(http.StatusOK)
+		})
+
+		req := lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+				HTTPMethod: "GET",
+				Headers:    tc.Headers,
+			},
+		}
+
+		req.RequestContext.Identity.SourceIP = tc.SourceIP
+
+		// WHEN we perform the request
+		router.ServeHTTP(context.Background(), req)
+
+		// THEN the client IP & scheme should be what we expect
+		assert.Equal(t, tc.ExpectedIP, ip)
+		assert.Equal(t, tc.ExpectedScheme, scheme)
+	}
+}
+
+func TestRouter_TrustedProxiesErr(t *testing.T) {
+	t.Parallel()
+
+	// GIVEN that we have a router with an invalid trusted proxy
+	router := lux.NewRouter().TrustedProxies("10.0.0.0/8", "not-an-ip", "10.0.0.0/33")
+	router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+	// WHEN we check the router for errors
+	err := router.Err()
+
+	// THEN the invalid proxies should be reported
+	assert.EqualError(t, err, "failed to configure trusted proxies, invalid proxies not-an-ip, 10.0.0.0/33")
+}
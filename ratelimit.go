@@ -0,0 +1,146 @@
+package lux
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+type (
+	// The RateLimitOptions type contains configuration for the rate limiting middleware.
+	RateLimitOptions struct {
+		// Limit contains the number of requests allowed for each key within a window.
+		Limit int
+
+		// Window contains the duration of each window. Defaults to one minute.
+		Window time.Duration
+
+		// Key returns the key requests are limited by. Defaults to the source IP of
+		// the request.
+		Key func(*Request) string
+
+		// Store contains the backend used to count requests. As lambda functions are
+		// stateless, a shared store such as DynamoDB or Redis should be used to limit
+		// requests across containers. Defaults to an in-memory store.
+		Store RateLimitStore
+	}
+
+	// The RateLimitStore interface describes a backend that counts requests for the
+	// rate limiting middleware using fixed windows.
+	RateLimitStore interface {
+		// Increment increments the number of requests for the given key within the
+		// current window, starting a new window of the given duration if the previous
+		// one has ended. It returns the number of requests in the window and the time
+		// the window ends.
+		Increment(ctx context.Context, key string, window time.Duration) (int, time.Time, error)
+	}
+
+	memoryRateLimitStore struct {
+		mu      sync.Mutex
+		windows map[string]rateLimitWindow
+		swept   time.Time
+	}
+
+	rateLimitWindow struct {
+		count int
+		reset time.Time
+	}
+)
+
+var errRateLimited = errors.New("rate limit exceeded")
+
+// RateLimit creates a middleware function that limits the number of requests clients can
+// make within a fixed window. The X-RateLimit-Limit, X-RateLimit-Remaining and
+// X-RateLimit-Reset headers are set on responses. Once the limit has been exceeded, a 429
+// response is returned with a Retry-After header, preventing execution of any further
+// middleware & the handler. Requests are allowed if the store returns an error.
+func RateLimit(opts RateLimitOptions) HandlerFunc {
+	if opts.Window <= 0 {
+		opts.Window = time.Minute
+	}
+
+	if opts.Key == nil {
+		opts.Key = func(r *Request) string {
+			return r.RequestContext.Identity.SourceIP
+		}
+	}
+
+	if opts.Store == nil {
+		opts.Store = NewMemoryRateLimitStore()
+	}
+
+	return func(w ResponseWriter, r *Request) {
+		count, reset, err := opts.Store.Increment(r.Context(), opts.Key(r), opts.Window)
+
+		if err != nil {
+			return
+		}
+
+		remaining := opts.Limit - count
+
+		if remaining < 0 {
+			remaining = 0
+		}
+
+		headers := w.Header()
+		headers.Set("X-RateLimit-Limit", strconv.Itoa(opts.Limit))
+		headers.Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+		headers.Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+
+		if count <= opts.Limit {
+			return
+		}
+
+		retry := int(math.Ceil(time.Until(reset).Seconds()))
+
+		if retry < 1 {
+			retry = 1
+		}
+
+		headers.Set("Retry-After", strconv.Itoa(retry))
+		r.router.writeError(w, r, http.StatusTooManyRequests, errRateLimited)
+	}
+}
+
+// NewMemoryRateLimitStore creates a RateLimitStore that counts requests in memory. Counts
+// are only shared between requests handled by the same lambda container.
+func NewMemoryRateLimitStore() RateLimitStore {
+	return &memoryRateLimitStore{
+		windows: make(map[string]rateLimitWindow),
+	}
+}
+
+// Increment increments the number of requests for the given key within the current
+// window.
+func (s *memoryRateLimitStore) Increment(ctx context.Context, key string, window time.Duration) (int, time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+
+	// Periodically remove windows that have ended
+	if now.Sub(s.swept) > window {
+		for k, w := range s.windows {
+			if !now.Before(w.reset) {
+				delete(s.windows, k)
+			}
+		}
+
+		s.swept = now
+	}
+
+	w, ok := s.windows[key]
+
+	if !ok || !now.Before(w.reset) {
+		w = rateLimitWindow{reset: now.Add(window)}
+	}
+
+	w.count++
+	s.windows[key] = w
+
+	return w.count, w.reset, nil
+}
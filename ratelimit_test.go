@@ -0,0 +1,96 @@
+package lux_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/davidsbond/lux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+type failingStore struct{}
+
+func (failingStore) Increment(ctx context.Context, key string, window time.Duration) (int, time.Time, error) {
+	return 0, time.Time{}, errors.New("unavailable")
+}
+
+func TestRateLimit(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Options           lux.RateLimitOptions
+		Requests          []string
+		ExpectedStatuses  []int
+		ExpectedRemaining []string
+	}{
+		// Scenario 1: Client exceeds the limit
+		{
+			Options:           lux.RateLimitOptions{Limit: 2, Window: time.Minute},
+			Requests:          []string{"1.1.1.1", "1.1.1.1", "1.1.1.1"},
+			ExpectedStatuses:  []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests},
+			ExpectedRemaining: []string{"1", "0", "0"},
+		},
+		// Scenario 2: Clients are limited independently
+		{
+			Options:           lux.RateLimitOptions{Limit: 1, Window: time.Minute},
+			Requests:          []string{"1.1.1.1", "2.2.2.2", "1.1.1.1"},
+			ExpectedStatuses:  []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests},
+			ExpectedRemaining: []string{"0", "0", "0"},
+		},
+		// Scenario 3: Requests limited using a custom key
+		{
+			Options: lux.RateLimitOptions{
+				Limit:  1,
+				Window: time.Minute,
+				Key: func(r *lux.Request) string {
+					return "global"
+				},
+			},
+			Requests:          []string{"1.1.1.1", "2.2.2.2"},
+			ExpectedStatuses:  []int{http.StatusOK, http.StatusTooManyRequests},
+			ExpectedRemaining: []string{"0", "0"},
+		},
+		// Scenario 4: Requests are allowed when the store fails
+		{
+			Options:           lux.RateLimitOptions{Limit: 1, Store: failingStore{}},
+			Requests:          []string{"1.1.1.1", "1.1.1.1"},
+			ExpectedStatuses:  []int{http.StatusOK, http.StatusOK},
+			ExpectedRemaining: []string{"", ""},
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router using rate limiting
+		router := lux.NewRouter().Middleware(lux.RateLimit(tc.Options))
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has a handler
+		router.Handler("GET", getHandler)
+
+		for i, ip := range tc.Requests {
+			req := lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{HTTPMethod: "GET"},
+			}
+
+			req.RequestContext.Identity.SourceIP = ip
+
+			// WHEN we perform a request
+			resp, _ := router.ServeHTTP(context.Background(), req)
+
+			// THEN the response should have the expected status & headers
+			assert.Equal(t, tc.ExpectedStatuses[i], resp.StatusCode)
+			assert.Equal(t, tc.ExpectedRemaining[i], resp.Headers["X-Ratelimit-Remaining"])
+
+			// AND limited requests should be told when to retry
+			if resp.StatusCode == http.StatusTooManyRequests {
+				assert.Equal(t, "60", resp.Headers["Retry-After"])
+			}
+		}
+	}
+}
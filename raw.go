@@ -0,0 +1,45 @@
+package lux
+
+import (
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// Raw returns the API Gateway event the request was created from, allowing handlers to access
+// fields that lux does not provide helpers for. Requests received using ServeV2, ServeALB,
+// ServeWebSocket or ServeStream are converted into this format, so use RawV2, RawALB,
+// RawWebSocket or RawFunctionURL to access the original event for those requests.
+func (r *Request) Raw() events.APIGatewayProxyRequest {
+	return r.APIGatewayProxyRequest
+}
+
+// RawV2 returns the version 2.0 API Gateway event the request was created from, and whether
+// or not the request was received using ServeV2.
+func (r *Request) RawV2() (events.APIGatewayV2HTTPRequest, bool) {
+	raw, ok := r.raw.(events.APIGatewayV2HTTPRequest)
+
+	return raw, ok
+}
+
+// RawALB returns the load balancer event the request was created from, and whether or not the
+// request was received using ServeALB.
+func (r *Request) RawALB() (events.ALBTargetGroupRequest, bool) {
+	raw, ok := r.raw.(events.ALBTargetGroupRequest)
+
+	return raw, ok
+}
+
+// RawWebSocket returns the WebSocket event the request was created from, and whether or not
+// the request was received using ServeWebSocket.
+func (r *Request) RawWebSocket() (events.APIGatewayWebsocketProxyRequest, bool) {
+	raw, ok := r.raw.(events.APIGatewayWebsocketProxyRequest)
+
+	return raw, ok
+}
+
+// RawFunctionURL returns the function URL event the request was created from, and whether or
+// not the request was received using ServeStream.
+func (r *Request) RawFunctionURL() (events.LambdaFunctionURLRequest, bool) {
+	raw, ok := r.raw.(events.LambdaFunctionURLRequest)
+
+	return raw, ok
+}
@@ -0,0 +1,133 @@
+package lux_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/davidsbond/lux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequest_Raw(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Serve                func(*lux.Router)
+		ExpectedPath         string
+		ExpectedV2           bool
+		ExpectedALB          bool
+		ExpectedWebSocket    bool
+		ExpectedFunctionURL  bool
+		ExpectedOriginalPath string
+	}{
+		// Scenario 1: API Gateway event
+		{
+			Serve: func(router *lux.Router) {
+				router.ServeHTTP(context.Background(), lux.Request{
+					APIGatewayProxyRequest: events.APIGatewayProxyRequest{HTTPMethod: "GET", Path: "/users"},
+				})
+			},
+			ExpectedPath: "/users",
+		},
+		// Scenario 2: Version 2.0 API Gateway event
+		{
+			Serve: func(router *lux.Router) {
+				router.ServeV2(context.Background(), events.APIGatewayV2HTTPRequest{
+					RawPath:        "/users",
+					RequestContext: events.APIGatewayV2HTTPRequestContext{HTTP: events.APIGatewayV2HTTPRequestContextHTTPDescription{Method: "GET"}},
+				})
+			},
+			ExpectedPath:         "/users",
+			ExpectedV2:           true,
+			ExpectedOriginalPath: "/users",
+		},
+		// Scenario 3: Load balancer event
+		{
+			Serve: func(router *lux.Router) {
+				router.ServeALB(context.Background(), events.ALBTargetGroupRequest{HTTPMethod: "GET", Path: "/users"})
+			},
+			ExpectedPath:         "/users",
+			ExpectedALB:          true,
+			ExpectedOriginalPath: "/users",
+		},
+		// Scenario 4: WebSocket event
+		{
+			Serve: func(router *lux.Router) {
+				router.ServeWebSocket(context.Background(), events.APIGatewayWebsocketProxyRequest{
+					Path:           "/users",
+					RequestContext: events.APIGatewayWebsocketProxyRequestContext{RouteKey: "$default"},
+				})
+			},
+			ExpectedPath:         "/users",
+			ExpectedWebSocket:    true,
+			ExpectedOriginalPath: "/users",
+		},
+		// Scenario 5: Function URL event
+		{
+			Serve: func(router *lux.Router) {
+				resp, _ := router.ServeStream(context.Background(), events.LambdaFunctionURLRequest{
+					RawPath:        "/users",
+					RequestContext: events.LambdaFunctionURLRequestContext{HTTP: events.LambdaFunctionURLRequestContextHTTPDescription{Method: "GET"}},
+				})
+
+				io.ReadAll(resp.Body)
+			},
+			ExpectedPath:         "/users",
+			ExpectedFunctionURL:  true,
+			ExpectedOriginalPath: "/users",
+		},
+	}
+
+	for _, tc := range tt {
+		var path, original string
+		var v2, alb, ws, url bool
+
+		handler := func(w lux.ResponseWriter, r *lux.Request) {
+			path = r.Raw().Path
+
+			if raw, ok := r.RawV2(); ok {
+				v2, original = true, raw.RawPath
+			}
+
+			if raw, ok := r.RawALB(); ok {
+				alb, original = true, raw.Path
+			}
+
+			if raw, ok := r.RawWebSocket(); ok {
+				ws, original = true, raw.Path
+			}
+
+			if raw, ok := r.RawFunctionURL(); ok {
+				url, original = true, raw.RawPath
+			}
+
+			w.WriteHeader(http.StatusOK)
+		}
+
+		// GIVEN that we have a router
+		router := lux.NewRouter()
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has handlers that read the raw event
+		router.Handler("GET", handler).Path("/users")
+		router.WSRoute("$default", handler)
+
+		// WHEN we perform the request
+		tc.Serve(router)
+
+		// THEN the API Gateway event should be available
+		assert.Equal(t, tc.ExpectedPath, path)
+
+		// AND the original event should only be available for its event type
+		assert.Equal(t, tc.ExpectedV2, v2)
+		assert.Equal(t, tc.ExpectedALB, alb)
+		assert.Equal(t, tc.ExpectedWebSocket, ws)
+		assert.Equal(t, tc.ExpectedFunctionURL, url)
+		assert.Equal(t, tc.ExpectedOriginalPath, original)
+	}
+}
@@ -0,0 +1,461 @@
+package lux
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/textproto"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrMissingParam is the error returned when attempting to obtain a typed value for a
+	// parameter that is not present in the request.
+	ErrMissingParam = errors.New("parameter not found")
+
+	// ErrNotJSON is the error returned when attempting to bind a request body whose
+	// content type is not JSON.
+	ErrNotJSON = errors.New("content type is not json")
+
+	// ErrNotForm is the error returned when attempting to read a form from a request body
+	// whose content type is not a form.
+	ErrNotForm = errors.New("content type is not a form")
+
+	// ErrBodyTooLarge is the error returned when attempting to read a compressed request
+	// body that exceeds the body size limit once decompressed.
+	ErrBodyTooLarge = errors.New("request body too large")
+)
+
+type (
+	// valueKey is the type used for keys of values stored in the request context using
+	// Request.Set, so that they cannot collide with keys used by other packages.
+	valueKey string
+)
+
+// Context returns the request's context. This is the context provided by the lambda
+// runtime, which carries the deadline of the invocation. Handlers should use it when
+// making calls to databases or other services so that they can be cancelled before
+// the lambda function times out.
+func (r *Request) Context() context.Context {
+	if r.ctx == nil {
+		return context.Background()
+	}
+
+	return r.ctx
+}
+
+// SetContext replaces the request's context. This allows middleware to add values to
+// the context that can be read by subsequent middleware & handlers.
+func (r *Request) SetContext(ctx context.Context) {
+	r.ctx = ctx
+}
+
+// Set stores a value under the given key for the rest of the request, allowing middleware to
+// pass data such as the authenticated user to subsequent middleware & handlers. Values are
+// stored in the request's context, so they are never shared between requests, including
+// concurrent invocations or those that reuse the same lambda container.
+func (r *Request) Set(key string, value interface{}) {
+	r.SetContext(context.WithValue(r.Context(), valueKey(key), value))
+}
+
+// Get returns the value stored under the given key using Request.Set and whether or not a
+// value was stored.
+func (r *Request) Get(key string) (interface{}, bool) {
+	value := r.Context().Value(valueKey(key))
+
+	return value, value != nil
+}
+
+// RoutePattern returns the path pattern of the route that matched the request, such as
+// "/users/{id}", rather than the concrete path. This keeps the cardinality of logs &
+// metrics low. If the route has no path, the HTTP method of the request is returned. An
+// empty string is returned if the request has not been routed.
+func (r *Request) RoutePattern() string {
+	return r.route.patternOrMethod(r.HTTPMethod)
+}
+
+// RouteMeta returns the value attached to the matched route under the given key using
+// Route.Meta, or nil if the route has no such value or the request has not been routed.
+func (r *Request) RouteMeta(key string) interface{} {
+	if r.route == nil {
+		return nil
+	}
+
+	return r.route.meta[key]
+}
+
+// PathParam returns the value of the named parameter from the matched route's path. If
+// the route did not define the parameter, the path parameters provided by the API
+// Gateway are checked instead. An empty string is returned if the parameter cannot
+// be found.
+func (r *Request) PathParam(name string) string {
+	value, _ := r.pathParam(name)
+
+	return value
+}
+
+// PathParamInt returns the value of the named path parameter as an integer. If the
+// parameter is not present in the request, ErrMissingParam is returned.
+func (r *Request) PathParamInt(name string) (int, error) {
+	value, ok := r.pathParam(name)
+
+	if !ok {
+		return 0, ErrMissingParam
+	}
+
+	out, err := strconv.Atoi(value)
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse path parameter %s, %v", name, err)
+	}
+
+	return out, nil
+}
+
+// PathParamBool returns the value of the named path parameter as a boolean. If the
+// parameter is not present in the request, ErrMissingParam is returned.
+func (r *Request) PathParamBool(name string) (bool, error) {
+	value, ok := r.pathParam(name)
+
+	if !ok {
+		return false, ErrMissingParam
+	}
+
+	out, err := strconv.ParseBool(value)
+
+	if err != nil {
+		return false, fmt.Errorf("failed to parse path parameter %s, %v", name, err)
+	}
+
+	return out, nil
+}
+
+// pathParam returns the value of the named path parameter and whether or not it
+// was present in the request.
+func (r *Request) pathParam(name string) (string, bool) {
+	if value, ok := r.params[name]; ok {
+		return value, true
+	}
+
+	value, ok := r.PathParameters[name]
+
+	return value, ok
+}
+
+// Query returns the value of the given query parameter and whether or not it was
+// present in the request. This allows you to distinguish between a parameter with
+// an empty value and a missing one. Parameters are looked up in the multi-value
+// query parameters first, falling back to the single value ones, and if the parameter
+// was provided multiple times, the last value is returned.
+func (r *Request) Query(key string) (string, bool) {
+	if values := r.MultiValueQueryStringParameters[key]; len(values) > 0 {
+		return values[len(values)-1], true
+	}
+
+	value, ok := r.QueryStringParameters[key]
+
+	return value, ok
+}
+
+// QueryValues returns all values for the given query parameter. Like Query, the multi-value
+// query parameters take precedence over the single value ones. A nil slice is returned if
+// the parameter was not present in the request.
+func (r *Request) QueryValues(key string) []string {
+	if values := r.MultiValueQueryStringParameters[key]; len(values) > 0 {
+		return values
+	}
+
+	if value, ok := r.QueryStringParameters[key]; ok {
+		return []string{value}
+	}
+
+	return nil
+}
+
+// QueryDefault returns the value of the given query parameter, or the provided
+// default if the parameter was not present in the request.
+func (r *Request) QueryDefault(key, def string) string {
+	if value, ok := r.Query(key); ok {
+		return value
+	}
+
+	return def
+}
+
+// QueryInt returns the value of the given query parameter as an integer. If the parameter
+// is not present in the request, ErrMissingParam is returned.
+func (r *Request) QueryInt(key string) (int, error) {
+	value, ok := r.Query(key)
+
+	if !ok {
+		return 0, ErrMissingParam
+	}
+
+	out, err := strconv.Atoi(value)
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse query parameter %s, %v", key, err)
+	}
+
+	return out, nil
+}
+
+// QueryBool returns the value of the given query parameter as a boolean. If the parameter
+// is not present in the request, ErrMissingParam is returned.
+func (r *Request) QueryBool(key string) (bool, error) {
+	value, ok := r.Query(key)
+
+	if !ok {
+		return false, ErrMissingParam
+	}
+
+	out, err := strconv.ParseBool(value)
+
+	if err != nil {
+		return false, fmt.Errorf("failed to parse query parameter %s, %v", key, err)
+	}
+
+	return out, nil
+}
+
+// Bind decodes the JSON request body into the value pointed to by v. If the body is
+// base64 encoded or gzip compressed, it is decoded first using RawBody. If the request
+// specifies a Content-Type header that is not JSON, ErrNotJSON is returned.
+func (r *Request) Bind(v interface{}) error {
+	return r.bind(v, r.codec().unmarshal)
+}
+
+// BindStrict decodes the JSON request body into the value pointed to by v like Bind, but
+// returns an error naming the first field of the body that does not exist in v, rather
+// than ignoring it. BindStrict always uses the encoding/json package, regardless of the
+// router's JSON codec.
+func (r *Request) BindStrict(v interface{}) error {
+	return r.bind(v, func(data []byte, v interface{}) error {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+
+		return dec.Decode(v)
+	})
+}
+
+// MustBind decodes the JSON request body into the value pointed to by v like Bind. If the
+// body cannot be decoded, an error response is written using the router's error handler,
+// the chain is aborted & false is returned, so handlers can simply return. Bodies that are
+// not JSON result in a 415 response, bodies that are too large once decompressed result
+// in a 413 response & all other failures result in a 400 response.
+func (r *Request) MustBind(w ResponseWriter, v interface{}) bool {
+	err := r.Bind(v)
+
+	if err == nil {
+		return true
+	}
+
+	if r.router != nil {
+		r.router.writeError(w, r, bindStatus(err), err)
+	} else {
+		JSON(w, bindStatus(err), err.Error())
+	}
+
+	w.Abort()
+
+	return false
+}
+
+// bindStatus returns the status code of the response to write when the request body could
+// not be bound.
+func bindStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrNotJSON):
+		return http.StatusUnsupportedMediaType
+	case errors.Is(err, ErrBodyTooLarge):
+		return http.StatusRequestEntityTooLarge
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// bind decodes the JSON request body into the value pointed to by v using the given
+// function.
+func (r *Request) bind(v interface{}, unmarshal func([]byte, interface{}) error) error {
+	if ct := r.header("Content-Type"); ct != "" && !isJSON(ct) {
+		return ErrNotJSON
+	}
+
+	body, err := r.RawBody()
+
+	if err != nil {
+		return err
+	}
+
+	if err := unmarshal(body, v); err != nil {
+		return fmt.Errorf("failed to decode request body, %v", err)
+	}
+
+	return nil
+}
+
+// body returns the request body, decoding it from base64 if required.
+func (r *Request) body() ([]byte, error) {
+	if !r.IsBase64Encoded {
+		return []byte(r.Body), nil
+	}
+
+	body, err := base64.StdEncoding.DecodeString(r.Body)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode base64 request body, %v", err)
+	}
+
+	return body, nil
+}
+
+// normalize replaces any nil header, query & path parameter maps within the request with
+// empty ones, so that requests from custom invokers & tests that omit them behave the same
+// as those that do not.
+func (r *Request) normalize() {
+	if r.Headers == nil {
+		r.Headers = map[string]string{}
+	}
+
+	if r.QueryStringParameters == nil {
+		r.QueryStringParameters = map[string]string{}
+	}
+
+	if r.PathParameters == nil {
+		r.PathParameters = map[string]string{}
+	}
+}
+
+// Header returns the value of the given request header, ignoring the case of the
+// header name. If the header was only provided in the multi-value headers, its first
+// value is returned.
+func (r *Request) Header(name string) string {
+	return r.header(name)
+}
+
+// header returns the value of the given request header, ignoring the case of
+// the header name.
+func (r *Request) header(name string) string {
+	value, _ := r.lookupHeader(name)
+
+	return value
+}
+
+// matchHeader returns the value of the given request header for use when matching routes.
+// The Content-Type header is returned as its media type, without any parameters.
+func (r *Request) matchHeader(name string) (string, bool) {
+	value, ok := r.lookupHeader(name)
+
+	if ok && textproto.CanonicalMIMEHeaderKey(name) == "Content-Type" {
+		value = mediaType(value)
+	}
+
+	return value, ok
+}
+
+// lookupHeader returns the value of the given request header and whether or not it
+// was present, ignoring the case of the header name.
+func (r *Request) lookupHeader(name string) (string, bool) {
+	if value, ok := lookupHeader(r.Headers, name); ok {
+		return value, true
+	}
+
+	for key, values := range r.MultiValueHeaders {
+		if strings.EqualFold(key, name) && len(values) > 0 {
+			return values[0], true
+		}
+	}
+
+	return "", false
+}
+
+// ContentType returns the media type of the request's Content-Type header without any
+// parameters, such as the charset or boundary. For example, a request with a Content-Type
+// of "application/json; charset=utf-8" has a content type of "application/json". An empty
+// string is returned when the request has no Content-Type header.
+func (r *Request) ContentType() string {
+	return mediaType(r.header("Content-Type"))
+}
+
+// ClientIP returns the IP address of the client that made the request. The last address in
+// the X-Forwarded-For header is used, as it is added by API Gateway or the load balancer and
+// cannot be spoofed by the client, skipping any addresses of proxies set using
+// Router.TrustedProxies. Otherwise, the source IP of the request is returned.
+func (r *Request) ClientIP() string {
+	addrs := r.forwardedHeader("X-Forwarded-For")
+
+	if i := r.forwardedHop(addrs); i >= 0 && addrs[i] != "" {
+		return addrs[i]
+	}
+
+	return r.RequestContext.Identity.SourceIP
+}
+
+// Scheme returns the lowercase scheme used by the client to make the request, such as "https",
+// from the X-Forwarded-Proto header. The scheme added by the same proxy as the address returned
+// by ClientIP is used. Requests without the header are assumed to use HTTPS, as API Gateway
+// only accepts HTTPS requests.
+func (r *Request) Scheme() string {
+	protos := r.forwardedHeader("X-Forwarded-Proto")
+
+	if len(protos) == 0 {
+		return "https"
+	}
+
+	addrs := r.forwardedHeader("X-Forwarded-For")
+
+	// Each proxy appends to both headers, so entries are aligned from the right
+	i := len(protos) - 1
+
+	if hop := r.forwardedHop(addrs); hop >= 0 {
+		if i = len(protos) - (len(addrs) - hop); i < 0 {
+			i = 0
+		}
+	}
+
+	if protos[i] == "" {
+		return "https"
+	}
+
+	return strings.ToLower(protos[i])
+}
+
+// UserAgent returns the value of the request's User-Agent header, falling back to the user agent
+// in the request context. An empty string is returned if neither are present.
+func (r *Request) UserAgent() string {
+	if agent := r.header("User-Agent"); agent != "" {
+		return agent
+	}
+
+	return r.RequestContext.Identity.UserAgent
+}
+
+// mediaType returns the lowercase media type of the given content type, stripping any
+// parameters. Content types with malformed parameters still return their media type.
+func mediaType(contentType string) string {
+	// Content types without parameters do not need parsing
+	if !strings.Contains(contentType, ";") {
+		return strings.ToLower(strings.TrimSpace(contentType))
+	}
+
+	media, _, err := mime.ParseMediaType(contentType)
+
+	if err != nil {
+		media = strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+	}
+
+	return media
+}
+
+// isJSON determines if the given content type is a JSON media type.
+func isJSON(contentType string) bool {
+	media := mediaType(contentType)
+
+	return media == "application/json" || strings.HasSuffix(media, "+json")
+}
@@ -0,0 +1,931 @@
+package lux_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/davidsbond/lux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequest_PathParam(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Pattern       string
+		Request       lux.Request
+		Param         string
+		ExpectedValue string
+	}{
+		// Scenario 1: Parameter parsed from the request path
+		{
+			Pattern: "/users/{id}",
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					HTTPMethod: "GET",
+					Path:       "/users/42",
+				},
+			},
+			Param:         "id",
+			ExpectedValue: "42",
+		},
+		// Scenario 2: Parameter provided by the API gateway
+		{
+			Pattern: "/users/{id}",
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					HTTPMethod:     "GET",
+					Path:           "/users/42",
+					PathParameters: map[string]string{"name": "test"},
+				},
+			},
+			Param:         "name",
+			ExpectedValue: "test",
+		},
+		// Scenario 3: Parameter does not exist
+		{
+			Pattern: "/users/{id}",
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					HTTPMethod: "GET",
+					Path:       "/users/42",
+				},
+			},
+			Param:         "name",
+			ExpectedValue: "",
+		},
+		// Scenario 4: Catch-all parameter captures the remainder of the path
+		{
+			Pattern: "/files/{path...}",
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					HTTPMethod: "GET",
+					Path:       "/files/docs/2024/report.pdf",
+				},
+			},
+			Param:         "path",
+			ExpectedValue: "docs/2024/report.pdf",
+		},
+	}
+
+	for _, tc := range tt {
+		var actual string
+
+		// GIVEN that we have a router
+		router := lux.NewRouter()
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has a handler with a path registered
+		router.Handler("GET", func(w lux.ResponseWriter, r *lux.Request) {
+			actual = r.PathParam(tc.Param)
+			w.WriteHeader(http.StatusOK)
+		}).Path(tc.Pattern)
+
+		// WHEN we perform the request
+		router.ServeHTTP(context.Background(), tc.Request)
+
+		// THEN the path parameter should be what we expect
+		assert.Equal(t, tc.ExpectedValue, actual)
+	}
+}
+
+func TestRequest_TypedPathParam(t *testing.T) {
+	t.Parallel()
+
+	var intErr, boolErr, missingErr error
+	var id int
+	var active bool
+
+	// GIVEN that we have a router
+	router := lux.NewRouter()
+	router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+	// AND that router has a handler that reads typed path parameters
+	router.Handler("GET", func(w lux.ResponseWriter, r *lux.Request) {
+		id, intErr = r.PathParamInt("id")
+		active, boolErr = r.PathParamBool("active")
+		_, missingErr = r.PathParamInt("missing")
+
+		w.WriteHeader(http.StatusOK)
+	}).Path("/users/{id}/{active}")
+
+	// WHEN we perform a request with valid path parameters
+	router.ServeHTTP(context.Background(), lux.Request{
+		APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+			HTTPMethod: "GET",
+			Path:       "/users/42/true",
+		},
+	})
+
+	// THEN the typed values should be what we expect
+	assert.Nil(t, intErr)
+	assert.Equal(t, 42, id)
+	assert.Nil(t, boolErr)
+	assert.True(t, active)
+
+	// AND missing parameters should return ErrMissingParam
+	assert.Equal(t, lux.ErrMissingParam, missingErr)
+
+	// WHEN we perform a request with invalid path parameters
+	router.ServeHTTP(context.Background(), lux.Request{
+		APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+			HTTPMethod: "GET",
+			Path:       "/users/abc/nope",
+		},
+	})
+
+	// THEN parsing errors should be returned
+	assert.NotNil(t, intErr)
+	assert.NotEqual(t, lux.ErrMissingParam, intErr)
+	assert.NotNil(t, boolErr)
+	assert.NotEqual(t, lux.ErrMissingParam, boolErr)
+}
+
+func TestRequest_Query(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Request        lux.Request
+		Key            string
+		ExpectedValue  string
+		ExpectedOK     bool
+		ExpectedValues []string
+	}{
+		// Scenario 1: Parameter with a value
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					QueryStringParameters: map[string]string{"key": "value"},
+				},
+			},
+			Key:            "key",
+			ExpectedValue:  "value",
+			ExpectedOK:     true,
+			ExpectedValues: []string{"value"},
+		},
+		// Scenario 2: Parameter with an empty value
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					QueryStringParameters: map[string]string{"key": ""},
+				},
+			},
+			Key:            "key",
+			ExpectedValue:  "",
+			ExpectedOK:     true,
+			ExpectedValues: []string{""},
+		},
+		// Scenario 3: Missing parameter
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					QueryStringParameters: map[string]string{},
+				},
+			},
+			Key:           "key",
+			ExpectedValue: "",
+			ExpectedOK:    false,
+		},
+		// Scenario 4: Parameter with multiple values
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					QueryStringParameters:           map[string]string{"tag": "b"},
+					MultiValueQueryStringParameters: map[string][]string{"tag": {"a", "b"}},
+				},
+			},
+			Key:            "tag",
+			ExpectedValue:  "b",
+			ExpectedOK:     true,
+			ExpectedValues: []string{"a", "b"},
+		},
+		// Scenario 5: Parameter only present in the multi-value parameters
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					MultiValueQueryStringParameters: map[string][]string{"tag": {"a", "b"}},
+				},
+			},
+			Key:            "tag",
+			ExpectedValue:  "b",
+			ExpectedOK:     true,
+			ExpectedValues: []string{"a", "b"},
+		},
+		// Scenario 6: Multi-value parameters take precedence
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					QueryStringParameters:           map[string]string{"tag": "c"},
+					MultiValueQueryStringParameters: map[string][]string{"tag": {"a", "b"}},
+				},
+			},
+			Key:            "tag",
+			ExpectedValue:  "b",
+			ExpectedOK:     true,
+			ExpectedValues: []string{"a", "b"},
+		},
+		// Scenario 7: Parameter with no multi-values falls back to the single value
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					QueryStringParameters:           map[string]string{"tag": "c"},
+					MultiValueQueryStringParameters: map[string][]string{"tag": {}},
+				},
+			},
+			Key:            "tag",
+			ExpectedValue:  "c",
+			ExpectedOK:     true,
+			ExpectedValues: []string{"c"},
+		},
+	}
+
+	for _, tc := range tt {
+		// WHEN we obtain the query parameter
+		value, ok := tc.Request.Query(tc.Key)
+		values := tc.Request.QueryValues(tc.Key)
+
+		// THEN the value should be what we expect
+		assert.Equal(t, tc.ExpectedValue, value)
+		assert.Equal(t, tc.ExpectedOK, ok)
+		assert.Equal(t, tc.ExpectedValues, values)
+	}
+}
+
+func TestRequest_Header(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Request       lux.Request
+		Name          string
+		ExpectedValue string
+	}{
+		// Scenario 1: Header with the same casing
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					Headers: map[string]string{"Content-Type": "application/json"},
+				},
+			},
+			Name:          "Content-Type",
+			ExpectedValue: "application/json",
+		},
+		// Scenario 2: Header with different casing
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					Headers: map[string]string{"CONTENT-TYPE": "application/json"},
+				},
+			},
+			Name:          "content-type",
+			ExpectedValue: "application/json",
+		},
+		// Scenario 3: Header only present in the multi-value headers
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					MultiValueHeaders: map[string][]string{"x-forwarded-for": {"1.1.1.1", "2.2.2.2"}},
+				},
+			},
+			Name:          "X-Forwarded-For",
+			ExpectedValue: "1.1.1.1",
+		},
+		// Scenario 4: Missing header
+		{
+			Name:          "Content-Type",
+			ExpectedValue: "",
+		},
+	}
+
+	for _, tc := range tt {
+		// WHEN we obtain the header
+		value := tc.Request.Header(tc.Name)
+
+		// THEN the value should be what we expect
+		assert.Equal(t, tc.ExpectedValue, value)
+	}
+}
+
+func TestRequest_ClientIP(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Headers           map[string]string
+		MultiValueHeaders map[string][]string
+		SourceIP          string
+		ExpectedIP        string
+	}{
+		// Scenario 1: Request without an X-Forwarded-For header
+		{
+			SourceIP:   "1.1.1.1",
+			ExpectedIP: "1.1.1.1",
+		},
+		// Scenario 2: Request with a single forwarded address
+		{
+			Headers:    map[string]string{"X-Forwarded-For": "2.2.2.2"},
+			SourceIP:   "1.1.1.1",
+			ExpectedIP: "2.2.2.2",
+		},
+		// Scenario 3: Request with a list of forwarded addresses uses the address added last
+		{
+			Headers:    map[string]string{"x-forwarded-for": " 3.3.3.3 , 2.2.2.2, 1.1.1.1 "},
+			SourceIP:   "1.1.1.1",
+			ExpectedIP: "1.1.1.1",
+		},
+		// Scenario 4: Request with forwarded addresses in the multi-value headers
+		{
+			MultiValueHeaders: map[string][]string{"X-Forwarded-For": {"3.3.3.3, 2.2.2.2", "4.4.4.4"}},
+			SourceIP:          "1.1.1.1",
+			ExpectedIP:        "4.4.4.4",
+		},
+		// Scenario 5: Request with an empty last forwarded address
+		{
+			Headers:    map[string]string{"X-Forwarded-For": "2.2.2.2, "},
+			SourceIP:   "1.1.1.1",
+			ExpectedIP: "1.1.1.1",
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a request
+		req := lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+				Headers:           tc.Headers,
+				MultiValueHeaders: tc.MultiValueHeaders,
+			},
+		}
+
+		req.RequestContext.Identity.SourceIP = tc.SourceIP
+
+		// WHEN we obtain the client IP
+		ip := req.ClientIP()
+
+		// THEN the IP should be what we expect
+		assert.Equal(t, tc.ExpectedIP, ip)
+	}
+}
+
+func TestRequest_UserAgent(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Headers       map[string]string
+		IdentityAgent string
+		ExpectedAgent string
+	}{
+		// Scenario 1: Request with a User-Agent header
+		{
+			Headers:       map[string]string{"user-agent": "curl/8.0"},
+			IdentityAgent: "identity",
+			ExpectedAgent: "curl/8.0",
+		},
+		// Scenario 2: Request with a user agent in the request context
+		{
+			IdentityAgent: "identity",
+			ExpectedAgent: "identity",
+		},
+		// Scenario 3: Request without a user agent
+		{
+			ExpectedAgent: "",
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a request
+		req := lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{Headers: tc.Headers},
+		}
+
+		req.RequestContext.Identity.UserAgent = tc.IdentityAgent
+
+		// WHEN we obtain the user agent
+		agent := req.UserAgent()
+
+		// THEN the user agent should be what we expect
+		assert.Equal(t, tc.ExpectedAgent, agent)
+	}
+}
+
+func TestRequest_ContentType(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		ContentType   string
+		ExpectedValue string
+	}{
+		// Scenario 1: Content type without parameters
+		{
+			ContentType:   "application/json",
+			ExpectedValue: "application/json",
+		},
+		// Scenario 2: Content type with a charset
+		{
+			ContentType:   "Application/JSON; charset=utf-8",
+			ExpectedValue: "application/json",
+		},
+		// Scenario 3: Content type with a boundary
+		{
+			ContentType:   "multipart/form-data; boundary=abc",
+			ExpectedValue: "multipart/form-data",
+		},
+		// Scenario 4: Content type with malformed parameters
+		{
+			ContentType:   "text/plain; charset",
+			ExpectedValue: "text/plain",
+		},
+		// Scenario 5: No content type
+		{
+			ExpectedValue: "",
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a request with a content type
+		req := lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+				Headers: map[string]string{"content-type": tc.ContentType},
+			},
+		}
+
+		// WHEN we obtain the content type
+		value := req.ContentType()
+
+		// THEN the media type should be what we expect
+		assert.Equal(t, tc.ExpectedValue, value)
+	}
+}
+
+func TestRequest_TypedQuery(t *testing.T) {
+	t.Parallel()
+
+	// GIVEN that we have a request with query parameters
+	req := lux.Request{
+		APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+			QueryStringParameters: map[string]string{
+				"page":   "2",
+				"active": "true",
+				"bad":    "nope",
+			},
+		},
+	}
+
+	// WHEN we obtain valid typed parameters
+	page, err := req.QueryInt("page")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, page)
+
+	active, err := req.QueryBool("active")
+	assert.Nil(t, err)
+	assert.True(t, active)
+
+	// THEN missing parameters should return ErrMissingParam
+	_, err = req.QueryInt("missing")
+	assert.Equal(t, lux.ErrMissingParam, err)
+
+	_, err = req.QueryBool("missing")
+	assert.Equal(t, lux.ErrMissingParam, err)
+
+	// AND invalid parameters should return a parsing error
+	_, err = req.QueryInt("bad")
+	assert.NotNil(t, err)
+	assert.NotEqual(t, lux.ErrMissingParam, err)
+
+	_, err = req.QueryBool("bad")
+	assert.NotNil(t, err)
+	assert.NotEqual(t, lux.ErrMissingParam, err)
+
+	// AND defaults should only be used for missing parameters
+	assert.Equal(t, "10", req.QueryDefault("limit", "10"))
+	assert.Equal(t, "2", req.QueryDefault("page", "10"))
+}
+
+func TestRequest_Bind(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Request       lux.Request
+		ExpectedValue map[string]string
+		ExpectError   bool
+	}{
+		// Scenario 1: Valid JSON body
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					Headers: map[string]string{"Content-Type": "application/json"},
+					Body:    `{"name":"test"}`,
+				},
+			},
+			ExpectedValue: map[string]string{"name": "test"},
+		},
+		// Scenario 2: Valid base64 encoded JSON body
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					Headers:         map[string]string{"content-type": "application/json; charset=utf-8"},
+					Body:            "eyJuYW1lIjoidGVzdCJ9",
+					IsBase64Encoded: true,
+				},
+			},
+			ExpectedValue: map[string]string{"name": "test"},
+		},
+		// Scenario 3: Malformed JSON body
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					Headers: map[string]string{"Content-Type": "application/json"},
+					Body:    `{"name":`,
+				},
+			},
+			ExpectError: true,
+		},
+		// Scenario 4: Non-JSON content type
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					Headers: map[string]string{"Content-Type": "application/xml"},
+					Body:    `<name>test</name>`,
+				},
+			},
+			ExpectError: true,
+		},
+		// Scenario 5: Invalid base64 body
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					Body:            "!!!",
+					IsBase64Encoded: true,
+				},
+			},
+			ExpectError: true,
+		},
+	}
+
+	for _, tc := range tt {
+		var actual map[string]string
+
+		// WHEN we bind the request body
+		err := tc.Request.Bind(&actual)
+
+		// THEN any errors should be what we expect
+		assert.Equal(t, tc.ExpectError, err != nil)
+
+		// AND the bound value should be what we expect
+		assert.Equal(t, tc.ExpectedValue, actual)
+	}
+}
+
+func TestRequest_BindStrict(t *testing.T) {
+	t.Parallel()
+
+	type user struct {
+		Name string `json:"name"`
+	}
+
+	tt := []struct {
+		Body          string
+		ExpectedValue user
+		ExpectedError string
+	}{
+		// Scenario 1: Body contains only known fields
+		{
+			Body:          `{"name":"test"}`,
+			ExpectedValue: user{Name: "test"},
+		},
+		// Scenario 2: Body contains an unknown field
+		{
+			Body:          `{"name":"test","nmae":"typo"}`,
+			ExpectedValue: user{Name: "test"},
+			ExpectedError: `failed to decode request body, json: unknown field "nmae"`,
+		},
+		// Scenario 3: Malformed JSON body
+		{
+			Body:          `{"name":`,
+			ExpectedError: "failed to decode request body, unexpected EOF",
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a request
+		req := lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+				Headers: map[string]string{"Content-Type": "application/json"},
+				Body:    tc.Body,
+			},
+		}
+
+		// WHEN we strictly bind the request body
+		var actual user
+		err := req.BindStrict(&actual)
+
+		// THEN any errors should name the unknown field
+		if tc.ExpectedError == "" {
+			assert.NoError(t, err)
+		} else {
+			assert.EqualError(t, err, tc.ExpectedError)
+		}
+
+		// AND the bound value should be what we expect
+		assert.Equal(t, tc.ExpectedValue, actual)
+	}
+}
+
+func TestRequest_MustBind(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Headers        map[string]string
+		Body           string
+		ErrorHandler   lux.ErrorFunc
+		ExpectedStatus int
+		ExpectedBody   string
+	}{
+		// Scenario 1: Body is valid JSON
+		{
+			Body:           `{"name":"test"}`,
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   "test",
+		},
+		// Scenario 2: Body is malformed JSON
+		{
+			Body:           `{"name":`,
+			ExpectedStatus: http.StatusBadRequest,
+			ExpectedBody:   `"failed to decode request body, unexpected end of JSON input"`,
+		},
+		// Scenario 3: Body is not JSON
+		{
+			Headers:        map[string]string{"Content-Type": "text/plain"},
+			Body:           "test",
+			ExpectedStatus: http.StatusUnsupportedMediaType,
+			ExpectedBody:   `"content type is not json"`,
+		},
+		// Scenario 4: Router has a custom error handler
+		{
+			Body: `{"name":`,
+			ErrorHandler: func(w lux.ResponseWriter, r *lux.Request, status int, err error) {
+				lux.JSON(w, status, map[string]string{"error": err.Error()})
+			},
+			ExpectedStatus: http.StatusBadRequest,
+			ExpectedBody:   `{"error":"failed to decode request body, unexpected end of JSON input"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router
+		router := lux.NewRouter()
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		if tc.ErrorHandler != nil {
+			router.ErrorHandler(tc.ErrorHandler)
+		}
+
+		// AND that router has a handler that binds the request body
+		router.Handler("POST", func(w lux.ResponseWriter, r *lux.Request) {
+			var user struct {
+				Name string `json:"name"`
+			}
+
+			if !r.MustBind(w, &user) {
+				return
+			}
+
+			lux.Text(w, http.StatusOK, user.Name)
+		})
+
+		// WHEN we perform a request
+		resp, _ := router.ServeHTTP(context.Background(), lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+				HTTPMethod: "POST",
+				Headers:    tc.Headers,
+				Body:       tc.Body,
+			},
+		})
+
+		// THEN the response should be what we expect
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+		assert.Equal(t, tc.ExpectedBody, resp.Body)
+	}
+}
+
+func TestRequest_Context(t *testing.T) {
+	t.Parallel()
+
+	var actual context.Context
+
+	// GIVEN that we have a router
+	router := lux.NewRouter()
+	router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+	// AND that router has a handler that reads the request context
+	router.Handler("GET", func(w lux.ResponseWriter, r *lux.Request) {
+		actual = r.Context()
+		w.WriteHeader(http.StatusOK)
+	})
+
+	// AND we have a context with a deadline
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	// WHEN we perform the request
+	router.ServeHTTP(ctx, lux.Request{
+		APIGatewayProxyRequest: events.APIGatewayProxyRequest{HTTPMethod: "GET"},
+	})
+
+	// THEN the handler should have been given the context
+	deadline, ok := actual.Deadline()
+	expected, _ := ctx.Deadline()
+
+	assert.True(t, ok)
+	assert.Equal(t, expected, deadline)
+}
+
+func TestRequest_SetAndGet(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Key           string
+		ExpectedValue interface{}
+		ExpectedOK    bool
+	}{
+		// Scenario 1: Value set by middleware
+		{
+			Key:           "user",
+			ExpectedValue: "alice",
+			ExpectedOK:    true,
+		},
+		// Scenario 2: Value replaced by subsequent middleware
+		{
+			Key:           "role",
+			ExpectedValue: "admin",
+			ExpectedOK:    true,
+		},
+		// Scenario 3: Value that was never set
+		{
+			Key:           "missing",
+			ExpectedValue: nil,
+			ExpectedOK:    false,
+		},
+	}
+
+	for _, tc := range tt {
+		var value interface{}
+		var ok bool
+
+		// GIVEN that we have a router
+		router := lux.NewRouter()
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has middleware that sets values
+		router.Middleware(func(w lux.ResponseWriter, r *lux.Request) {
+			r.Set("user", "alice")
+			r.Set("role", "user")
+		}, func(w lux.ResponseWriter, r *lux.Request) {
+			r.Set("role", "admin")
+		})
+
+		// AND that router has a handler that gets a value
+		router.Handler("GET", func(w lux.ResponseWriter, r *lux.Request) {
+			value, ok = r.Get(tc.Key)
+			w.WriteHeader(http.StatusOK)
+		})
+
+		// WHEN we perform the request
+		router.ServeHTTP(context.Background(), lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{HTTPMethod: "GET"},
+		})
+
+		// THEN the value should be what we expect
+		assert.Equal(t, tc.ExpectedValue, value)
+		assert.Equal(t, tc.ExpectedOK, ok)
+	}
+}
+
+func TestRequest_RoutePattern(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Request         lux.Request
+		ExpectedPattern string
+	}{
+		// Scenario 1: Request matches a route with a path
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{HTTPMethod: "GET", Path: "/users/42"},
+			},
+			ExpectedPattern: "/users/{id}",
+		},
+		// Scenario 2: Request matches a route in a group
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{HTTPMethod: "GET", Path: "/v1/posts/42"},
+			},
+			ExpectedPattern: "/v1/posts/{id}",
+		},
+		// Scenario 3: Request matches a route without a path
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{HTTPMethod: "POST", Path: "/anything"},
+			},
+			ExpectedPattern: "POST",
+		},
+		// Scenario 4: Request does not match any route
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{HTTPMethod: "GET", Path: "/unknown"},
+			},
+			ExpectedPattern: "",
+		},
+	}
+
+	for _, tc := range tt {
+		var actual string
+
+		record := func(w lux.ResponseWriter, r *lux.Request) {
+			actual = r.RoutePattern()
+			w.WriteHeader(http.StatusOK)
+		}
+
+		// GIVEN that we have a router with an error handler that records the pattern
+		router := lux.NewRouter().ErrorHandler(func(w lux.ResponseWriter, r *lux.Request, status int, err error) {
+			record(w, r)
+		})
+
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has handlers that record the pattern
+		router.Handler("GET", record).Path("/users/{id}")
+		router.Group("/v1").Handler("GET", record).Path("/posts/{id}")
+		router.Handler("POST", record)
+
+		// WHEN we perform the request
+		router.ServeHTTP(context.Background(), tc.Request)
+
+		// THEN the pattern should be what we expect
+		assert.Equal(t, tc.ExpectedPattern, actual)
+	}
+}
+
+func TestRequest_RouteMeta(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Path           string
+		ExpectedStatus int
+	}{
+		// Scenario 1: Route requires a scope the request has
+		{
+			Path:           "/users",
+			ExpectedStatus: http.StatusOK,
+		},
+		// Scenario 2: Route requires a scope the request does not have
+		{
+			Path:           "/admin",
+			ExpectedStatus: http.StatusForbidden,
+		},
+		// Scenario 3: Route without metadata
+		{
+			Path:           "/public",
+			ExpectedStatus: http.StatusOK,
+		},
+		// Scenario 4: Request does not match any route
+		{
+			Path:           "/unknown",
+			ExpectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router
+		router := lux.NewRouter()
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has middleware enforcing the scope of each route
+		router.Middleware(func(w lux.ResponseWriter, r *lux.Request) {
+			if scope, ok := r.RouteMeta("scope").(string); ok && scope != "user" {
+				w.WriteHeader(http.StatusForbidden)
+			}
+		})
+
+		// AND that router has handlers with metadata
+		router.Handler("GET", getHandler).Path("/users").Meta("scope", "user")
+		router.Handler("GET", getHandler).Path("/admin").Meta("scope", "admin").Meta("audit", true)
+		router.Handler("GET", getHandler).Path("/public")
+
+		// WHEN we perform the request
+		resp, _ := router.ServeHTTP(context.Background(), lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{HTTPMethod: "GET", Path: tc.Path},
+		})
+
+		// THEN the response should have the expected status
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+	}
+
+	// GIVEN that we have a request that has not been routed
+	req := lux.Request{}
+
+	// THEN it should not have any metadata
+	assert.Nil(t, req.RouteMeta("scope"))
+}
@@ -0,0 +1,61 @@
+package lux
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+)
+
+type (
+	// contextKey is the type used for keys of values the package stores in the
+	// request context.
+	contextKey string
+)
+
+const requestIDKey = contextKey("requestId")
+
+// RequestID creates a middleware function that ensures every request has a request ID.
+// The ID provided by the API Gateway is preferred, followed by the value of an incoming
+// X-Request-Id header. If neither are present, a random UUID is generated. The ID is
+// stored in the request context, can be obtained using Request.RequestID and is returned
+// to the client in the X-Request-Id response header.
+func RequestID() HandlerFunc {
+	return func(w ResponseWriter, r *Request) {
+		id := r.RequestContext.RequestID
+
+		if id == "" {
+			id = r.header("X-Request-Id")
+		}
+
+		if id == "" {
+			id = newUUID()
+		}
+
+		r.SetContext(context.WithValue(r.Context(), requestIDKey, id))
+		w.Header().Set("X-Request-Id", id)
+	}
+}
+
+// RequestID returns the ID of the request. If the RequestID middleware has been used,
+// the ID it assigned is returned. Otherwise, the ID provided by the API Gateway is
+// returned.
+func (r *Request) RequestID() string {
+	if id, ok := r.Context().Value(requestIDKey).(string); ok {
+		return id
+	}
+
+	return r.RequestContext.RequestID
+}
+
+// newUUID generates a random version 4 UUID.
+func newUUID() string {
+	b := make([]byte, 16)
+
+	// The UUID is only used for correlation, so an error is unlikely to matter.
+	rand.Read(b)
+
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
@@ -0,0 +1,84 @@
+package lux_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/davidsbond/lux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestID(t *testing.T) {
+	t.Parallel()
+
+	uuid := regexp.MustCompile("^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$")
+
+	tt := []struct {
+		Request    lux.Request
+		ExpectedID string
+	}{
+		// Scenario 1: Request with an API Gateway request ID
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					HTTPMethod: "GET",
+					Headers:    map[string]string{"X-Request-Id": "header"},
+					RequestContext: events.APIGatewayProxyRequestContext{
+						RequestID: "gateway",
+					},
+				},
+			},
+			ExpectedID: "gateway",
+		},
+		// Scenario 2: Request with a request ID header
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					HTTPMethod: "GET",
+					Headers:    map[string]string{"x-request-id": "header"},
+				},
+			},
+			ExpectedID: "header",
+		},
+		// Scenario 3: Request without a request ID
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					HTTPMethod: "GET",
+				},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		var actual string
+
+		// GIVEN that we have a router using the request ID middleware
+		router := lux.NewRouter().Middleware(lux.RequestID())
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has a handler that reads the request ID
+		router.Handler("GET", func(w lux.ResponseWriter, r *lux.Request) {
+			actual = r.RequestID()
+			w.WriteHeader(http.StatusOK)
+		})
+
+		// WHEN we perform the request
+		resp, _ := router.ServeHTTP(context.Background(), tc.Request)
+
+		// THEN the request ID should be what we expect
+		if tc.ExpectedID == "" {
+			assert.Regexp(t, uuid, actual)
+		} else {
+			assert.Equal(t, tc.ExpectedID, actual)
+		}
+
+		// AND the request ID should be returned to the client
+		assert.Equal(t, actual, resp.Headers["X-Request-Id"])
+	}
+}
@@ -0,0 +1,135 @@
+package lux
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+// textTypes contains the media types, other than "text/*", whose responses are not
+// base64 encoded.
+var textTypes = map[string]bool{
+	"application/json":                  true,
+	"application/xml":                   true,
+	"application/javascript":            true,
+	"application/ecmascript":            true,
+	"application/x-www-form-urlencoded": true,
+	"application/graphql":               true,
+}
+
+// JSON writes the JSON encoding of v to the response with the given status code and
+// an "application/json" content type. If v cannot be encoded, the error is returned &
+// nothing is written to the response, allowing your handler to decide what to do.
+func JSON(w ResponseWriter, status int, v interface{}) error {
+	data, err := encodeJSON(w, v)
+
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(data)
+
+	return nil
+}
+
+// encodeJSON encodes v using the JSON codec of the router the response is being written for.
+func encodeJSON(w ResponseWriter, v interface{}) ([]byte, error) {
+	codec := defaultCodec
+
+	if rw, ok := w.(*responseWriter); ok {
+		codec = codecOrDefault(rw.codec)
+	}
+
+	data, err := codec.marshal(v)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode response body, %v", err)
+	}
+
+	return data, nil
+}
+
+// Text writes the given string to the response with the given status code and a
+// "text/plain" content type.
+func Text(w ResponseWriter, status int, s string) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(status)
+	w.Write([]byte(s))
+}
+
+// NoContent writes a 204 response with no body.
+func NoContent(w ResponseWriter) {
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Created writes a 201 response with the Location header set to the URL of the created
+// resource, such as one generated using Request.URL. If v is not nil, its JSON encoding is
+// written as the body of the response. If v cannot be encoded, the error is returned &
+// nothing is written to the response.
+func Created(w ResponseWriter, location string, v interface{}) error {
+	if v == nil {
+		w.Header().Set("Location", location)
+		w.WriteHeader(http.StatusCreated)
+
+		return nil
+	}
+
+	data, err := encodeJSON(w, v)
+
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Location", location)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	w.Write(data)
+
+	return nil
+}
+
+// Redirect responds to the request with a redirect to the given URL. The status code
+// must be in the 3xx range, otherwise an error is returned & nothing is written to the
+// response.
+func Redirect(w ResponseWriter, status int, url string) error {
+	if status < 300 || status > 399 {
+		return fmt.Errorf("invalid redirect status code %d", status)
+	}
+
+	w.Header().Set("Location", url)
+	w.WriteHeader(status)
+
+	return nil
+}
+
+// Binary writes the given data to the response with the given status code and content
+// type. The response body is always base64 encoded, allowing binary payloads such as
+// images & documents to be returned through the API Gateway. Responses written using
+// the ResponseWriter are also base64 encoded when their content type is not textual.
+func Binary(w ResponseWriter, status int, contentType string, data []byte) {
+	if rw, ok := w.(*responseWriter); ok {
+		rw.binary = true
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	w.Write(data)
+}
+
+// isText determines if a response body with the given content type is textual. When
+// no content type is given, the body is textual if it is valid UTF-8.
+func isText(contentType string, body []byte) bool {
+	if contentType == "" {
+		return utf8.Valid(body)
+	}
+
+	media := mediaType(contentType)
+
+	return strings.HasPrefix(media, "text/") ||
+		strings.HasSuffix(media, "+json") ||
+		strings.HasSuffix(media, "+xml") ||
+		textTypes[media]
+}
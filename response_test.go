@@ -0,0 +1,245 @@
+package lux_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/davidsbond/lux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResponse_Helpers(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Handler             lux.HandlerFunc
+		ExpectedStatus      int
+		ExpectedBody        string
+		ExpectedContentType string
+		ExpectedLocation    string
+	}{
+		// Scenario 1: Handler writes JSON
+		{
+			Handler: func(w lux.ResponseWriter, r *lux.Request) {
+				lux.JSON(w, http.StatusCreated, map[string]string{"name": "test"})
+			},
+			ExpectedStatus:      http.StatusCreated,
+			ExpectedBody:        `{"name":"test"}`,
+			ExpectedContentType: "application/json",
+		},
+		// Scenario 2: Handler writes text
+		{
+			Handler: func(w lux.ResponseWriter, r *lux.Request) {
+				lux.Text(w, http.StatusOK, "hello test")
+			},
+			ExpectedStatus:      http.StatusOK,
+			ExpectedBody:        "hello test",
+			ExpectedContentType: "text/plain; charset=utf-8",
+		},
+		// Scenario 3: Handler redirects
+		{
+			Handler: func(w lux.ResponseWriter, r *lux.Request) {
+				lux.Redirect(w, http.StatusFound, "/login")
+			},
+			ExpectedStatus:   http.StatusFound,
+			ExpectedLocation: "/login",
+		},
+		// Scenario 4: Handler redirects with an invalid status code
+		{
+			Handler: func(w lux.ResponseWriter, r *lux.Request) {
+				if err := lux.Redirect(w, http.StatusOK, "/login"); err != nil {
+					lux.Text(w, http.StatusInternalServerError, err.Error())
+				}
+			},
+			ExpectedStatus:      http.StatusInternalServerError,
+			ExpectedBody:        "invalid redirect status code 200",
+			ExpectedContentType: "text/plain; charset=utf-8",
+		},
+		// Scenario 5: Handler writes JSON that cannot be encoded
+		{
+			Handler: func(w lux.ResponseWriter, r *lux.Request) {
+				if err := lux.JSON(w, http.StatusOK, make(chan int)); err != nil {
+					lux.Text(w, http.StatusInternalServerError, "error")
+				}
+			},
+			ExpectedStatus:      http.StatusInternalServerError,
+			ExpectedBody:        "error",
+			ExpectedContentType: "text/plain; charset=utf-8",
+		},
+		// Scenario 6: Handler writes no content
+		{
+			Handler: func(w lux.ResponseWriter, r *lux.Request) {
+				lux.NoContent(w)
+			},
+			ExpectedStatus: http.StatusNoContent,
+		},
+		// Scenario 7: Handler writes a created resource
+		{
+			Handler: func(w lux.ResponseWriter, r *lux.Request) {
+				location, _ := r.URL("getUser", "id", "42")
+				lux.Created(w, location, map[string]string{"id": "42"})
+			},
+			ExpectedStatus:      http.StatusCreated,
+			ExpectedBody:        `{"id":"42"}`,
+			ExpectedContentType: "application/json",
+			ExpectedLocation:    "/users/42",
+		},
+		// Scenario 8: Handler writes a created resource without a body
+		{
+			Handler: func(w lux.ResponseWriter, r *lux.Request) {
+				lux.Created(w, "/users/42", nil)
+			},
+			ExpectedStatus:   http.StatusCreated,
+			ExpectedLocation: "/users/42",
+		},
+		// Scenario 9: Handler writes a created resource that cannot be encoded
+		{
+			Handler: func(w lux.ResponseWriter, r *lux.Request) {
+				if err := lux.Created(w, "/users/42", make(chan int)); err != nil {
+					lux.Text(w, http.StatusInternalServerError, "error")
+				}
+			},
+			ExpectedStatus:      http.StatusInternalServerError,
+			ExpectedBody:        "error",
+			ExpectedContentType: "text/plain; charset=utf-8",
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router
+		router := lux.NewRouter()
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has a handler registered
+		router.Handler("GET", tc.Handler)
+		router.Handler("GET", getHandler).Path("/users/{id}").Name("getUser")
+
+		// WHEN we perform a request
+		resp, _ := router.ServeHTTP(context.Background(), lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{HTTPMethod: "GET"},
+		})
+
+		// THEN the response should be what we expect
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+		assert.Equal(t, tc.ExpectedBody, resp.Body)
+		assert.Equal(t, tc.ExpectedContentType, resp.Headers["Content-Type"])
+		assert.Equal(t, tc.ExpectedLocation, resp.Headers["Location"])
+	}
+}
+
+func TestResponse_MultiValueHeaders(t *testing.T) {
+	t.Parallel()
+
+	// GIVEN that we have a router
+	router := lux.NewRouter()
+	router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+	// AND that router has a handler that adds multiple values for a header
+	router.Handler("GET", func(w lux.ResponseWriter, r *lux.Request) {
+		w.Header().Add("Set-Cookie", "a=1")
+		w.Header().Add("set-cookie", "b=2")
+		w.Header().Set("Content-Type", "text/plain")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+	})
+
+	// WHEN we perform a request
+	resp, _ := router.ServeHTTP(context.Background(), lux.Request{
+		APIGatewayProxyRequest: events.APIGatewayProxyRequest{HTTPMethod: "GET"},
+	})
+
+	// THEN each added value should be a distinct entry in the multi-value headers
+	assert.Equal(t, []string{"a=1", "b=2"}, resp.MultiValueHeaders["Set-Cookie"])
+
+	// AND setting a header should replace existing values
+	assert.Equal(t, []string{"application/json"}, resp.MultiValueHeaders["Content-Type"])
+	assert.Equal(t, "application/json", resp.Headers["Content-Type"])
+}
+
+func TestResponse_Binary(t *testing.T) {
+	t.Parallel()
+
+	png := []byte{0x89, 'P', 'N', 'G', 0x0d, 0x0a, 0x1a, 0x0a}
+
+	tt := []struct {
+		Handler          lux.HandlerFunc
+		ExpectedBody     []byte
+		ExpectedEncoding bool
+	}{
+		// Scenario 1: Handler writes binary data
+		{
+			Handler: func(w lux.ResponseWriter, r *lux.Request) {
+				lux.Binary(w, http.StatusOK, "image/png", png)
+			},
+			ExpectedBody:     png,
+			ExpectedEncoding: true,
+		},
+		// Scenario 2: Handler writes textual data as binary
+		{
+			Handler: func(w lux.ResponseWriter, r *lux.Request) {
+				lux.Binary(w, http.StatusOK, "text/csv", []byte("a,b"))
+			},
+			ExpectedBody:     []byte("a,b"),
+			ExpectedEncoding: true,
+		},
+		// Scenario 3: Handler writes a binary content type
+		{
+			Handler: func(w lux.ResponseWriter, r *lux.Request) {
+				w.Header().Set("Content-Type", "application/pdf")
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte("%PDF-1.4"))
+			},
+			ExpectedBody:     []byte("%PDF-1.4"),
+			ExpectedEncoding: true,
+		},
+		// Scenario 4: Handler writes a textual content type
+		{
+			Handler: func(w lux.ResponseWriter, r *lux.Request) {
+				w.Header().Set("Content-Type", "application/vnd.api+json")
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte("{}"))
+			},
+			ExpectedBody: []byte("{}"),
+		},
+		// Scenario 5: Handler writes invalid UTF-8 without a content type
+		{
+			Handler: func(w lux.ResponseWriter, r *lux.Request) {
+				w.WriteHeader(http.StatusOK)
+				w.Write(png)
+			},
+			ExpectedBody:     png,
+			ExpectedEncoding: true,
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router
+		router := lux.NewRouter()
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has a handler registered
+		router.Handler("GET", tc.Handler)
+
+		// WHEN we perform a request
+		resp, _ := router.ServeHTTP(context.Background(), lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{HTTPMethod: "GET"},
+		})
+
+		// THEN the response should only be base64 encoded when we expect
+		assert.Equal(t, tc.ExpectedEncoding, resp.IsBase64Encoded)
+
+		body := []byte(resp.Body)
+
+		if resp.IsBase64Encoded {
+			body, _ = base64.StdEncoding.DecodeString(resp.Body)
+		}
+
+		// AND the body should contain the data written by the handler
+		assert.Equal(t, tc.ExpectedBody, body)
+	}
+}
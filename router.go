@@ -4,12 +4,19 @@ package lux
 
 import (
 	"context"
-	"encoding/json"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"io"
+	"mime/multipart"
+	"net"
 	"net/http"
-	"runtime"
+	"net/textproto"
+	"regexp"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -18,27 +25,105 @@ import (
 )
 
 var (
+	errNotFound      = errors.New("not found")
 	errNotAllowed    = errors.New("not allowed")
 	errNotAcceptable = errors.New("not acceptable")
+	errUnsupported   = errors.New("unsupported media type")
+	errNoResponse    = errors.New("failed to obtain response")
+	errTimeout       = errors.New("timed out")
+	errTooLarge      = ErrBodyTooLarge
+
+	// bufferPool contains the buffers used for response bodies, so that they can be reused
+	// between requests.
+	bufferPool = sync.Pool{
+		New: func() interface{} {
+			buf := make([]byte, 0, 512)
+			return &buf
+		},
+	}
+
+	// errorStatus maps routing errors to their HTTP status codes.
+	errorStatus = map[error]int{
+		errNotFound:      http.StatusNotFound,
+		errNotAllowed:    http.StatusMethodNotAllowed,
+		errNotAcceptable: http.StatusNotAcceptable,
+		errUnsupported:   http.StatusUnsupportedMediaType,
+		errTooLarge:      http.StatusRequestEntityTooLarge,
+	}
+)
+
+// maxPooledBuffer is the capacity above which response body buffers are not reused.
+const maxPooledBuffer = 64 << 10
+
+const (
+	// StageMiddleware is the stage of a request in which middleware is executed.
+	StageMiddleware = "middleware"
+
+	// StageHandler is the stage of a request in which the route handler is executed.
+	StageHandler = "handler"
+
+	// StageAfter is the stage of a request in which the functions registered using
+	// ResponseWriter.After are executed.
+	StageAfter = "after"
 )
 
 type (
 	// The Router type handles incoming requests & routes them to the registered
 	// handlers.
 	Router struct {
-		routes     []*Route
-		middleware []HandlerFunc
-		recovery   RecoverFunc
-		log        *logrus.Logger
+		routes         []*Route
+		middleware     []HandlerFunc
+		recovery       RecoverFunc
+		recoveryResp   RecoverResponseFunc
+		log            Logger
+		defaultLog     *logrus.Logger
+		compression    *CompressionOptions
+		headers        map[string]string
+		errorHandler   ErrorFunc
+		notFound       HandlerFunc
+		notAllowed     HandlerFunc
+		named          map[string]*Route
+		wsRoutes       map[string]*Route
+		duplicates     []string
+		tracing        bool
+		timeout        time.Duration
+		deadlineMargin time.Duration
+		maxBodySize    int
+		returnErrors   bool
+		streaming      bool
+		strictSlash    bool
+		debug          bool
+		events         EventType
+		trustedProxies []*net.IPNet
+		invalidProxies []string
+		codec          *jsonCodec
+		tree           *routeTree
+		treeMu         sync.Mutex
 	}
 
 	// The Route type defines a route that can be used by the router.
 	Route struct {
-		handler    HandlerFunc
-		method     string
-		headers    map[string]string
-		queries    map[string]string
-		middleware []HandlerFunc
+		handler      HandlerFunc
+		name         string
+		routeKey     string
+		methods      []string
+		path         *pathPattern
+		headers      map[string]string
+		queries      map[string]string
+		accepts      []string
+		matchers     []func(*Request) error
+		noHead       bool
+		noLog        bool
+		noMiddleware bool
+		logBody      bool
+		maxBodySize  int
+		schema       *bodySchema
+		doc          routeDoc
+		fallback     HandlerFunc
+		meta         map[string]interface{}
+		middleware   []HandlerFunc
+		group        *Group
+		router       *Router
 	}
 
 	// The ResponseWriter type allows for interacting with the HTTP response similarly to a triaditional
@@ -47,6 +132,20 @@ type (
 		Write([]byte) (int, error)
 		WriteHeader(int)
 		Header() *Headers
+		Abort()
+
+		// Status returns the HTTP status code written to the response, or 0 if no
+		// status code has been written.
+		Status() int
+
+		// Size returns the number of bytes written to the response body.
+		Size() int
+
+		// After registers a function to be called once the route handler has
+		// finished, or the chain has been halted. Functions are called in the
+		// reverse order they are registered, allowing middleware to inspect the
+		// response using Status and Size.
+		After(func())
 	}
 
 	// The PanicInfo type is passed to any custom registered panic handler functions and provides details
@@ -55,40 +154,107 @@ type (
 		Error   error
 		Stack   []byte
 		Request Request
+
+		// Value contains the original value passed to panic.
+		Value interface{}
+
+		// Method contains the HTTP method of the request that caused the panic.
+		Method string
+
+		// Route contains the path pattern of the route that was handling the request,
+		// or an empty string if the route has no path.
+		Route string
+
+		// Stage contains the stage of the request that panicked, which is one of
+		// StageMiddleware, StageHandler or StageAfter.
+		Stage string
+
+		// Middleware contains the position of the middleware that panicked within the
+		// middleware chain when the Stage is StageMiddleware, otherwise it is -1.
+		Middleware int
 	}
 
 	// The HandlerFunc type defines what a handler function should look like.
 	HandlerFunc func(ResponseWriter, *Request)
 
+	// The ErrorFunc type defines what a function that renders errors generated by the
+	// router should look like.
+	ErrorFunc func(w ResponseWriter, r *Request, status int, err error)
+
 	// The RecoverFunc type defines what a panic recovery function should look like.
 	RecoverFunc func(PanicInfo)
 
+	// The RecoverResponseFunc type defines what a panic recovery function that writes the
+	// response should look like.
+	RecoverResponseFunc func(ResponseWriter, PanicInfo)
+
 	// The Request type represents an incoming HTTP request.
 	Request struct {
 		events.APIGatewayProxyRequest
 
-		Context context.Context `json:"-"`
+		ctx       context.Context
+		params    map[string]string
+		route     *Route
+		websocket *events.APIGatewayWebsocketProxyRequestContext
+		router    *Router
+		bodyLimit int
+		response  *responseWriter
+		startedAt time.Time
+		raw       interface{}
+		form      *multipart.Form
 	}
 
 	// The Response type represents an outgoing HTTP response.
 	Response events.APIGatewayProxyResponse
 
-	// The Headers type represents the HTTP response headers.
-	Headers map[string]string
+	// The Headers type represents the HTTP response headers. A header can have
+	// multiple values, such as when setting multiple cookies.
+	Headers map[string][]string
+
+	routeMatch struct {
+		route    *Route
+		params   map[string]string
+		allowed  []string
+		fallback *Route
+	}
+
+	routeCandidate struct {
+		route  *Route
+		params map[string]string
+	}
+
+	requestStage struct {
+		name       string
+		middleware int
+	}
 
 	responseWriter struct {
 		code    int
 		headers Headers
 		body    []byte
+		aborted bool
+		binary  bool
+		after   []func()
+		failure error
+		stream  *responseStream
+		sent    int
+		codec   *jsonCodec
+		log     Logger
 	}
 )
 
 // NewRouter creates a new lambda router.
 func NewRouter() *Router {
+	log := logrus.New()
+
 	return &Router{
 		routes:     []*Route{},
 		middleware: []HandlerFunc{},
-		log:        logrus.New(),
+		log:        NewLogrusLogger(log),
+		defaultLog: log,
+		named:      make(map[string]*Route),
+		wsRoutes:   make(map[string]*Route),
+		headers:    make(map[string]string),
 	}
 }
 
@@ -96,24 +262,28 @@ func NewRouter() *Router {
 func (r *Router) Handler(method string, fn HandlerFunc) *Route {
 	route := &Route{
 		handler:    fn,
-		method:     method,
+		methods:    []string{method},
 		headers:    make(map[string]string),
 		queries:    make(map[string]string),
 		middleware: []HandlerFunc{},
+		router:     r,
 	}
 
 	r.routes = append(r.routes, route)
+	r.reindex()
 
-	r.log.WithFields(logrus.Fields{
+	r.log.Info("registered new handler", Fields{
 		"method": method,
-	}).Info("registered new handler")
+	})
 
 	return route
 }
 
 // Middleware adds a middleware function to the router. These methods will be called
 // prior to the route handler and allow you to perform processing on the request before
-// your handler is executed.
+// your handler is executed. Global middleware is executed in the order it is registered
+// and always before any group or route specific middleware, regardless of whether it was
+// registered before or after the route.
 func (r *Router) Middleware(fn ...HandlerFunc) *Router {
 	r.middleware = append(r.middleware, fn...)
 
@@ -122,186 +292,746 @@ func (r *Router) Middleware(fn ...HandlerFunc) *Router {
 
 // Recovery sets a custom recovery handler that allows you to process panics using
 // your own handler. Not providing a recovery handler does not mean that your
-// panics are not handled. When no custom handler is specified your panic
-// will be logged to os.Stdout and execution can resume.
+// panics are not handled. The router always recovers from panics, logging them
+// using the configured logger & returning a 500 response.
 func (r *Router) Recovery(fn RecoverFunc) *Router {
 	r.recovery = fn
 
 	return r
 }
 
-// Logging sets the output for logs generated by the router. The logging package used
-// is logrus (https://github.com/sirupsen/logrus). All logs written to os.Stdout and
-// os.Stderr will automatically be picked up by CloudWatch. The logrus.Formatter
-// interface allows you to specify a custom format for logs. By default, the output
-// is JSON
+// RecoveryResponse sets a custom recovery handler that writes the response to requests
+// that panicked, allowing you to render a custom error page or JSON body. It is called
+// after any handler set using Recovery. If it writes nothing to the response, the error
+// handler or default 500 response is used.
+func (r *Router) RecoveryResponse(fn RecoverResponseFunc) *Router {
+	r.recoveryResp = fn
+
+	return r
+}
+
+// ErrorHandler sets a custom handler for rendering errors generated by the router & its
+// middleware, such as when a request does not match any routes, fails authentication or a
+// handler panics. This allows you to render all errors consistently, such as using a JSON
+// envelope. When no error handler is specified, routing errors are written as JSON encoded
+// strings & panics result in a plain text 500 response.
+func (r *Router) ErrorHandler(fn ErrorFunc) *Router {
+	r.errorHandler = fn
+
+	return r
+}
+
+// NotFound sets a handler for requests whose path does not match any routes, replacing
+// the default 404 response. The router's global middleware is executed before the handler
+// and it takes precedence over the error handler.
+func (r *Router) NotFound(fn HandlerFunc) *Router {
+	r.notFound = fn
+
+	return r
+}
+
+// MethodNotAllowed sets a handler for requests whose path matches a route, but whose
+// method does not, replacing the default 405 response. The Allow header is set before
+// the handler is executed, so that it lists the methods registered for the path. The
+// router's global middleware is executed before the handler and it takes precedence over
+// the error handler. OPTIONS requests are still responded to by the router.
+func (r *Router) MethodNotAllowed(fn HandlerFunc) *Router {
+	r.notAllowed = fn
+
+	return r
+}
+
+// DefaultHeaders sets headers that are added to every response, such as security or
+// caching headers. Default headers never replace headers written by middleware, handlers
+// or the error handler. Calling DefaultHeaders multiple times merges the given headers
+// with those already set.
+func (r *Router) DefaultHeaders(headers map[string]string) *Router {
+	for key, value := range headers {
+		r.headers[textproto.CanonicalMIMEHeaderKey(key)] = value
+	}
+
+	return r
+}
+
+// Logging sets the output for logs generated by the router. By default, the logging
+// package used is logrus (https://github.com/sirupsen/logrus), use Router.Logger to use a
+// different package. All logs written to os.Stdout and os.Stderr will automatically be
+// picked up by CloudWatch. The logrus.Formatter interface allows you to specify a custom
+// format for logs. By default, the output is JSON
 func (r *Router) Logging(out io.Writer, format logrus.Formatter) *Router {
-	r.log.Formatter = format
-	r.log.Out = out
+	log := logrus.New()
+	log.Formatter = format
+	log.Out = out
+
+	r.log, r.defaultLog = NewLogrusLogger(log), log
+	r.setLogLevel()
+
+	return r
+}
+
+// Logger sets the logger used by the router, allowing you to use the logging package of
+// your choice by implementing the Logger interface. Use NopLogger to disable logging.
+func (r *Router) Logger(log Logger) *Router {
+	r.log, r.defaultLog = log, nil
 
 	return r
 }
 
 // ServeHTTP handles an incoming HTTP request from the AWS API Gateway. If
 // the request matches a registered route then the specified handler will be
-// executed after any registered middleware.
+// executed after any registered middleware. The given context is made available
+// to handlers using Request.Context, allowing them to observe the deadline of the
+// lambda invocation.
 //
 // If a handler cannot be found matching the HTTP method, a 405 response
-// will be returned to the client.
+// will be returned to the client with an Allow header listing the methods
+// that are registered for the path. OPTIONS requests for paths without an
+// OPTIONS handler will result in a 204 response with the same Allow header.
 //
 // If you have specified query or header filters to your route, a request
 // that matches the HTTP method but lacks the required parameters/headers
-// will result in a 406 response.
+// will result in a 406 response, unless it is the Content-Type header that
+// does not match, which will result in a 415 response.
+//
+// Nil header, query & path parameter maps are treated as empty, so handlers
+// & matchers can safely read from and write to them.
 //
 // A panic will result in a 500 response.
-func (r *Router) ServeHTTP(req Request) (Response, error) {
+//
+// The returned error is always nil unless Router.ReturnErrors is enabled, as the
+// lambda runtime discards the response when an error is returned. When enabled,
+// a ServeError is returned alongside the response for framework-level failures:
+// requests that could not be routed, panics, timeouts & handlers that write no
+// response. Error responses written by handlers & middleware never return an
+// error.
+func (r *Router) ServeHTTP(ctx context.Context, req Request) (Response, error) {
+	return r.handle(ctx, req, nil)
+}
+
+// handle logs & serves the request. If a stream is given, the response body is written to
+// the stream as the handler writes it, rather than being buffered.
+func (r *Router) handle(ctx context.Context, req Request, stream *responseStream) (Response, error) {
 	ts := time.Now()
+	req.startedAt = ts
+
+	resp, route, failure := r.serve(ctx, req, stream)
+
+	duration := time.Since(ts)
+
+	if route.logged() {
+		r.log.Info("finished handling request", Fields{
+			"method":     req.HTTPMethod,
+			"path":       req.Path,
+			"route":      route.patternOrMethod(req.HTTPMethod),
+			"status":     resp.StatusCode,
+			"size":       len(resp.Body),
+			"duration":   duration.String(),
+			"durationMs": float64(duration) / float64(time.Millisecond),
+			"requestId":  req.RequestContext.RequestID,
+		})
+	}
 
-	r.log.WithFields(logrus.Fields{
-		"method":    req.HTTPMethod,
-		"params":    req.QueryStringParameters,
-		"requestId": req.RequestContext.RequestID,
-	}).Info("handling incoming request")
+	if r.returnErrors && failure != nil {
+		return resp, &ServeError{StatusCode: resp.StatusCode, Err: failure}
+	}
+
+	return resp, nil
+}
+
+// serve routes the request to the appropriate handler and returns the response along
+// with the matched route, which will be nil if the request could not be routed, and any
+// framework-level failure that occurred while handling the request.
+func (r *Router) serve(ctx context.Context, req Request, stream *responseStream) (Response, *Route, error) {
+	req.normalize()
 
-	route, err := r.findRoute(req)
+	match, err := r.findRoute(req)
+	route := match.route
+
+	w := &responseWriter{
+		headers: make(Headers),
+		body:    getBuffer(),
+		stream:  stream,
+		codec:   r.codec,
+		log:     r.log,
+	}
+
+	// The body is read once the request has been handled, as it may have been grown or
+	// replaced by the response written within a timeout
+	defer func() {
+		putBuffer(w.body)
+	}()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ctx, cancel := r.withDeadlineMargin(ctx)
+	defer cancel()
+
+	req.ctx = ctx
+	req.params = match.params
+	req.router = r
+	req.response = w
 
 	if err == errNotAllowed {
-		return newResponse(err.Error(), http.StatusMethodNotAllowed)
+		w.Header().Set("Allow", strings.Join(match.allowed, ", "))
 	}
 
-	if err == errNotAcceptable {
-		return newResponse(err.Error(), http.StatusNotAcceptable)
+	// Respond to OPTIONS requests for paths without an OPTIONS handler
+	if err == errNotAllowed && req.HTTPMethod == http.MethodOptions {
+		route, err = newOptionsRoute(), nil
 	}
 
-	w := &responseWriter{
-		headers: make(Headers),
-		body:    []byte{},
+	// Redirect requests whose path only differs from a route by a trailing slash
+	if err == errNotFound {
+		if redirect := r.slashRedirect(req); redirect != nil {
+			route, err = redirect, nil
+		}
 	}
 
-	req.Context = context.Background()
-	r.performRequest(route, w, req)
+	// Routing failures are reported even when a custom handler renders the response
+	w.failure = err
+
+	if err != nil && r.debug {
+		r.logMismatch(req, match, err)
+	}
+
+	// Use any custom handlers for requests that cannot be routed
+	switch {
+	case err == errNotFound && r.notFound != nil:
+		route, err = newFallbackRoute(req.HTTPMethod, r.notFound), nil
+	case err == errNotAllowed && match.fallback != nil:
+		route, err = match.fallback, nil
+	case err == errNotAllowed && r.notAllowed != nil:
+		route, err = newFallbackRoute(req.HTTPMethod, r.notAllowed), nil
+	}
+
+	req.route = route
+	req.bodyLimit = r.bodyLimit(route)
+
+	if route.logged() {
+		r.logRequest(route, &req)
+	}
+
+	if err == nil && req.bodyLimit > 0 && bodySize(req) > req.bodyLimit {
+		err, w.failure = errTooLarge, errTooLarge
+	}
+
+	// Responses from routes that negotiate their media type depend on the Accept header
+	if err == nil && len(route.accepts) > 0 {
+		w.headers.addVary("Accept")
+	}
+
+	if err != nil {
+		r.writeError(w, &req, errorStatus[err], err)
+	} else if r.tracing {
+		r.traceRequest(route, w, req)
+	} else {
+		r.performRequest(route, w, req)
+	}
+
+	// If nothing was written, let the error handler create a response
+	if w.code == 0 && w.failure == nil {
+		w.failure = errNoResponse
+	}
+
+	if w.code == 0 && r.errorHandler != nil {
+		r.errorHandler(w, &req, http.StatusInternalServerError, errNoResponse)
+	}
 
 	resp := w.getResponse()
 
-	r.log.WithFields(logrus.Fields{
-		"status":    resp.StatusCode,
-		"duration":  time.Since(ts).String(),
-		"requestId": req.RequestContext.RequestID,
-	}).Info("finished handling request")
+	// Apply any default headers the response does not already have
+	for key, value := range r.headers {
+		if resp.header(key) == "" {
+			resp.setHeader(key, value)
+		}
+	}
 
-	return resp, nil
+	if r.compression != nil {
+		resp = r.compression.compress(req, resp)
+	}
+
+	// Responses to HEAD requests contain the headers of the response without the body
+	if req.HTTPMethod == http.MethodHead {
+		resp.Body = ""
+		resp.IsBase64Encoded = false
+	}
+
+	return resp, route, w.failure
 }
 
-// performRequest executes any registered middleware before attempting to use the route's
-// handler & will recover from any panics.
+// writeError writes a framework generated error to the response using the custom error
+// handler if one has been provided. Otherwise, the error message is written as a JSON
+// encoded string, including for requests that are handled without a router.
+func (r *Router) writeError(w ResponseWriter, req *Request, status int, err error) {
+	if r != nil && r.errorHandler != nil {
+		r.errorHandler(w, req, status, err)
+		return
+	}
+
+	JSON(w, status, err.Error())
+}
+
+// performRequest executes the request, bounding its execution time if a timeout has
+// been set.
 func (r *Router) performRequest(route *Route, w *responseWriter, req Request) {
-	defer r.recover(req)
+	if r.timeout > 0 {
+		r.timeoutRequest(route, w, req)
+		return
+	}
+
+	r.runRequest(route, w, req)
+}
+
+// runRequest executes any registered middleware before attempting to use the route's
+// handler & will recover from any panics.
+func (r *Router) runRequest(route *Route, w *responseWriter, req Request) {
+	stage := &requestStage{name: StageMiddleware, middleware: -1}
 
-	wares := append(r.middleware, route.middleware...)
+	defer r.finish(route, w, &req, stage)
+	defer r.recover(route, w, &req, stage)
 
 	// Run any registered middleware
-	for _, mid := range wares {
+	for i, mid := range r.chain(route) {
+		stage.middleware = i
+
 		// Return a response if the middleware warrants it
-		if mid(w, &req); w.code != 0 {
+		if mid(w, &req); w.code != 0 || w.aborted {
 			return
 		}
 	}
 
+	stage.name, stage.middleware = StageHandler, -1
+
+	// Validate the request body before the handler uses it
+	if route.schema != nil && !route.schema.validate(w, &req) {
+		return
+	}
+
 	route.handler(w, &req)
 }
 
+// finish calls the functions registered using ResponseWriter.After, recovering from any
+// panics.
+func (r *Router) finish(route *Route, w *responseWriter, req *Request, stage *requestStage) {
+	stage.name, stage.middleware = StageAfter, -1
+
+	defer r.recover(route, w, req, stage)
+
+	w.finish()
+}
+
+// Path allows you to specify the URL path a request should have in order to use
+// this route. Segments wrapped in braces, such as "/users/{id}", are treated as
+// named parameters and their values can be obtained using Request.PathParam.
+// Routes without a path will match any request path. If the route belongs to a
+// group, the pattern is appended to the group's prefix.
+func (r *Route) Path(pattern string) *Route {
+	if r.group != nil {
+		pattern = r.group.fullPrefix() + pattern
+	}
+
+	r.path = newPathPattern(pattern)
+	r.router.reindex()
+
+	return r
+}
+
+// Methods allows you to specify the HTTP methods the route handles, replacing the method
+// it was registered with. Each method is matched independently, but shares the handler,
+// matchers & middleware of the route.
+func (r *Route) Methods(methods ...string) *Route {
+	r.methods = methods
+
+	return r
+}
+
+// NoHead prevents a GET route from handling HEAD requests. By default, HEAD requests
+// for paths without a HEAD handler are handled by the GET handler, with the body of
+// the response discarded. Use this when a handler has side effects.
+func (r *Route) NoHead() *Route {
+	r.noHead = true
+
+	return r
+}
+
+// NoLog prevents requests handled by the route from being logged by the router, such as for
+// frequently called health checks. Logs written by middleware & the route handler are not
+// affected.
+func (r *Route) NoLog() *Route {
+	r.noLog = true
+
+	return r
+}
+
+// LogBody includes the body of requests handled by the route in a debug level log entry,
+// which can help when troubleshooting. Bodies are decoded from base64 & decompressed where
+// required. Avoid using this for routes that receive sensitive data.
+func (r *Route) LogBody() *Route {
+	r.logBody = true
+
+	return r
+}
+
+// Name registers the route under the given name, allowing a URL for the route to be
+// generated using Router.URL. Only the first route registered under a name is used, any
+// others are reported by Router.Err.
+func (r *Route) Name(name string) *Route {
+	r.name = name
+
+	if existing, ok := r.router.named[name]; ok && existing != r {
+		r.router.duplicates = append(r.router.duplicates, fmt.Sprintf("route name %s is registered more than once", name))
+		return r
+	}
+
+	r.router.named[name] = r
+
+	return r
+}
+
+// Meta attaches a value to the route under the given key, such as the scopes required to
+// access it. Middleware can obtain the value using Request.RouteMeta, allowing a single
+// middleware to enforce per-route policies without hardcoding paths.
+func (r *Route) Meta(key string, value interface{}) *Route {
+	if r.meta == nil {
+		r.meta = make(map[string]interface{})
+	}
+
+	r.meta[key] = value
+
+	return r
+}
+
+// chain returns the middleware to execute for the given route. Middleware is always
+// executed in the following order, with each set in the order it was registered:
+//
+//  1. The router's global middleware
+//  2. The middleware of each group the route belongs to, from the outermost group
+//  3. The route specific middleware
+//
+// Routes that use NoMiddleware only execute their route specific middleware.
+func (r *Router) chain(route *Route) []HandlerFunc {
+	if route.noMiddleware {
+		return route.middleware
+	}
+
+	wares := append([]HandlerFunc{}, r.middleware...)
+
+	if route.group != nil {
+		wares = append(wares, route.group.fullMiddleware()...)
+	}
+
+	return append(wares, route.middleware...)
+}
+
 // Headers allows you to specify headers a request should have in order to
 // use this route. You can use wildcards when you only care about a header's
-// presence rather than its value.
+// presence rather than its value. Header names are matched case-insensitively. The
+// Content-Type header is matched on its media type, ignoring parameters such as the
+// charset. Calling Headers multiple times adds to the required headers, so a request
+// must have all of them, while a header given again replaces its expected value.
 func (r *Route) Headers(pairs ...string) *Route {
-	r.headers = mapPairs(pairs...)
+	if r.headers == nil {
+		r.headers = make(map[string]string)
+	}
+
+	// Header names are case-insensitive, so store them in their canonical form
+	for key, value := range mapPairs(pairs...) {
+		key = textproto.CanonicalMIMEHeaderKey(key)
+
+		r.headers[key] = headerMatchValue(key, value)
+	}
 
 	return r
 }
 
+// HeaderIn allows you to specify a header a request should have, whose value is any of
+// the given values, in order to use this route. Like Headers, the header name is matched
+// case-insensitively and the Content-Type header is matched on its media type.
+func (r *Route) HeaderIn(name string, values ...string) *Route {
+	err := matchError(name)
+	key := textproto.CanonicalMIMEHeaderKey(name)
+	expected := make([]string, len(values))
+
+	for i, value := range values {
+		expected[i] = headerMatchValue(key, value)
+	}
+
+	r.matchers = append(r.matchers, func(req *Request) error {
+		value, ok := req.matchHeader(name)
+
+		for _, exp := range expected {
+			if matchValue(exp, value, ok) {
+				return nil
+			}
+		}
+
+		return err
+	})
+
+	return r
+}
+
+// headerMatchValue returns the value to match the header with the given canonical name
+// against. Content-Type values are reduced to their media type.
+func headerMatchValue(key, value string) string {
+	if key == "Content-Type" && value != "*" {
+		return mediaType(value)
+	}
+
+	return value
+}
+
 // Queries allows you to specify query parameters and values a request should have
 // in order to use this route. You can use wildcards when you only care about a
-// parameter's presence rather than its value.
+// parameter's presence rather than its value. If a parameter was provided multiple
+// times, any of its values may match.
 func (r *Route) Queries(pairs ...string) *Route {
 	r.queries = mapPairs(pairs...)
 
 	return r
 }
 
+// HeaderMatch allows you to specify a header a request should have, whose value matches
+// the given regular expression, in order to use this route.
+func (r *Route) HeaderMatch(name string, re *regexp.Regexp) *Route {
+	return r.HeaderFunc(name, re.MatchString)
+}
+
+// HeaderFunc allows you to specify a header a request should have, whose value satisfies
+// the given predicate, in order to use this route.
+func (r *Route) HeaderFunc(name string, fn func(string) bool) *Route {
+	err := matchError(name)
+
+	r.matchers = append(r.matchers, func(req *Request) error {
+		if value := req.header(name); value == "" || !fn(value) {
+			return err
+		}
+
+		return nil
+	})
+
+	return r
+}
+
+// QueryMatch allows you to specify a query parameter a request should have, whose value
+// matches the given regular expression, in order to use this route.
+func (r *Route) QueryMatch(key string, re *regexp.Regexp) *Route {
+	return r.QueryFunc(key, re.MatchString)
+}
+
+// QueryFunc allows you to specify a query parameter a request should have, whose value
+// satisfies the given predicate, in order to use this route. If the parameter was provided
+// multiple times, any of its values may satisfy the predicate.
+func (r *Route) QueryFunc(key string, fn func(string) bool) *Route {
+	r.matchers = append(r.matchers, func(req *Request) error {
+		for _, value := range req.QueryValues(key) {
+			if fn(value) {
+				return nil
+			}
+		}
+
+		return errNotAcceptable
+	})
+
+	return r
+}
+
+// Accepts allows you to specify the media types a route can respond with. A request
+// whose Accept header does not allow any of the given media types will result in a
+// 406 response. Use Request.Negotiate within your handler to determine which of the
+// media types should be used.
+func (r *Route) Accepts(media ...string) *Route {
+	r.accepts = media
+
+	return r
+}
+
 // Middleware allows you to apply middleware functions to a specific route, rather than
-// globally to all routes.
+// globally to all routes. Route specific middleware is executed in the order it is
+// registered, after any global & group middleware.
 func (r *Route) Middleware(fn ...HandlerFunc) *Route {
 	r.middleware = append(r.middleware, fn...)
 
 	return r
 }
 
-// newResponse creates a new response object with a JSON encoded body and given
-// status code.
-func newResponse(data interface{}, status int) (Response, error) {
-	json, err := json.Marshal(data)
+// NoMiddleware prevents the router's global middleware & the middleware of any groups
+// the route belongs to from being executed for the route, such as for health checks that
+// should not require authentication. Route specific middleware is still executed.
+func (r *Route) NoMiddleware() *Route {
+	r.noMiddleware = true
 
-	if err != nil {
-		return Response{}, fmt.Errorf("failed to encode response body, %v", err)
-	}
+	return r
+}
 
-	resp := Response{
-		StatusCode: status,
-		Body:       string(json),
-		Headers:    make(map[string]string),
+// Write appends the given data to the response body. Like the net/http package, if the status
+// code has not been written, a 200 status code is written first.
+func (w *responseWriter) Write(data []byte) (int, error) {
+	if w.code == 0 {
+		w.WriteHeader(http.StatusOK)
 	}
 
-	resp.Headers["Content-Type"] = "application/json"
-
-	return resp, nil
-}
+	// Streamed responses write the body as soon as the status code is known
+	if w.stream != nil && w.code != 0 {
+		return w.stream.write(w, data)
+	}
 
-// Write appends the given data to the response body.
-func (w *responseWriter) Write(data []byte) (int, error) {
 	w.body = append(w.body, data...)
 
 	return len(data), nil
 }
 
-// WriteHeader writes the given HTTP status code to the HTTP response.
+// WriteHeader writes the given HTTP status code to the HTTP response. Like the net/http
+// package, only the first call has an effect, with any subsequent calls logging a warning.
 func (w *responseWriter) WriteHeader(code int) {
+	if w.code != 0 {
+		if w.log != nil {
+			w.log.Warn("superfluous call to WriteHeader", Fields{
+				"status":  w.code,
+				"ignored": code,
+			})
+		}
+
+		return
+	}
+
 	w.code = code
 }
 
+// Abort prevents execution of any subsequent middleware & the route handler. The
+// response is made up of anything written prior to calling Abort.
+func (w *responseWriter) Abort() {
+	w.aborted = true
+}
+
+// Status returns the HTTP status code written to the response, or 0 if no status
+// code has been written.
+func (w *responseWriter) Status() int {
+	return w.code
+}
+
+// Size returns the number of bytes written to the response body.
+func (w *responseWriter) Size() int {
+	return len(w.body) + w.sent
+}
+
+// After registers a function to be called once the route handler has finished.
+func (w *responseWriter) After(fn func()) {
+	w.after = append(w.after, fn)
+}
+
+// finish calls the functions registered using After in reverse order.
+func (w *responseWriter) finish() {
+	for i := len(w.after) - 1; i >= 0; i-- {
+		w.after[i]()
+	}
+}
+
 // Headers obtains the HTTP response headers for a request.
 func (w *responseWriter) Header() *Headers {
 	return &w.headers
 }
 
-// Set creates a new header with the given key and value.
+// Set creates a new header with the given key and value, replacing any existing
+// values.
 func (h Headers) Set(key, val string) {
-	h[key] = val
+	h[textproto.CanonicalMIMEHeaderKey(key)] = []string{val}
+}
+
+// Add appends the given value to the header with the given key. Each value is
+// returned as a distinct entry in the response's multi-value headers.
+func (h Headers) Add(key, val string) {
+	key = textproto.CanonicalMIMEHeaderKey(key)
+	h[key] = append(h[key], val)
+}
+
+// Get returns the first value of the header with the given key, or an empty string
+// if the header has not been set.
+func (h Headers) Get(key string) string {
+	if values := h[textproto.CanonicalMIMEHeaderKey(key)]; len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
+
+// Values returns all values of the header with the given key.
+func (h Headers) Values(key string) []string {
+	return h[textproto.CanonicalMIMEHeaderKey(key)]
+}
+
+// Del removes the header with the given key.
+func (h Headers) Del(key string) {
+	delete(h, textproto.CanonicalMIMEHeaderKey(key))
 }
 
 // findRoute attempts to locate a route that can handle a given request and
 // returns errors specifying if no route is found, or the provided headers &
-// parameters for that route are invalid.
-func (r *Router) findRoute(req Request) (*Route, error) {
-	var out *Route
-	var checkRoutes []*Route
+// parameters for that route are invalid. The returned match contains the route,
+// any named parameters from its path and the methods registered for the path.
+func (r *Router) findRoute(req Request) (routeMatch, error) {
+	if req.websocket != nil {
+		return r.findWebSocketRoute(req)
+	}
+
+	var out routeMatch
+	var matched []*Route
+	var checkRoutes, headRoutes, anyRoutes []routeCandidate
 	var err error
 
-	// Look through each route
-	for _, route := range r.routes {
-		// If the route method matches, add it to the slice.
-		if route.method == req.HTTPMethod {
-			checkRoutes = append(checkRoutes, route)
+	// Look through each route that may match the path
+	for _, route := range r.index().lookup(req.Path) {
+		params, ok := route.matchPath(req.Path)
+
+		// If the route path doesn't match, check the next one.
+		if !ok {
+			continue
 		}
+
+		matched = append(matched, route)
+
+		for _, method := range route.methods {
+			// If the route method matches, add it to the slice.
+			if method == req.HTTPMethod {
+				checkRoutes = append(checkRoutes, routeCandidate{route: route, params: params})
+			}
+
+			// GET routes also handle HEAD requests unless they opt out.
+			if method == http.MethodGet && !route.noHead && req.HTTPMethod == http.MethodHead {
+				headRoutes = append(headRoutes, routeCandidate{route: route, params: params})
+			}
+
+			// Routes for any method are only checked after those for specific methods.
+			if method == MethodAny {
+				anyRoutes = append(anyRoutes, routeCandidate{route: route, params: params})
+			}
+		}
+	}
+
+	// Use GET routes for HEAD requests without a HEAD handler
+	if len(checkRoutes) == 0 {
+		checkRoutes = headRoutes
+	}
+
+	checkRoutes = append(checkRoutes, anyRoutes...)
+
+	// If we had routes but none of them matched the path, return a 404
+	if len(matched) == 0 && len(r.routes) > 0 {
+		return out, errNotFound
 	}
 
 	// If we got no routes to check, return a 405
 	if len(checkRoutes) == 0 {
-		return nil, errNotAllowed
+		out.allowed = allowedMethods(matched)
+		out.fallback = fallbackRoute(req.HTTPMethod, matched)
+		return out, errNotAllowed
 	}
 
-	// Look at each route with a matching method
-	for _, route := range checkRoutes {
-		err = route.canRoute(req)
+	// Look at each route with a matching path & method
+	for _, candidate := range checkRoutes {
+		err = candidate.route.canRoute(req)
 
 		// If we cannot use this route, check the next one.
 		if err != nil {
@@ -309,19 +1039,21 @@ func (r *Router) findRoute(req Request) (*Route, error) {
 		}
 
 		// Otherwise, we found our route
-		out = route
+		out.route = candidate.route
+		out.params = candidate.params
 		err = nil
 		break
 	}
 
-	// If we found a route, 'out' will be non-nil.
+	// If we found a route, 'out.route' will be non-nil.
 	return out, err
 }
 
 // recover handles panics that may occur during execution of the lambda function. In a situation
 // where a panic does occur, the router will recover and execute a custom panic handler if it has
-// been provided.
-func (r *Router) recover(req Request) {
+// been provided. Anything written to the response prior to the panic is discarded so that a 500
+// response is always returned.
+func (r *Router) recover(route *Route, w *responseWriter, req *Request, stage *requestStage) {
 	var err error
 
 	// If a panic was thrown
@@ -333,64 +1065,316 @@ func (r *Router) recover(req Request) {
 		case error:
 			err = x
 		default:
-			err = fmt.Errorf("%s", x)
+			err = fmt.Errorf("%v", x)
 		}
 
-		r.log.WithFields(logrus.Fields{
+		// Discard any partially written response
+		w.code = 0
+		w.body = w.body[:0]
+		w.failure = err
+
+		r.log.Error("recovered from panic", Fields{
 			"requestId": req.RequestContext.RequestID,
+			"method":    req.HTTPMethod,
 			"error":     err.Error(),
-		}).Error("recovered from panic")
+			"stage":     stage.name,
+		})
 
 		info := PanicInfo{
 			Error:   err,
-			Request: req,
-			Stack:   make([]byte, 1024*8),
+			Request: *req,
+			Stack:   debug.Stack(),
+			Value:   rec,
+			Method:  req.HTTPMethod,
 		}
 
-		runtime.Stack(info.Stack, false)
+		info.Route = route.pattern()
+		info.Stage = stage.name
+		info.Middleware = stage.middleware
 
 		// If a custom recover func was defined, use it.
 		if r.recovery != nil {
 			r.recovery(info)
 		}
+
+		// If a custom recover func that writes the response was defined, use it.
+		if r.recoveryResp != nil {
+			r.recoveryResp(w, info)
+		}
+
+		// If a custom error handler was defined, use it to render the response.
+		if w.code == 0 && r.errorHandler != nil {
+			r.errorHandler(w, req, http.StatusInternalServerError, err)
+		}
 	}
 }
 
-// canRoute determines if a route can handle a given request based on the route's expected headers
-// and parameters.
+// canRoute determines if a route can handle a given request based on the route's expected headers,
+// parameters and media types. A request whose Content-Type does not match results in errUnsupported,
+// which takes precedence over errNotAcceptable for any other mismatch.
 func (r *Route) canRoute(req Request) error {
-	if !matchMap(r.headers, req.Headers) || !matchMap(r.queries, req.QueryStringParameters) {
-		return errNotAcceptable
+	var err error
+
+	for key, expected := range r.headers {
+		if value, ok := req.matchHeader(key); !matchValue(expected, value, ok) {
+			err = mismatch(err, matchError(key))
+		}
+	}
+
+	if !matchQueries(r.queries, &req) {
+		err = mismatch(err, errNotAcceptable)
+	}
+
+	for _, match := range r.matchers {
+		err = mismatch(err, match(&req))
+	}
+
+	if len(r.accepts) > 0 && req.Negotiate(r.accepts...) == "" {
+		err = mismatch(err, errNotAcceptable)
+	}
+
+	return err
+}
+
+// mismatch returns the error to report for a request that failed to match a route, given the
+// error found so far & the next one. Unsupported media types take precedence, as the request
+// body cannot be processed regardless of the response it would receive.
+func mismatch(current, next error) error {
+	if current == errUnsupported || next == nil {
+		return current
+	}
+
+	return next
+}
+
+// matchPath determines if a route can handle a given request path. Routes without a
+// path will match any request path.
+func (r *Route) matchPath(path string) (map[string]string, bool) {
+	if r.path == nil {
+		return nil, true
 	}
 
-	return nil
+	return r.path.match(path)
+}
+
+// pattern returns the path pattern of the route, or the route key of a WebSocket route.
+// An empty string is returned if the route is nil or has neither.
+func (r *Route) pattern() string {
+	switch {
+	case r == nil:
+		return ""
+	case r.routeKey != "":
+		return r.routeKey
+	case r.path == nil:
+		return ""
+	default:
+		return r.path.raw
+	}
 }
 
 // getResponse takes all data written to the response writer and converts it into a Response type
-// that can be returned to the client.
+// that can be returned to the client. Headers are provided in both the single & multi-value formats,
+// where the single value format contains the first value of each header.
 func (w *responseWriter) getResponse() Response {
 	if w.code == 0 {
 		return Response{
 			StatusCode: http.StatusInternalServerError,
-			Body:       "failed to obtain response",
+			Body:       errNoResponse.Error(),
+			Headers: map[string]string{
+				"Content-Type":   "text/plain; charset=utf-8",
+				"Content-Length": strconv.Itoa(len(errNoResponse.Error())),
+			},
+		}
+	}
+
+	// Responses with these status codes cannot have a body
+	if !bodyAllowed(w.code) {
+		w.body = w.body[:0]
+		w.headers.Del("Content-Length")
+	} else if len(w.body) > 0 {
+		w.headers.Set("Content-Length", strconv.Itoa(len(w.body)))
+	}
+
+	resp := Response{
+		StatusCode:        w.code,
+		Headers:           make(map[string]string, len(w.headers)),
+		MultiValueHeaders: make(map[string][]string, len(w.headers)),
+	}
+
+	// Binary bodies must be base64 encoded to pass through the API Gateway
+	if len(w.body) > 0 && (w.binary || !isText(w.headers.Get("Content-Type"), w.body)) {
+		resp.Body = base64.StdEncoding.EncodeToString(w.body)
+		resp.IsBase64Encoded = true
+	} else {
+		resp.Body = string(w.body)
+	}
+
+	for key, values := range w.headers {
+		if len(values) == 0 {
+			continue
 		}
+
+		resp.Headers[key] = values[0]
+		resp.MultiValueHeaders[key] = values
 	}
 
-	return Response{
-		StatusCode: w.code,
-		Body:       string(w.body),
-		Headers:    w.headers,
+	return resp
+}
+
+// bodyAllowed determines if a response with the given status code can have a body.
+func bodyAllowed(code int) bool {
+	return code >= 200 && code != http.StatusNoContent && code != http.StatusNotModified
+}
+
+// header returns the first value of the given response header, ignoring the case of the
+// header name.
+func (r Response) header(name string) string {
+	if values, ok := r.MultiValueHeaders[name]; ok && len(values) > 0 {
+		return values[0]
 	}
+
+	return headerValue(r.Headers, name)
 }
 
-// matchMap determines whether or not the keys/values from the first map
-// match the keys/values in the second.
-func matchMap(m1, m2 map[string]string) bool {
-	// The first map contains the values we expect to be in the second
-	// map.
-	for expKey, expVal := range m1 {
-		// If the value we expect does not exist in the map, return false.
-		if value, ok := m2[expKey]; !ok || (value != expVal && expVal != "*") {
+// setHeader sets the value of the given response header in both the single & multi-value
+// headers.
+func (r *Response) setHeader(name, value string) {
+	if r.Headers == nil {
+		r.Headers = make(map[string]string)
+	}
+
+	r.Headers[name] = value
+
+	if r.MultiValueHeaders != nil {
+		r.MultiValueHeaders[name] = []string{value}
+	}
+}
+
+// logged determines if requests handled by the route are logged by the router. Requests that
+// could not be routed are always logged.
+func (r *Route) logged() bool {
+	return r == nil || !r.noLog
+}
+
+// logRequest logs an incoming request once it has been routed, including its body if the
+// route requires it.
+func (r *Router) logRequest(route *Route, req *Request) {
+	r.log.Info("handling incoming request", Fields{
+		"method":    req.HTTPMethod,
+		"params":    req.QueryStringParameters,
+		"requestId": req.RequestContext.RequestID,
+	})
+
+	if route == nil || !route.logBody {
+		return
+	}
+
+	body, err := req.RawBody()
+
+	if err != nil {
+		body = []byte(req.Body)
+	}
+
+	r.log.Debug("incoming request body", Fields{
+		"method":    req.HTTPMethod,
+		"path":      req.Path,
+		"body":      string(body),
+		"requestId": req.RequestContext.RequestID,
+	})
+}
+
+// patternOrMethod returns the pattern of the route, or the given method if the route has
+// no path. An empty string is returned if the request was not routed.
+func (r *Route) patternOrMethod(method string) string {
+	if r == nil {
+		return ""
+	}
+
+	if pattern := r.pattern(); pattern != "" {
+		return pattern
+	}
+
+	return method
+}
+
+// newOptionsRoute creates the route used to respond to OPTIONS requests for paths that do
+// not have an OPTIONS handler. The router's global middleware will still be executed.
+func newOptionsRoute() *Route {
+	return &Route{
+		methods: []string{http.MethodOptions},
+		handler: func(w ResponseWriter, r *Request) {
+			w.WriteHeader(http.StatusNoContent)
+		},
+	}
+}
+
+// newFallbackRoute creates the route used to execute a custom handler for requests that
+// could not be routed. The router's global middleware will still be executed.
+func newFallbackRoute(method string, fn HandlerFunc) *Route {
+	return &Route{
+		methods: []string{method},
+		handler: fn,
+	}
+}
+
+// getBuffer returns an empty buffer for a response body from the pool.
+func getBuffer() []byte {
+	return (*bufferPool.Get().(*[]byte))[:0]
+}
+
+// putBuffer returns a response body buffer to the pool once the response has been created.
+// Large buffers are not pooled, so that a single large response does not retain memory for
+// the lifetime of the lambda container.
+func putBuffer(buf []byte) {
+	if cap(buf) > maxPooledBuffer {
+		return
+	}
+
+	bufferPool.Put(&buf)
+}
+
+// allowedMethods returns the methods that can be used for a path matched by the given
+// routes. GET routes also allow HEAD unless they opt out, and OPTIONS requests are always
+// handled by the router.
+func allowedMethods(routes []*Route) []string {
+	var out []string
+
+	for _, route := range routes {
+		for _, method := range route.methods {
+			out = appendUnique(out, method)
+
+			if method == http.MethodGet && !route.noHead {
+				out = appendUnique(out, http.MethodHead)
+			}
+		}
+	}
+
+	return appendUnique(out, http.MethodOptions)
+}
+
+// appendUnique appends the value to the slice if it is not already present.
+func appendUnique(values []string, value string) []string {
+	for _, existing := range values {
+		if existing == value {
+			return values
+		}
+	}
+
+	return append(values, value)
+}
+
+// matchValue determines whether or not a value, and whether or not it was present,
+// satisfies the expected value, which may be a wildcard.
+func matchValue(expected, value string, ok bool) bool {
+	return ok && (value == expected || expected == "*")
+}
+
+// matchQueries determines whether or not the request has the expected query parameters. Both
+// the single & multi-value query parameters are considered, and a parameter provided multiple
+// times matches if any of its values does.
+func matchQueries(m map[string]string, req *Request) bool {
+	for key, expected := range m {
+		if !matchAny(expected, req.QueryValues(key)) {
 			return false
 		}
 	}
@@ -398,6 +1382,17 @@ func matchMap(m1, m2 map[string]string) bool {
 	return true
 }
 
+// matchAny determines whether or not any of the given values satisfies the expected value.
+func matchAny(expected string, values []string) bool {
+	for _, value := range values {
+		if matchValue(expected, value, true) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // mapPairs converts a given number of string arguments to a map. If an odd number
 // of arguments are specified, the last one will be given a wildcard (*) value.
 func mapPairs(pairs ...string) map[string]string {
@@ -408,7 +1403,7 @@ func mapPairs(pairs ...string) map[string]string {
 		value := ""
 
 		// Use a wildcard for odd pairings
-		if len(pairs) < i+1 {
+		if i+1 >= len(pairs) {
 			value = "*"
 		} else {
 			value = pairs[i+1]
@@ -420,3 +1415,38 @@ func mapPairs(pairs ...string) map[string]string {
 
 	return out
 }
+
+// matchError returns the error used when a request's value for the given header does
+// not match the route. Mismatched Content-Type headers describe the request body, so
+// they result in errUnsupported rather than errNotAcceptable.
+func matchError(name string) error {
+	if textproto.CanonicalMIMEHeaderKey(name) == "Content-Type" {
+		return errUnsupported
+	}
+
+	return errNotAcceptable
+}
+
+// headerValue returns the value of the given header, ignoring the case of the
+// header name.
+func headerValue(headers map[string]string, name string) string {
+	value, _ := lookupHeader(headers, name)
+
+	return value
+}
+
+// lookupHeader returns the value of the given header and whether or not it was present,
+// ignoring the case of the header name.
+func lookupHeader(headers map[string]string, name string) (string, bool) {
+	if value, ok := headers[name]; ok {
+		return value, true
+	}
+
+	for key, value := range headers {
+		if strings.EqualFold(key, name) {
+			return value, true
+		}
+	}
+
+	return "", false
+}
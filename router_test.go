@@ -2,8 +2,12 @@ package lux_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
+	"regexp"
+	"strconv"
 	"testing"
 
 	"github.com/aws/aws-lambda-go/events"
@@ -50,6 +54,19 @@ func TestRouter_UsesMiddleware(t *testing.T) {
 			Middleware:     errorMiddleware,
 			ExpectedBody:   "\"error\"",
 		},
+		// Scenario 3: Valid request but middleware aborts the chain.
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					HTTPMethod: "GET",
+					Headers:    map[string]string{"content-type": "application/json"},
+				},
+			},
+			Handlers:       map[string]lux.HandlerFunc{"GET": getHandler},
+			ExpectedStatus: http.StatusInternalServerError,
+			Middleware:     abortMiddleware,
+			ExpectedBody:   "failed to obtain response",
+		},
 	}
 
 	for _, tc := range tt {
@@ -68,7 +85,7 @@ func TestRouter_UsesMiddleware(t *testing.T) {
 		router.Middleware(tc.Middleware)
 
 		// WHEN we perform a request
-		resp, _ := router.ServeHTTP(tc.Request)
+		resp, _ := router.ServeHTTP(context.Background(), tc.Request)
 
 		// THEN the status code & body should be what we expect.
 		assert.Equal(t, tc.ExpectedBody, resp.Body)
@@ -76,6 +93,395 @@ func TestRouter_UsesMiddleware(t *testing.T) {
 	}
 }
 
+func TestRouter_MiddlewareOrder(t *testing.T) {
+	t.Parallel()
+
+	var called []string
+
+	record := func(name string) lux.HandlerFunc {
+		return func(w lux.ResponseWriter, r *lux.Request) {
+			called = append(called, name)
+		}
+	}
+
+	// GIVEN that we have a router with global middleware
+	router := lux.NewRouter().Middleware(record("global1"), record("global2"))
+	router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+	// AND that router has a handler with route specific middleware
+	router.Handler("GET", func(w lux.ResponseWriter, r *lux.Request) {
+		called = append(called, "handler")
+		w.WriteHeader(http.StatusOK)
+	}).Middleware(record("route1"), record("route2"))
+
+	// AND global middleware is registered after the handler
+	router.Middleware(record("global3"))
+
+	// WHEN we perform a request
+	router.ServeHTTP(context.Background(), lux.Request{
+		APIGatewayProxyRequest: events.APIGatewayProxyRequest{HTTPMethod: "GET"},
+	})
+
+	// THEN global middleware should run first, followed by route middleware & the handler.
+	assert.Equal(t, []string{"global1", "global2", "global3", "route1", "route2", "handler"}, called)
+}
+
+func TestResponseWriter_StatusAndSize(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Handler        lux.HandlerFunc
+		Middleware     []lux.HandlerFunc
+		ExpectedStatus int
+		ExpectedSize   int
+		ExpectedCalls  []string
+	}{
+		// Scenario 1: Handler writes a response
+		{
+			Handler: func(w lux.ResponseWriter, r *lux.Request) {
+				lux.Text(w, http.StatusCreated, "created")
+			},
+			ExpectedStatus: http.StatusCreated,
+			ExpectedSize:   7,
+			ExpectedCalls:  []string{"second", "first"},
+		},
+		// Scenario 2: Middleware halts the chain
+		{
+			Handler:        getHandler,
+			Middleware:     []lux.HandlerFunc{errorMiddleware},
+			ExpectedStatus: http.StatusInternalServerError,
+			ExpectedSize:   7,
+			ExpectedCalls:  []string{"second", "first"},
+		},
+		// Scenario 3: Handler panics
+		{
+			Handler:        panicHandler,
+			ExpectedStatus: 0,
+			ExpectedSize:   0,
+			ExpectedCalls:  []string{"second", "first"},
+		},
+	}
+
+	for _, tc := range tt {
+		var status, size int
+		var called []string
+
+		after := func(name string) lux.HandlerFunc {
+			return func(w lux.ResponseWriter, r *lux.Request) {
+				w.After(func() {
+					called = append(called, name)
+					status, size = w.Status(), w.Size()
+				})
+			}
+		}
+
+		// GIVEN that we have a router with middleware that inspects the response
+		router := lux.NewRouter().Middleware(after("first"), after("second"))
+		router.Middleware(tc.Middleware...)
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has a handler
+		router.Handler("GET", tc.Handler)
+
+		// WHEN we perform a request
+		router.ServeHTTP(context.Background(), lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{HTTPMethod: "GET"},
+		})
+
+		// THEN the middleware should observe the written status & size
+		assert.Equal(t, tc.ExpectedStatus, status)
+		assert.Equal(t, tc.ExpectedSize, size)
+
+		// AND the functions should be called in reverse order
+		assert.Equal(t, tc.ExpectedCalls, called)
+	}
+}
+
+func TestResponseWriter_WriteHeaderOnce(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Handler          lux.HandlerFunc
+		ExpectedStatus   int
+		ExpectedBody     string
+		ExpectedWarnings []lux.Fields
+	}{
+		// Scenario 1: Handler writes the status code once
+		{
+			Handler: func(w lux.ResponseWriter, r *lux.Request) {
+				w.WriteHeader(http.StatusCreated)
+			},
+			ExpectedStatus: http.StatusCreated,
+		},
+		// Scenario 2: Handler writes the status code twice
+		{
+			Handler: func(w lux.ResponseWriter, r *lux.Request) {
+				w.WriteHeader(http.StatusCreated)
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			ExpectedStatus:   http.StatusCreated,
+			ExpectedWarnings: []lux.Fields{{"status": http.StatusCreated, "ignored": http.StatusInternalServerError}},
+		},
+		// Scenario 3: Handler writes a response after writing the status code
+		{
+			Handler: func(w lux.ResponseWriter, r *lux.Request) {
+				w.WriteHeader(http.StatusAccepted)
+				lux.Text(w, http.StatusOK, "body")
+			},
+			ExpectedStatus:   http.StatusAccepted,
+			ExpectedBody:     "body",
+			ExpectedWarnings: []lux.Fields{{"status": http.StatusAccepted, "ignored": http.StatusOK}},
+		},
+		// Scenario 4: Function registered using After writes the status code
+		{
+			Handler: func(w lux.ResponseWriter, r *lux.Request) {
+				w.After(func() {
+					w.WriteHeader(http.StatusTeapot)
+				})
+
+				w.WriteHeader(http.StatusOK)
+			},
+			ExpectedStatus:   http.StatusOK,
+			ExpectedWarnings: []lux.Fields{{"status": http.StatusOK, "ignored": http.StatusTeapot}},
+		},
+	}
+
+	for _, tc := range tt {
+		log := &recordingLogger{}
+
+		// GIVEN that we have a router with a logger
+		router := lux.NewRouter().Logger(log)
+
+		// AND that router has a handler
+		router.Handler("GET", tc.Handler)
+
+		// WHEN we perform a request
+		resp, _ := router.ServeHTTP(context.Background(), lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{HTTPMethod: "GET"},
+		})
+
+		// THEN the first status code should be used
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+		assert.Equal(t, tc.ExpectedBody, resp.Body)
+
+		// AND any subsequent status codes should be logged as warnings
+		var warnings []lux.Fields
+
+		for _, e := range log.entries {
+			if e.Level == "warn" {
+				assert.Equal(t, "superfluous call to WriteHeader", e.Message)
+				warnings = append(warnings, e.Fields)
+			}
+		}
+
+		assert.Equal(t, tc.ExpectedWarnings, warnings)
+	}
+}
+
+func TestResponseWriter_ImplicitStatus(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Middleware     lux.HandlerFunc
+		Handler        lux.HandlerFunc
+		ExpectedStatus int
+		ExpectedBody   string
+	}{
+		// Scenario 1: Handler only writes a body
+		{
+			Handler: func(w lux.ResponseWriter, r *lux.Request) {
+				w.Write([]byte("hello"))
+			},
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   "hello",
+		},
+		// Scenario 2: Handler writes the status code after writing a body
+		{
+			Handler: func(w lux.ResponseWriter, r *lux.Request) {
+				w.Write([]byte("hello"))
+				w.WriteHeader(http.StatusNotFound)
+			},
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   "hello",
+		},
+		// Scenario 3: Handler writes the status code before writing a body
+		{
+			Handler: func(w lux.ResponseWriter, r *lux.Request) {
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte("hello"))
+			},
+			ExpectedStatus: http.StatusCreated,
+			ExpectedBody:   "hello",
+		},
+		// Scenario 4: Middleware writing a body halts the chain
+		{
+			Middleware: func(w lux.ResponseWriter, r *lux.Request) {
+				w.Write([]byte("middleware"))
+			},
+			Handler:        getHandler,
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   "middleware",
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router
+		router := lux.NewRouter()
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		if tc.Middleware != nil {
+			router.Middleware(tc.Middleware)
+		}
+
+		// AND that router has a handler
+		router.Handler("GET", tc.Handler)
+
+		// WHEN we perform a request
+		resp, _ := router.ServeHTTP(context.Background(), lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{HTTPMethod: "GET"},
+		})
+
+		// THEN the response should have the expected status & body
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+		assert.Equal(t, tc.ExpectedBody, resp.Body)
+	}
+}
+
+func TestRouter_ResponseBodies(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Handler               lux.HandlerFunc
+		ExpectedStatus        int
+		ExpectedBody          string
+		ExpectedContentLength string
+	}{
+		// Scenario 1: Handler writes a status without a body
+		{
+			Handler: func(w lux.ResponseWriter, r *lux.Request) {
+				w.WriteHeader(http.StatusAccepted)
+			},
+			ExpectedStatus: http.StatusAccepted,
+		},
+		// Scenario 2: Handler writes a body with an incorrect content length
+		{
+			Handler: func(w lux.ResponseWriter, r *lux.Request) {
+				w.Header().Set("Content-Length", "100")
+				lux.Text(w, http.StatusOK, "hello")
+			},
+			ExpectedStatus:        http.StatusOK,
+			ExpectedBody:          "hello",
+			ExpectedContentLength: "5",
+		},
+		// Scenario 3: Handler writes a body with a status that cannot have one
+		{
+			Handler: func(w lux.ResponseWriter, r *lux.Request) {
+				w.Header().Set("Content-Length", "5")
+				lux.Text(w, http.StatusNoContent, "hello")
+			},
+			ExpectedStatus: http.StatusNoContent,
+		},
+		// Scenario 4: Handler writes a response before panicking
+		{
+			Handler: func(w lux.ResponseWriter, r *lux.Request) {
+				lux.Text(w, http.StatusOK, "hello")
+				panic("oops")
+			},
+			ExpectedStatus:        http.StatusInternalServerError,
+			ExpectedBody:          "failed to obtain response",
+			ExpectedContentLength: "25",
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router
+		router := lux.NewRouter()
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has a handler
+		router.Handler("GET", tc.Handler)
+
+		// WHEN we perform a request
+		resp, _ := router.ServeHTTP(context.Background(), lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{HTTPMethod: "GET"},
+		})
+
+		// THEN the response should be what we expect
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+		assert.Equal(t, tc.ExpectedBody, resp.Body)
+		assert.False(t, resp.IsBase64Encoded)
+
+		// AND the content length should match the body
+		assert.Equal(t, tc.ExpectedContentLength, resp.Headers["Content-Length"])
+	}
+}
+
+func TestRouter_DefaultHeaders(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Request         lux.Request
+		ExpectedHeaders map[string]string
+	}{
+		// Scenario 1: Handler does not set the default headers
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{HTTPMethod: "GET", Path: "/users"},
+			},
+			ExpectedHeaders: map[string]string{
+				"X-Frame-Options": "DENY",
+				"Cache-Control":   "no-store",
+			},
+		},
+		// Scenario 2: Handler sets one of the default headers
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{HTTPMethod: "GET", Path: "/cached"},
+			},
+			ExpectedHeaders: map[string]string{
+				"X-Frame-Options": "DENY",
+				"Cache-Control":   "max-age=60",
+			},
+		},
+		// Scenario 3: Request results in a routing error
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{HTTPMethod: "GET", Path: "/unknown"},
+			},
+			ExpectedHeaders: map[string]string{
+				"X-Frame-Options": "DENY",
+				"Cache-Control":   "no-store",
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router with default headers
+		router := lux.NewRouter().DefaultHeaders(map[string]string{
+			"x-frame-options": "DENY",
+			"Cache-Control":   "no-store",
+		})
+
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has handlers registered
+		router.Handler("GET", getHandler).Path("/users")
+		router.Handler("GET", func(w lux.ResponseWriter, r *lux.Request) {
+			w.Header().Set("cache-control", "max-age=60")
+			getHandler(w, r)
+		}).Path("/cached")
+
+		// WHEN we perform the request
+		resp, _ := router.ServeHTTP(context.Background(), tc.Request)
+
+		// THEN the response should contain the expected headers
+		for key, value := range tc.ExpectedHeaders {
+			assert.Equal(t, value, resp.Headers[key])
+			assert.Equal(t, []string{value}, resp.MultiValueHeaders[key])
+		}
+	}
+}
+
 func TestRouter_HandlesRequests(t *testing.T) {
 	t.Parallel()
 
@@ -107,8 +513,8 @@ func TestRouter_HandlesRequests(t *testing.T) {
 				},
 			},
 			Handlers:       map[string]lux.HandlerFunc{"GET": getHandler},
-			ExpectedStatus: http.StatusNotAcceptable,
-			ExpectedError:  "not acceptable",
+			ExpectedStatus: http.StatusUnsupportedMediaType,
+			ExpectedError:  "unsupported media type",
 		},
 		// Scenario 3: Handler does not exist
 		{
@@ -133,8 +539,8 @@ func TestRouter_HandlesRequests(t *testing.T) {
 				},
 			},
 			Handlers:       map[string]lux.HandlerFunc{"GET": getHandler},
-			ExpectedStatus: http.StatusNotAcceptable,
-			ExpectedError:  "not acceptable",
+			ExpectedStatus: http.StatusUnsupportedMediaType,
+			ExpectedError:  "unsupported media type",
 		},
 		// Scenario 5: Valid DELETE request with only a GET handler registered.
 		{
@@ -177,7 +583,7 @@ func TestRouter_HandlesRequests(t *testing.T) {
 		}
 
 		// WHEN we perform the request
-		resp, err := router.ServeHTTP(tc.Request)
+		resp, err := router.ServeHTTP(context.Background(), tc.Request)
 
 		// THEN any errors should be what we expect
 		if err != nil {
@@ -223,7 +629,7 @@ func TestRouter_Recovers(t *testing.T) {
 		}
 
 		// WHEN we perform the request that will panic
-		resp, _ := router.ServeHTTP(tc.Request)
+		resp, _ := router.ServeHTTP(context.Background(), tc.Request)
 
 		// AND the status code should be what we expect.
 		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
@@ -231,26 +637,1289 @@ func TestRouter_Recovers(t *testing.T) {
 	}
 }
 
-func getHandler(w lux.ResponseWriter, r *lux.Request) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-
-	encoder := json.NewEncoder(w)
-	encoder.Encode("hello test")
-
-}
-
-func recoverHandler(info lux.PanicInfo) {
-	// Do nothing
-}
-
-func panicHandler(w lux.ResponseWriter, r *lux.Request) {
-	panic("uh oh")
-}
+func TestRouter_RoutesPaths(t *testing.T) {
+	t.Parallel()
 
-func errorMiddleware(w lux.ResponseWriter, r *lux.Request) {
-	w.WriteHeader(http.StatusInternalServerError)
-	w.Write([]byte("\"error\""))
+	tt := []struct {
+		Request        lux.Request
+		Paths          map[string]lux.HandlerFunc
+		ExpectedStatus int
+	}{
+		// Scenario 1: Request matches a static path
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					HTTPMethod: "GET",
+					Path:       "/users",
+				},
+			},
+			Paths:          map[string]lux.HandlerFunc{"/users": getHandler},
+			ExpectedStatus: http.StatusOK,
+		},
+		// Scenario 2: Request matches a path with a named parameter
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					HTTPMethod: "GET",
+					Path:       "/users/42/posts",
+				},
+			},
+			Paths:          map[string]lux.HandlerFunc{"/users/{id}/posts": getHandler},
+			ExpectedStatus: http.StatusOK,
+		},
+		// Scenario 3: Request does not match any path
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					HTTPMethod: "GET",
+					Path:       "/posts/42",
+				},
+			},
+			Paths:          map[string]lux.HandlerFunc{"/users/{id}": getHandler},
+			ExpectedStatus: http.StatusNotFound,
+		},
+		// Scenario 4: Request is missing a named parameter
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					HTTPMethod: "GET",
+					Path:       "/users/",
+				},
+			},
+			Paths:          map[string]lux.HandlerFunc{"/users/{id}": getHandler},
+			ExpectedStatus: http.StatusNotFound,
+		},
+		// Scenario 5: Request matches a path but not the method
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					HTTPMethod: "DELETE",
+					Path:       "/users/42",
+				},
+			},
+			Paths:          map[string]lux.HandlerFunc{"/users/{id}": getHandler},
+			ExpectedStatus: http.StatusMethodNotAllowed,
+		},
+		// Scenario 6: Request matches a catch-all parameter with multiple segments
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					HTTPMethod: "GET",
+					Path:       "/files/a/b/c.txt",
+				},
+			},
+			Paths:          map[string]lux.HandlerFunc{"/files/{path...}": getHandler},
+			ExpectedStatus: http.StatusOK,
+		},
+		// Scenario 7: Request matches a catch-all parameter with a single segment
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					HTTPMethod: "GET",
+					Path:       "/files/a.txt",
+				},
+			},
+			Paths:          map[string]lux.HandlerFunc{"/files/{path...}": getHandler},
+			ExpectedStatus: http.StatusOK,
+		},
+		// Scenario 8: Request is missing the segments of a catch-all parameter
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					HTTPMethod: "GET",
+					Path:       "/files/",
+				},
+			},
+			Paths:          map[string]lux.HandlerFunc{"/files/{path...}": getHandler},
+			ExpectedStatus: http.StatusNotFound,
+		},
+		// Scenario 9: Catch-all parameter is not the last segment
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					HTTPMethod: "GET",
+					Path:       "/files/a/raw",
+				},
+			},
+			Paths:          map[string]lux.HandlerFunc{"/files/{path...}/raw": getHandler},
+			ExpectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router
+		router := lux.NewRouter()
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has handlers registered for paths
+		for path, handler := range tc.Paths {
+			router.Handler("GET", handler).Path(path)
+		}
+
+		// WHEN we perform the request
+		resp, _ := router.ServeHTTP(context.Background(), tc.Request)
+
+		// THEN the status code should be what we expect.
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+	}
+}
+
+func TestRouter_MatchesPredicates(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Headers        map[string]string
+		Query          map[string]string
+		ExpectedStatus int
+	}{
+		// Scenario 1: Request matches the header expression & query predicate
+		{
+			Headers:        map[string]string{"Content-Type": "application/vnd.api+json"},
+			Query:          map[string]string{"page": "2"},
+			ExpectedStatus: http.StatusOK,
+		},
+		// Scenario 2: Request header does not match the expression
+		{
+			Headers:        map[string]string{"Content-Type": "text/plain"},
+			Query:          map[string]string{"page": "2"},
+			ExpectedStatus: http.StatusUnsupportedMediaType,
+		},
+		// Scenario 3: Request query parameter does not satisfy the predicate
+		{
+			Headers:        map[string]string{"Content-Type": "application/json"},
+			Query:          map[string]string{"page": "two"},
+			ExpectedStatus: http.StatusNotAcceptable,
+		},
+		// Scenario 4: Request is missing the header & query parameter
+		{
+			ExpectedStatus: http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router
+		router := lux.NewRouter()
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has a handler with header & query matchers
+		router.Handler("GET", getHandler).
+			HeaderMatch("content-type", regexp.MustCompile("^application/(.+\\+)?json$")).
+			QueryFunc("page", func(v string) bool {
+				_, err := strconv.Atoi(v)
+				return err == nil
+			})
+
+		// WHEN we perform a request
+		resp, _ := router.ServeHTTP(context.Background(), lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+				HTTPMethod:            "GET",
+				Headers:               tc.Headers,
+				QueryStringParameters: tc.Query,
+			},
+		})
+
+		// THEN the response should have the expected status
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+	}
+}
+
+func TestRouter_MatchesMultiValueQueries(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Query          map[string]string
+		MultiQuery     map[string][]string
+		ExpectedStatus int
+	}{
+		// Scenario 1: Parameters only present in the multi-value parameters
+		{
+			MultiQuery:     map[string][]string{"key": {"value"}, "page": {"2"}},
+			ExpectedStatus: http.StatusOK,
+		},
+		// Scenario 2: Parameters provided multiple times where one value matches
+		{
+			MultiQuery:     map[string][]string{"key": {"other", "value"}, "page": {"two", "2"}},
+			ExpectedStatus: http.StatusOK,
+		},
+		// Scenario 3: Parameters provided multiple times where no value matches
+		{
+			MultiQuery:     map[string][]string{"key": {"other", "another"}, "page": {"2"}},
+			ExpectedStatus: http.StatusNotAcceptable,
+		},
+		// Scenario 4: Parameters only present in the single value parameters
+		{
+			Query:          map[string]string{"key": "value", "page": "2"},
+			ExpectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router
+		router := lux.NewRouter()
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has a handler with query matchers
+		router.Handler("GET", getHandler).
+			Queries("key", "value").
+			QueryFunc("page", func(v string) bool {
+				_, err := strconv.Atoi(v)
+				return err == nil
+			})
+
+		// WHEN we perform a request
+		resp, _ := router.ServeHTTP(context.Background(), lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+				HTTPMethod:                      "GET",
+				QueryStringParameters:           tc.Query,
+				MultiValueQueryStringParameters: tc.MultiQuery,
+			},
+		})
+
+		// THEN the response should have the expected status
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+	}
+}
+
+func TestRouter_MatchesMultipleHeaders(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Headers        map[string]string
+		ExpectedStatus int
+	}{
+		// Scenario 1: Request has both required headers & an allowed accept header
+		{
+			Headers: map[string]string{
+				"Content-Type": "application/json; charset=utf-8",
+				"X-Api-Key":    "secret",
+				"Accept":       "text/json",
+			},
+			ExpectedStatus: http.StatusOK,
+		},
+		// Scenario 2: Request is missing one of the required headers
+		{
+			Headers: map[string]string{
+				"Content-Type": "application/json",
+				"Accept":       "application/json",
+			},
+			ExpectedStatus: http.StatusNotAcceptable,
+		},
+		// Scenario 3: Request has an accept header that is not allowed
+		{
+			Headers: map[string]string{
+				"Content-Type": "application/json",
+				"X-Api-Key":    "secret",
+				"Accept":       "text/html",
+			},
+			ExpectedStatus: http.StatusNotAcceptable,
+		},
+		// Scenario 4: Request has a content type that is not allowed
+		{
+			Headers: map[string]string{
+				"Content-Type": "text/plain",
+				"X-Api-Key":    "secret",
+				"Accept":       "application/json",
+			},
+			ExpectedStatus: http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router
+		router := lux.NewRouter()
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has a handler requiring multiple headers
+		router.Handler("POST", getHandler).
+			Headers("content-type", "application/json").
+			Headers("x-api-key", "secret").
+			HeaderIn("accept", "application/json", "text/json")
+
+		// WHEN we perform a request
+		resp, _ := router.ServeHTTP(context.Background(), lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+				HTTPMethod: "POST",
+				Headers:    tc.Headers,
+			},
+		})
+
+		// THEN the response should have the expected status
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+	}
+}
+
+func TestRouter_MatchesHeadersCaseInsensitively(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Request        lux.Request
+		Pairs          []string
+		ExpectedStatus int
+	}{
+		// Scenario 1: Mixed-case header against a lowercase matcher
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					HTTPMethod: "GET",
+					Headers:    map[string]string{"Content-Type": "application/json"},
+				},
+			},
+			Pairs:          []string{"content-type", "application/json"},
+			ExpectedStatus: http.StatusOK,
+		},
+		// Scenario 2: Lowercase header against a mixed-case matcher
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					HTTPMethod: "GET",
+					Headers:    map[string]string{"x-api-key": "secret"},
+				},
+			},
+			Pairs:          []string{"X-Api-Key", "secret"},
+			ExpectedStatus: http.StatusOK,
+		},
+		// Scenario 3: Uppercase multi-value header against a wildcard matcher
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					HTTPMethod:        "GET",
+					MultiValueHeaders: map[string][]string{"X-API-KEY": {"secret"}},
+				},
+			},
+			Pairs:          []string{"x-api-key"},
+			ExpectedStatus: http.StatusOK,
+		},
+		// Scenario 4: Mixed-case header with a different value
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					HTTPMethod: "GET",
+					Headers:    map[string]string{"Content-Type": "text/plain"},
+				},
+			},
+			Pairs:          []string{"content-type", "application/json"},
+			ExpectedStatus: http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router
+		router := lux.NewRouter()
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has a handler with header matchers
+		router.Handler("GET", getHandler).Headers(tc.Pairs...)
+
+		// WHEN we perform the request
+		resp, _ := router.ServeHTTP(context.Background(), tc.Request)
+
+		// THEN the response should have the expected status
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+	}
+}
+
+func TestRouter_MatchesContentType(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		ContentType    string
+		Pairs          []string
+		ExpectedStatus int
+	}{
+		// Scenario 1: Content type with a charset against a media type
+		{
+			ContentType:    "application/json; charset=utf-8",
+			Pairs:          []string{"Content-Type", "application/json"},
+			ExpectedStatus: http.StatusOK,
+		},
+		// Scenario 2: Content type against a media type with a charset
+		{
+			ContentType:    "application/json",
+			Pairs:          []string{"Content-Type", "application/json; charset=utf-8"},
+			ExpectedStatus: http.StatusOK,
+		},
+		// Scenario 3: Content type with a different media type
+		{
+			ContentType:    "text/plain; charset=utf-8",
+			Pairs:          []string{"Content-Type", "application/json"},
+			ExpectedStatus: http.StatusUnsupportedMediaType,
+		},
+		// Scenario 4: Content type with a boundary against a wildcard
+		{
+			ContentType:    "multipart/form-data; boundary=abc",
+			Pairs:          []string{"Content-Type", "*"},
+			ExpectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router
+		router := lux.NewRouter()
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has a handler with a content type matcher
+		router.Handler("POST", getHandler).Headers(tc.Pairs...)
+
+		// WHEN we perform a request with a content type
+		resp, _ := router.ServeHTTP(context.Background(), lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+				HTTPMethod: "POST",
+				Headers:    map[string]string{"Content-Type": tc.ContentType},
+			},
+		})
+
+		// THEN the response should have the expected status
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+	}
+}
+
+func TestRouter_UnsupportedMediaType(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		ContentType    string
+		Accept         string
+		ExpectedStatus int
+		ExpectedBody   string
+	}{
+		// Scenario 1: Request matches the content type & accept header
+		{
+			ContentType:    "application/json",
+			Accept:         "application/json",
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   "\"hello test\"\n",
+		},
+		// Scenario 2: Request body has an unsupported content type
+		{
+			ContentType:    "application/xml",
+			Accept:         "application/json",
+			ExpectedStatus: http.StatusUnsupportedMediaType,
+			ExpectedBody:   `"unsupported media type"`,
+		},
+		// Scenario 3: Request does not accept the response media type
+		{
+			ContentType:    "application/json",
+			Accept:         "text/html",
+			ExpectedStatus: http.StatusNotAcceptable,
+			ExpectedBody:   `"not acceptable"`,
+		},
+		// Scenario 4: Request has an unsupported content type & does not accept the response
+		{
+			ContentType:    "application/xml",
+			Accept:         "text/html",
+			ExpectedStatus: http.StatusUnsupportedMediaType,
+			ExpectedBody:   `"unsupported media type"`,
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router
+		router := lux.NewRouter()
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has a handler that consumes & produces JSON
+		router.Handler("POST", getHandler).
+			Headers("Content-Type", "application/json").
+			Accepts("application/json")
+
+		// WHEN we perform a request
+		resp, _ := router.ServeHTTP(context.Background(), lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+				HTTPMethod: "POST",
+				Headers: map[string]string{
+					"Content-Type": tc.ContentType,
+					"Accept":       tc.Accept,
+				},
+			},
+		})
+
+		// THEN the response should describe which header could not be satisfied
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+		assert.Equal(t, tc.ExpectedBody, resp.Body)
+	}
+}
+
+func TestRoute_Methods(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Method         string
+		ExpectedStatus int
+		ExpectedAllow  string
+	}{
+		// Scenario 1: Request uses the first method
+		{
+			Method:         "PUT",
+			ExpectedStatus: http.StatusOK,
+		},
+		// Scenario 2: Request uses the second method
+		{
+			Method:         "PATCH",
+			ExpectedStatus: http.StatusOK,
+		},
+		// Scenario 3: Request uses a method the route does not handle
+		{
+			Method:         "POST",
+			ExpectedStatus: http.StatusMethodNotAllowed,
+			ExpectedAllow:  "PUT, PATCH, OPTIONS",
+		},
+	}
+
+	for _, tc := range tt {
+		var called int
+
+		// GIVEN that we have a router
+		router := lux.NewRouter()
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has a handler registered for multiple methods
+		router.Handler("PUT", getHandler).
+			Methods("PUT", "PATCH").
+			Path("/users/{id}").
+			Middleware(func(w lux.ResponseWriter, r *lux.Request) {
+				called++
+			})
+
+		// WHEN we perform a request
+		resp, _ := router.ServeHTTP(context.Background(), lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+				HTTPMethod: tc.Method,
+				Path:       "/users/42",
+			},
+		})
+
+		// THEN the response should have the expected status
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+		assert.Equal(t, tc.ExpectedAllow, resp.Headers["Allow"])
+
+		// AND the route middleware should be shared between the methods
+		if tc.ExpectedStatus == http.StatusOK {
+			assert.Equal(t, 1, called)
+		}
+	}
+}
+
+func TestRouter_HeadRequests(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		NoHead                bool
+		HeadHandler           lux.HandlerFunc
+		ExpectedStatus        int
+		ExpectedContentLength string
+		ExpectedAllow         string
+	}{
+		// Scenario 1: HEAD request handled by the GET handler
+		{
+			ExpectedStatus:        http.StatusOK,
+			ExpectedContentLength: "13",
+		},
+		// Scenario 2: HEAD request handled by an explicit HEAD handler
+		{
+			HeadHandler: func(w lux.ResponseWriter, r *lux.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			},
+			ExpectedStatus: http.StatusNoContent,
+		},
+		// Scenario 3: HEAD request for a GET handler that has opted out
+		{
+			NoHead:                true,
+			ExpectedStatus:        http.StatusMethodNotAllowed,
+			ExpectedContentLength: "13",
+			ExpectedAllow:         "GET, OPTIONS",
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router
+		router := lux.NewRouter()
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has a GET handler
+		route := router.Handler("GET", getHandler).Path("/users")
+
+		if tc.NoHead {
+			route.NoHead()
+		}
+
+		// AND that router may have a HEAD handler
+		if tc.HeadHandler != nil {
+			router.Handler("HEAD", tc.HeadHandler).Path("/users")
+		}
+
+		// WHEN we perform a HEAD request
+		resp, _ := router.ServeHTTP(context.Background(), lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+				HTTPMethod: "HEAD",
+				Path:       "/users",
+			},
+		})
+
+		// THEN the response should have the expected status & no body
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+		assert.Empty(t, resp.Body)
+
+		// AND the headers of the GET response should be preserved
+		assert.Equal(t, tc.ExpectedContentLength, resp.Headers["Content-Length"])
+		assert.Equal(t, tc.ExpectedAllow, resp.Headers["Allow"])
+	}
+}
+
+func TestRouter_RecoversByDefault(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Handler lux.HandlerFunc
+	}{
+		// Scenario 1: Handler panics before writing a response
+		{
+			Handler: panicHandler,
+		},
+		// Scenario 2: Handler panics after writing a response
+		{
+			Handler: func(w lux.ResponseWriter, r *lux.Request) {
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte("partial"))
+
+				panic(errors.New("uh oh"))
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		logs := bytes.NewBuffer([]byte{})
+
+		// GIVEN that we have a router without a recovery handler.
+		router := lux.NewRouter()
+		router.Logging(logs, &logrus.JSONFormatter{})
+
+		// AND that router has a handler that panics
+		router.Handler("GET", tc.Handler)
+
+		// WHEN we perform the request that will panic
+		resp, err := router.ServeHTTP(context.Background(), lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{HTTPMethod: "GET"},
+		})
+
+		// THEN the router should have recovered & returned a 500
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		assert.Equal(t, "failed to obtain response", resp.Body)
+
+		// AND the panic should have been logged
+		assert.Contains(t, logs.String(), "recovered from panic")
+	}
+}
+
+func TestRouter_ProvidesPanicInfo(t *testing.T) {
+	t.Parallel()
+
+	var info lux.PanicInfo
+
+	// GIVEN that we have a router with a recovery handler.
+	router := lux.NewRouter().Recovery(func(i lux.PanicInfo) {
+		info = i
+	})
+
+	router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+	// AND that router has a handler that panics
+	router.Handler("GET", panicHandler).Path("/users/{id}")
+
+	// WHEN we perform the request that will panic
+	router.ServeHTTP(context.Background(), lux.Request{
+		APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+			HTTPMethod: "GET",
+			Path:       "/users/42",
+		},
+	})
+
+	// THEN the panic information should describe the panic
+	assert.Equal(t, "uh oh", info.Error.Error())
+	assert.Equal(t, "uh oh", info.Value)
+	assert.Equal(t, "GET", info.Method)
+	assert.Equal(t, "/users/{id}", info.Route)
+	assert.Equal(t, lux.StageHandler, info.Stage)
+	assert.Equal(t, -1, info.Middleware)
+	assert.Contains(t, string(info.Stack), "panicHandler")
+}
+
+func TestRouter_RecoveryResponse(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Recovery       lux.RecoverResponseFunc
+		ExpectedStatus int
+		ExpectedBody   string
+	}{
+		// Scenario 1: Recovery handler writes a custom response
+		{
+			Recovery: func(w lux.ResponseWriter, info lux.PanicInfo) {
+				lux.JSON(w, http.StatusServiceUnavailable, info.Stage+": "+info.Error.Error())
+			},
+			ExpectedStatus: http.StatusServiceUnavailable,
+			ExpectedBody:   `"handler: something went wrong"`,
+		},
+		// Scenario 2: Recovery handler writes nothing
+		{
+			Recovery:       func(w lux.ResponseWriter, info lux.PanicInfo) {},
+			ExpectedStatus: http.StatusInternalServerError,
+			ExpectedBody:   `"error handler"`,
+		},
+	}
+
+	for _, tc := range tt {
+		observed := false
+
+		// GIVEN that we have a router with recovery & error handlers
+		router := lux.NewRouter().
+			Recovery(func(info lux.PanicInfo) { observed = true }).
+			RecoveryResponse(tc.Recovery).
+			ErrorHandler(func(w lux.ResponseWriter, r *lux.Request, status int, err error) {
+				lux.JSON(w, status, "error handler")
+			})
+
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has a handler that panics
+		router.Handler("GET", func(w lux.ResponseWriter, r *lux.Request) {
+			w.WriteHeader(http.StatusOK)
+			panic("something went wrong")
+		})
+
+		// WHEN we perform the request that will panic
+		resp, _ := router.ServeHTTP(context.Background(), lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{HTTPMethod: "GET"},
+		})
+
+		// THEN the response should be what we expect
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+		assert.Equal(t, tc.ExpectedBody, resp.Body)
+
+		// AND the info-only recovery handler should still observe the panic
+		assert.True(t, observed)
+	}
+}
+
+func TestRouter_RecoversMiddleware(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Middleware         []lux.HandlerFunc
+		ExpectedStage      string
+		ExpectedMiddleware int
+	}{
+		// Scenario 1: First middleware panics
+		{
+			Middleware:         []lux.HandlerFunc{panicHandler, middleware},
+			ExpectedStage:      lux.StageMiddleware,
+			ExpectedMiddleware: 0,
+		},
+		// Scenario 2: Second middleware panics
+		{
+			Middleware:         []lux.HandlerFunc{middleware, panicHandler},
+			ExpectedStage:      lux.StageMiddleware,
+			ExpectedMiddleware: 1,
+		},
+		// Scenario 3: Function registered using After panics
+		{
+			Middleware: []lux.HandlerFunc{func(w lux.ResponseWriter, r *lux.Request) {
+				w.After(func() {
+					panic("uh oh")
+				})
+			}},
+			ExpectedStage:      lux.StageAfter,
+			ExpectedMiddleware: -1,
+		},
+	}
+
+	for _, tc := range tt {
+		var info lux.PanicInfo
+		var called bool
+
+		// GIVEN that we have a router with a recovery handler
+		router := lux.NewRouter().Recovery(func(i lux.PanicInfo) {
+			info = i
+		})
+
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has middleware that panics
+		router.Middleware(tc.Middleware...)
+
+		router.Handler("GET", func(w lux.ResponseWriter, r *lux.Request) {
+			called = true
+			getHandler(w, r)
+		})
+
+		// WHEN we perform the request
+		resp, _ := router.ServeHTTP(context.Background(), lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{HTTPMethod: "GET"},
+		})
+
+		// THEN the router should recover with a 500
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+
+		// AND the panic information should describe the stage that panicked
+		assert.Equal(t, "uh oh", info.Value)
+		assert.Equal(t, tc.ExpectedStage, info.Stage)
+		assert.Equal(t, tc.ExpectedMiddleware, info.Middleware)
+
+		// AND the handler should only be executed if the middleware did not panic
+		assert.Equal(t, tc.ExpectedStage == lux.StageAfter, called)
+	}
+}
+
+func TestRouter_LogsRequests(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Request        lux.Request
+		ExpectedFields map[string]interface{}
+	}{
+		// Scenario 1: Request handled by a route
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					HTTPMethod: "GET",
+					Path:       "/users/42",
+					RequestContext: events.APIGatewayProxyRequestContext{
+						RequestID: "test",
+					},
+				},
+			},
+			ExpectedFields: map[string]interface{}{
+				"method":    "GET",
+				"path":      "/users/42",
+				"route":     "/users/{id}",
+				"status":    float64(http.StatusOK),
+				"size":      float64(len("\"hello test\"\n")),
+				"requestId": "test",
+			},
+		},
+		// Scenario 2: Request that could not be routed
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					HTTPMethod: "DELETE",
+					Path:       "/users/42",
+					RequestContext: events.APIGatewayProxyRequestContext{
+						RequestID: "test",
+					},
+				},
+			},
+			ExpectedFields: map[string]interface{}{
+				"method":    "DELETE",
+				"path":      "/users/42",
+				"route":     "",
+				"status":    float64(http.StatusMethodNotAllowed),
+				"requestId": "test",
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		logs := bytes.NewBuffer([]byte{})
+
+		// GIVEN that we have a router
+		router := lux.NewRouter()
+		router.Logging(logs, &logrus.JSONFormatter{})
+
+		// AND that router has a handler registered
+		router.Handler("GET", getHandler).Path("/users/{id}")
+
+		// WHEN we perform the request
+		router.ServeHTTP(context.Background(), tc.Request)
+
+		// THEN the final log entry should describe the request
+		var entry map[string]interface{}
+
+		lines := bytes.Split(bytes.TrimSpace(logs.Bytes()), []byte("\n"))
+		assert.Nil(t, json.Unmarshal(lines[len(lines)-1], &entry))
+
+		assert.Equal(t, "finished handling request", entry["msg"])
+		assert.Contains(t, entry, "durationMs")
+
+		for key, value := range tc.ExpectedFields {
+			assert.Equal(t, value, entry[key])
+		}
+	}
+}
+
+func TestRouter_AllowedMethods(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Request        lux.Request
+		ExpectedStatus int
+		ExpectedAllow  string
+	}{
+		// Scenario 1: Method not allowed for the path
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					HTTPMethod: "DELETE",
+					Path:       "/users/42",
+				},
+			},
+			ExpectedStatus: http.StatusMethodNotAllowed,
+			ExpectedAllow:  "GET, HEAD, PUT, OPTIONS",
+		},
+		// Scenario 2: OPTIONS request for a path without an OPTIONS handler
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					HTTPMethod: "OPTIONS",
+					Path:       "/users/42",
+				},
+			},
+			ExpectedStatus: http.StatusNoContent,
+			ExpectedAllow:  "GET, HEAD, PUT, OPTIONS",
+		},
+		// Scenario 3: OPTIONS request for a path with an OPTIONS handler
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					HTTPMethod: "OPTIONS",
+					Path:       "/users",
+				},
+			},
+			ExpectedStatus: http.StatusOK,
+		},
+		// Scenario 4: Method allowed for the path
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					HTTPMethod: "GET",
+					Path:       "/users/42",
+				},
+			},
+			ExpectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router
+		router := lux.NewRouter()
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has handlers registered for multiple methods
+		router.Handler("GET", getHandler).Path("/users/{id}")
+		router.Handler("PUT", getHandler).Path("/users/{id}")
+		router.Handler("OPTIONS", getHandler).Path("/users")
+
+		// AND a method is registered more than once for a path
+		router.Handler("GET", getHandler).Path("/users/{id}").Queries("key", "value")
+
+		// WHEN we perform the request
+		resp, _ := router.ServeHTTP(context.Background(), tc.Request)
+
+		// THEN the status code & allowed methods should be what we expect
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+		assert.Equal(t, tc.ExpectedAllow, resp.Headers["Allow"])
+	}
+}
+
+func TestRouter_ErrorHandler(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Request        lux.Request
+		ExpectedStatus int
+		ExpectedBody   string
+	}{
+		// Scenario 1: Request does not match any path
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					HTTPMethod: "GET",
+					Path:       "/unknown",
+				},
+			},
+			ExpectedStatus: http.StatusNotFound,
+			ExpectedBody:   `{"error":"not found"}`,
+		},
+		// Scenario 2: Request does not match any method
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					HTTPMethod: "DELETE",
+					Path:       "/test",
+				},
+			},
+			ExpectedStatus: http.StatusMethodNotAllowed,
+			ExpectedBody:   `{"error":"not allowed"}`,
+		},
+		// Scenario 3: Request does not match the required headers
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					HTTPMethod: "GET",
+					Path:       "/test",
+				},
+			},
+			ExpectedStatus: http.StatusNotAcceptable,
+			ExpectedBody:   `{"error":"not acceptable"}`,
+		},
+		// Scenario 4: Handler panics
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					HTTPMethod: "GET",
+					Path:       "/panic",
+				},
+			},
+			ExpectedStatus: http.StatusInternalServerError,
+			ExpectedBody:   `{"error":"uh oh"}`,
+		},
+		// Scenario 5: Handler does not write a response
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					HTTPMethod: "GET",
+					Path:       "/empty",
+				},
+			},
+			ExpectedStatus: http.StatusInternalServerError,
+			ExpectedBody:   `{"error":"failed to obtain response"}`,
+		},
+		// Scenario 6: Middleware rejects the request
+		{
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					HTTPMethod: "GET",
+					Path:       "/private",
+				},
+			},
+			ExpectedStatus: http.StatusUnauthorized,
+			ExpectedBody:   `{"error":"unauthorized"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router with a custom error handler
+		router := lux.NewRouter().ErrorHandler(func(w lux.ResponseWriter, r *lux.Request, status int, err error) {
+			lux.JSON(w, status, map[string]string{"error": err.Error()})
+		})
+
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has handlers registered
+		router.Handler("GET", getHandler).Path("/test").Headers("x-api-key", "secret")
+		router.Handler("GET", panicHandler).Path("/panic")
+		router.Handler("GET", middleware).Path("/empty")
+		router.Handler("GET", getHandler).Path("/private").Middleware(lux.BasicAuth(func(user, pass string) bool {
+			return false
+		}))
+
+		// WHEN we perform the request
+		resp, _ := router.ServeHTTP(context.Background(), tc.Request)
+
+		// THEN the error should be rendered using the error handler
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+		assert.Equal(t, tc.ExpectedBody, resp.Body)
+		assert.Equal(t, "application/json", resp.Headers["Content-Type"])
+	}
+}
+
+func TestRouter_RoutingFailureHandlers(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Method         string
+		Path           string
+		ExpectedStatus int
+		ExpectedBody   string
+		ExpectedAllow  string
+	}{
+		// Scenario 1: Request path does not match any routes
+		{
+			Method:         "GET",
+			Path:           "/missing",
+			ExpectedStatus: http.StatusNotFound,
+			ExpectedBody:   `{"message":"no such page"}`,
+		},
+		// Scenario 2: Request method does not match the route
+		{
+			Method:         "DELETE",
+			Path:           "/test",
+			ExpectedStatus: http.StatusMethodNotAllowed,
+			ExpectedBody:   `{"message":"try GET, HEAD, OPTIONS"}`,
+			ExpectedAllow:  "GET, HEAD, OPTIONS",
+		},
+		// Scenario 3: OPTIONS request for a path without an OPTIONS handler
+		{
+			Method:         "OPTIONS",
+			Path:           "/test",
+			ExpectedStatus: http.StatusNoContent,
+			ExpectedAllow:  "GET, HEAD, OPTIONS",
+		},
+		// Scenario 4: Request matches a route
+		{
+			Method:         "GET",
+			Path:           "/test",
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   "\"hello test\"\n",
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router with custom not found & method not allowed handlers
+		router := lux.NewRouter().
+			NotFound(func(w lux.ResponseWriter, r *lux.Request) {
+				lux.JSON(w, http.StatusNotFound, map[string]string{"message": "no such page"})
+			}).
+			MethodNotAllowed(func(w lux.ResponseWriter, r *lux.Request) {
+				lux.JSON(w, http.StatusMethodNotAllowed, map[string]string{"message": "try " + w.Header().Get("Allow")})
+			}).
+			ErrorHandler(func(w lux.ResponseWriter, r *lux.Request, status int, err error) {
+				lux.Text(w, status, "error handler")
+			})
+
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has global middleware
+		router.Middleware(func(w lux.ResponseWriter, r *lux.Request) {
+			w.Header().Set("X-Middleware", "true")
+		})
+
+		// AND that router has a handler registered
+		router.Handler("GET", getHandler).Path("/test")
+
+		// WHEN we perform the request
+		resp, _ := router.ServeHTTP(context.Background(), lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+				HTTPMethod: tc.Method,
+				Path:       tc.Path,
+			},
+		})
+
+		// THEN the response should be what we expect
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+		assert.Equal(t, tc.ExpectedBody, resp.Body)
+		assert.Equal(t, tc.ExpectedAllow, resp.Headers["Allow"])
+
+		// AND the global middleware should have been executed
+		assert.Equal(t, "true", resp.Headers["X-Middleware"])
+	}
+}
+
+func TestRouter_EmptyRequest(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Setup          func(router *lux.Router)
+		ExpectedStatus int
+	}{
+		// Scenario 1: Router has no routes
+		{
+			Setup:          func(router *lux.Router) {},
+			ExpectedStatus: http.StatusMethodNotAllowed,
+		},
+		// Scenario 2: Router has a route without a path
+		{
+			Setup: func(router *lux.Router) {
+				router.Handler("GET", getHandler)
+			},
+			ExpectedStatus: http.StatusMethodNotAllowed,
+		},
+		// Scenario 3: Router has a route with a path
+		{
+			Setup: func(router *lux.Router) {
+				router.Handler("GET", getHandler).Path("/users/{id}")
+			},
+			ExpectedStatus: http.StatusNotFound,
+		},
+		// Scenario 4: Router has a route for an empty method with matchers
+		{
+			Setup: func(router *lux.Router) {
+				router.Handler("", getHandler).
+					Headers("Content-Type", "application/json").
+					Queries("key", "value").
+					HeaderFunc("X-Test", func(string) bool { return true })
+			},
+			ExpectedStatus: http.StatusUnsupportedMediaType,
+		},
+		// Scenario 5: Router has a route for an empty method that writes to the request maps
+		{
+			Setup: func(router *lux.Router) {
+				router.Handler("", func(w lux.ResponseWriter, r *lux.Request) {
+					r.Headers["X-Test"] = "test"
+					r.QueryStringParameters["key"] = "value"
+					r.PathParameters["id"] = "1"
+
+					w.WriteHeader(http.StatusOK)
+				})
+			},
+			ExpectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router
+		router := lux.NewRouter()
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has been configured
+		tc.Setup(router)
+
+		// WHEN we perform a completely empty request
+		resp, err := router.ServeHTTP(context.Background(), lux.Request{})
+
+		// THEN the request should be handled without panicking
+		assert.Nil(t, err)
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+	}
+}
+
+func BenchmarkRouter_ServeHTTP(b *testing.B) {
+	// GIVEN that we have a router
+	router := lux.NewRouter().Logger(lux.NopLogger())
+
+	// AND that router has a handler with a path
+	router.Handler("GET", getHandler).Path("/users/{id}")
+
+	req := lux.Request{
+		APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+			HTTPMethod: "GET",
+			Path:       "/users/42",
+			Headers:    map[string]string{"Accept": "application/json"},
+		},
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	// WHEN we perform requests
+	for i := 0; i < b.N; i++ {
+		router.ServeHTTP(context.Background(), req)
+	}
+}
+
+func getHandler(w lux.ResponseWriter, r *lux.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	encoder := json.NewEncoder(w)
+	encoder.Encode("hello test")
+
+}
+
+func recoverHandler(info lux.PanicInfo) {
+	// Do nothing
+}
+
+func panicHandler(w lux.ResponseWriter, r *lux.Request) {
+	panic("uh oh")
+}
+
+func errorMiddleware(w lux.ResponseWriter, r *lux.Request) {
+	w.WriteHeader(http.StatusInternalServerError)
+	w.Write([]byte("\"error\""))
+}
+
+func abortMiddleware(w lux.ResponseWriter, r *lux.Request) {
+	w.Abort()
 }
 
 func middleware(w lux.ResponseWriter, r *lux.Request) {
@@ -0,0 +1,126 @@
+package lux
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/xeipuuv/gojsonschema"
+)
+
+type (
+	// The SchemaError type describes a part of the request body that failed validation
+	// against a JSON schema.
+	SchemaError struct {
+		// Location contains the JSON pointer to the invalid value within the body, such
+		// as "/name". The body itself has an empty location.
+		Location string `json:"location"`
+
+		// Message describes why the value is invalid.
+		Message string `json:"message"`
+	}
+
+	// The SchemaErrors type is the error written when a request body fails validation
+	// against the JSON schema of its route. It contains an entry for each invalid value.
+	SchemaErrors []SchemaError
+
+	bodySchema struct {
+		raw    string
+		schema *gojsonschema.Schema
+		err    error
+	}
+)
+
+// Schema validates the JSON body of requests handled by the route against the given JSON
+// schema before the handler is executed. The schema is compiled once, when Schema is called.
+// Bodies that fail validation result in a 400 response containing the SchemaErrors, which is
+// passed to the router's error handler if one has been provided. Bodies that cannot be read
+// result in the same responses as Request.MustBind. If the schema cannot be compiled, the
+// error is reported by Router.Err & requests handled by the route result in a 500 response.
+func (r *Route) Schema(schema string) *Route {
+	compiled, err := gojsonschema.NewSchema(gojsonschema.NewStringLoader(schema))
+
+	r.schema = &bodySchema{raw: schema, schema: compiled, err: err}
+
+	return r
+}
+
+// validate validates the request body against the schema, writing an error response &
+// returning false if it is invalid.
+func (s *bodySchema) validate(w ResponseWriter, req *Request) bool {
+	if s.err != nil {
+		req.router.writeError(w, req, http.StatusInternalServerError, fmt.Errorf("failed to compile schema, %v", s.err))
+		return false
+	}
+
+	var body interface{}
+
+	if err := req.bind(&body, unmarshalNumbers); err != nil {
+		req.router.writeError(w, req, bindStatus(err), err)
+		return false
+	}
+
+	result, err := s.schema.Validate(gojsonschema.NewGoLoader(body))
+
+	if err != nil {
+		req.router.writeError(w, req, http.StatusBadRequest, err)
+		return false
+	}
+
+	if result.Valid() {
+		return true
+	}
+
+	errs := schemaErrors(result.Errors())
+
+	// Sort the errors so responses are consistent between requests
+	sort.SliceStable(errs, func(i, j int) bool {
+		return errs[i].Location < errs[j].Location
+	})
+
+	if req.router.errorHandler != nil {
+		req.router.errorHandler(w, req, http.StatusBadRequest, errs)
+	} else {
+		JSON(w, http.StatusBadRequest, errs)
+	}
+
+	return false
+}
+
+// unmarshalNumbers decodes the given JSON data into the value pointed to by v, retaining
+// numbers as json.Number values as required for schema validation.
+func unmarshalNumbers(data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	return dec.Decode(v)
+}
+
+// schemaErrors converts the given validation results into the errors for each invalid value,
+// locating each value using a JSON pointer rather than the validator's "(root).name" form.
+func schemaErrors(results []gojsonschema.ResultError) SchemaErrors {
+	errs := make(SchemaErrors, len(results))
+
+	for i, result := range results {
+		errs[i] = SchemaError{
+			Location: strings.TrimPrefix(result.Context().String("/"), gojsonschema.STRING_CONTEXT_ROOT),
+			Message:  result.Description(),
+		}
+	}
+
+	return errs
+}
+
+// Error returns a description of each value that failed validation.
+func (e SchemaErrors) Error() string {
+	values := make([]string, len(e))
+
+	for i, err := range e {
+		values[i] = fmt.Sprintf("%s (%s)", err.Location, err.Message)
+	}
+
+	return "schema validation failed for " + strings.Join(values, ", ")
+}
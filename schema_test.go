@@ -0,0 +1,108 @@
+package lux_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/davidsbond/lux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+const userSchema = `{
+	"type": "object",
+	"properties": {
+		"name": {"type": "string", "minLength": 1},
+		"age": {"type": "integer", "minimum": 0}
+	},
+	"required": ["name"]
+}`
+
+func TestRoute_Schema(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Schema         string
+		Headers        map[string]string
+		Body           string
+		ExpectedStatus int
+		ExpectedBody   string
+		ExpectedErr    bool
+	}{
+		// Scenario 1: Body matches the schema
+		{
+			Schema:         userSchema,
+			Body:           `{"name":"test","age":42}`,
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   "\"hello test\"\n",
+		},
+		// Scenario 2: Body is missing a required property
+		{
+			Schema:         userSchema,
+			Body:           `{"age":42}`,
+			ExpectedStatus: http.StatusBadRequest,
+			ExpectedBody:   `[{"location":"","message":"name is required"}]`,
+		},
+		// Scenario 3: Body has multiple invalid properties
+		{
+			Schema:         userSchema,
+			Body:           `{"name":"","age":1.5}`,
+			ExpectedStatus: http.StatusBadRequest,
+			ExpectedBody:   `[{"location":"/age","message":"Invalid type. Expected: integer, given: number"},{"location":"/name","message":"String length must be greater than or equal to 1"}]`,
+		},
+		// Scenario 4: Body is not valid JSON
+		{
+			Schema:         userSchema,
+			Body:           `{"name":`,
+			ExpectedStatus: http.StatusBadRequest,
+			ExpectedBody:   `"failed to decode request body, unexpected EOF"`,
+		},
+		// Scenario 5: Body is not JSON
+		{
+			Schema:         userSchema,
+			Headers:        map[string]string{"Content-Type": "text/plain"},
+			Body:           "name",
+			ExpectedStatus: http.StatusUnsupportedMediaType,
+			ExpectedBody:   `"content type is not json"`,
+		},
+		// Scenario 6: Schema cannot be compiled
+		{
+			Schema:         `{"type": 1}`,
+			Body:           `{"name":"test"}`,
+			ExpectedStatus: http.StatusInternalServerError,
+			ExpectedErr:    true,
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router
+		router := lux.NewRouter()
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has a handler with a schema
+		router.Handler("POST", getHandler).Path("/users").Schema(tc.Schema)
+
+		// WHEN we perform a request
+		resp, _ := router.ServeHTTP(context.Background(), lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+				HTTPMethod: "POST",
+				Path:       "/users",
+				Headers:    tc.Headers,
+				Body:       tc.Body,
+			},
+		})
+
+		// THEN the response should be what we expect
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+
+		if tc.ExpectedBody != "" {
+			assert.Equal(t, tc.ExpectedBody, resp.Body)
+		}
+
+		// AND invalid schemas should be reported by the router
+		assert.Equal(t, tc.ExpectedErr, router.Err() != nil)
+	}
+}
@@ -0,0 +1,157 @@
+package lux
+
+import (
+	"errors"
+	"net/http"
+	"net/textproto"
+	"strconv"
+	"time"
+)
+
+type (
+	// The SecureHeadersOptions type contains configuration for the SecureHeaders
+	// middleware. The zero value provides sensible defaults for public APIs.
+	SecureHeadersOptions struct {
+		// HSTSMaxAge determines how long clients should only access the API using
+		// HTTPS. Defaults to one year.
+		HSTSMaxAge time.Duration
+
+		// HSTSIncludeSubdomains determines whether the Strict-Transport-Security
+		// header also applies to subdomains.
+		HSTSIncludeSubdomains bool
+
+		// HSTSPreload determines whether the Strict-Transport-Security header allows
+		// the domain to be included in browser preload lists.
+		HSTSPreload bool
+
+		// FrameOptions contains the value of the X-Frame-Options header. Defaults to
+		// "DENY".
+		FrameOptions string
+
+		// ContentSecurityPolicy contains the value of the Content-Security-Policy
+		// header. Defaults to "default-src 'none'; frame-ancestors 'none'".
+		ContentSecurityPolicy string
+
+		// ReferrerPolicy contains the value of the Referrer-Policy header. Defaults to
+		// "no-referrer".
+		ReferrerPolicy string
+
+		// Disable contains the names of any headers that should not be set.
+		Disable []string
+
+		// Override determines whether the headers replace values set by subsequent
+		// middleware & the handler. By default, values they set are kept.
+		Override bool
+	}
+
+	// The HTTPSOptions type contains configuration for the RequireHTTPS middleware.
+	HTTPSOptions struct {
+		// Redirect determines whether insecure requests are redirected to the same URL
+		// using HTTPS. By default, a 403 response is returned.
+		Redirect bool
+
+		// Host contains the host insecure requests are redirected to. Defaults to the
+		// Host header of the request.
+		Host string
+	}
+)
+
+var errInsecureRequest = errors.New("https is required")
+
+// SecureHeaders creates a middleware function that sets security headers on responses,
+// these are Strict-Transport-Security, X-Content-Type-Options, X-Frame-Options,
+// Content-Security-Policy and Referrer-Policy. The headers are set once the handler has
+// finished, so values set by subsequent middleware & the handler are not replaced unless
+// the Override option is set.
+func SecureHeaders(opts SecureHeadersOptions) HandlerFunc {
+	headers := opts.headers()
+
+	return func(w ResponseWriter, r *Request) {
+		w.After(func() {
+			h := w.Header()
+
+			for key, value := range headers {
+				if opts.Override || h.Get(key) == "" {
+					h.Set(key, value)
+				}
+			}
+		})
+	}
+}
+
+// RequireHTTPS creates a middleware function that rejects requests that were not made using
+// HTTPS, as determined by Request.Scheme, with a 403 response. While API Gateway only accepts
+// HTTPS requests, load balancers & other proxies may accept plain HTTP. When the Redirect
+// option is set, GET & HEAD requests are instead redirected to the same URL using HTTPS with a
+// 301 status code, and other methods use a 308 so that clients repeat the request with the
+// same method & body, keeping the stage of REST API requests in the path. Requests without a
+// host to redirect to are rejected.
+func RequireHTTPS(opts HTTPSOptions) HandlerFunc {
+	return func(w ResponseWriter, r *Request) {
+		if r.Scheme() == "https" {
+			return
+		}
+
+		host := valueOrDefault(opts.Host, r.header("Host"))
+
+		if !opts.Redirect || host == "" {
+			r.router.writeError(w, r, http.StatusForbidden, errInsecureRequest)
+			return
+		}
+
+		location := "https://" + host + externalPath(*r, r.Path)
+
+		if query := requestQuery(*r).Encode(); query != "" {
+			location += "?" + query
+		}
+
+		status := http.StatusPermanentRedirect
+
+		if r.HTTPMethod == http.MethodGet || r.HTTPMethod == http.MethodHead {
+			status = http.StatusMovedPermanently
+		}
+
+		w.Header().Set("Location", location)
+		w.WriteHeader(status)
+	}
+}
+
+// headers returns the security headers described by the options.
+func (opts SecureHeadersOptions) headers() map[string]string {
+	if opts.HSTSMaxAge <= 0 {
+		opts.HSTSMaxAge = 365 * 24 * time.Hour
+	}
+
+	hsts := "max-age=" + strconv.FormatInt(int64(opts.HSTSMaxAge/time.Second), 10)
+
+	if opts.HSTSIncludeSubdomains {
+		hsts += "; includeSubDomains"
+	}
+
+	if opts.HSTSPreload {
+		hsts += "; preload"
+	}
+
+	headers := map[string]string{
+		"Strict-Transport-Security": hsts,
+		"X-Content-Type-Options":    "nosniff",
+		"X-Frame-Options":           valueOrDefault(opts.FrameOptions, "DENY"),
+		"Content-Security-Policy":   valueOrDefault(opts.ContentSecurityPolicy, "default-src 'none'; frame-ancestors 'none'"),
+		"Referrer-Policy":           valueOrDefault(opts.ReferrerPolicy, "no-referrer"),
+	}
+
+	for _, name := range opts.Disable {
+		delete(headers, textproto.CanonicalMIMEHeaderKey(name))
+	}
+
+	return headers
+}
+
+// valueOrDefault returns the given value, or the default if it is empty.
+func valueOrDefault(value, def string) string {
+	if value == "" {
+		return def
+	}
+
+	return value
+}
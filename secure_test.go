@@ -0,0 +1,174 @@
+package lux_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/davidsbond/lux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecureHeaders(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Options         lux.SecureHeadersOptions
+		ExpectedHeaders map[string]string
+	}{
+		// Scenario 1: Default options
+		{
+			ExpectedHeaders: map[string]string{
+				"Strict-Transport-Security": "max-age=31536000",
+				"X-Content-Type-Options":    "nosniff",
+				"X-Frame-Options":           "DENY",
+				"Content-Security-Policy":   "default-src 'none'; frame-ancestors 'none'",
+				"Referrer-Policy":           "same-origin",
+			},
+		},
+		// Scenario 2: Custom options with disabled headers
+		{
+			Options: lux.SecureHeadersOptions{
+				HSTSMaxAge:            time.Hour,
+				HSTSIncludeSubdomains: true,
+				HSTSPreload:           true,
+				FrameOptions:          "SAMEORIGIN",
+				Disable:               []string{"content-security-policy"},
+			},
+			ExpectedHeaders: map[string]string{
+				"Strict-Transport-Security": "max-age=3600; includeSubDomains; preload",
+				"X-Content-Type-Options":    "nosniff",
+				"X-Frame-Options":           "SAMEORIGIN",
+				"Content-Security-Policy":   "",
+				"Referrer-Policy":           "same-origin",
+			},
+		},
+		// Scenario 3: Headers override values set by the handler
+		{
+			Options: lux.SecureHeadersOptions{Override: true},
+			ExpectedHeaders: map[string]string{
+				"Strict-Transport-Security": "max-age=31536000",
+				"X-Content-Type-Options":    "nosniff",
+				"X-Frame-Options":           "DENY",
+				"Content-Security-Policy":   "default-src 'none'; frame-ancestors 'none'",
+				"Referrer-Policy":           "no-referrer",
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router using the security headers middleware
+		router := lux.NewRouter().Middleware(lux.SecureHeaders(tc.Options))
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has a handler that sets one of the headers
+		router.Handler("GET", func(w lux.ResponseWriter, r *lux.Request) {
+			w.Header().Set("Referrer-Policy", "same-origin")
+			w.WriteHeader(http.StatusOK)
+		})
+
+		// WHEN we perform a request
+		resp, _ := router.ServeHTTP(context.Background(), lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{HTTPMethod: "GET"},
+		})
+
+		// THEN the response should contain the expected headers
+		for key, value := range tc.ExpectedHeaders {
+			assert.Equal(t, value, resp.Headers[key], key)
+		}
+	}
+}
+
+func TestRequireHTTPS(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Options          lux.HTTPSOptions
+		Method           string
+		Headers          map[string]string
+		Query            map[string]string
+		ContextPath      string
+		ExpectedStatus   int
+		ExpectedLocation string
+	}{
+		// Scenario 1: HTTPS request is handled
+		{
+			Method:         "GET",
+			Headers:        map[string]string{"X-Forwarded-Proto": "https"},
+			ExpectedStatus: http.StatusOK,
+		},
+		// Scenario 2: Request without a forwarded scheme is handled
+		{
+			Method:         "GET",
+			ExpectedStatus: http.StatusOK,
+		},
+		// Scenario 3: HTTP request is rejected
+		{
+			Method:         "GET",
+			Headers:        map[string]string{"X-Forwarded-Proto": "http", "Host": "example.com"},
+			ExpectedStatus: http.StatusForbidden,
+		},
+		// Scenario 4: HTTP GET request is redirected
+		{
+			Options:          lux.HTTPSOptions{Redirect: true},
+			Method:           "GET",
+			Headers:          map[string]string{"X-Forwarded-Proto": "http", "Host": "example.com"},
+			Query:            map[string]string{"page": "2"},
+			ExpectedStatus:   http.StatusMovedPermanently,
+			ExpectedLocation: "https://example.com/users?page=2",
+		},
+		// Scenario 5: HTTP POST request is redirected with the same method
+		{
+			Options:          lux.HTTPSOptions{Redirect: true, Host: "api.example.com"},
+			Method:           "POST",
+			Headers:          map[string]string{"X-Forwarded-Proto": "http", "Host": "example.com"},
+			ExpectedStatus:   http.StatusPermanentRedirect,
+			ExpectedLocation: "https://api.example.com/users",
+		},
+		// Scenario 6: HTTP request without a host is rejected
+		{
+			Options:        lux.HTTPSOptions{Redirect: true},
+			Method:         "GET",
+			Headers:        map[string]string{"X-Forwarded-Proto": "http"},
+			ExpectedStatus: http.StatusForbidden,
+		},
+		// Scenario 7: HTTP request to a REST API stage is redirected to the same stage
+		{
+			Options:          lux.HTTPSOptions{Redirect: true},
+			Method:           "GET",
+			Headers:          map[string]string{"X-Forwarded-Proto": "http", "Host": "example.com"},
+			ContextPath:      "/prod/users",
+			ExpectedStatus:   http.StatusMovedPermanently,
+			ExpectedLocation: "https://example.com/prod/users",
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router that requires HTTPS
+		router := lux.NewRouter().Middleware(lux.RequireHTTPS(tc.Options))
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has handlers
+		router.Handler("GET", getHandler).Path("/users")
+		router.Handler("POST", getHandler).Path("/users")
+
+		// WHEN we perform the request
+		resp, _ := router.ServeHTTP(context.Background(), lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+				HTTPMethod:            tc.Method,
+				Path:                  "/users",
+				Headers:               tc.Headers,
+				QueryStringParameters: tc.Query,
+				RequestContext:        events.APIGatewayProxyRequestContext{Path: tc.ContextPath},
+			},
+		})
+
+		// THEN the response should be what we expect
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+		assert.Equal(t, tc.ExpectedLocation, resp.Headers["Location"])
+	}
+}
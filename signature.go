@@ -0,0 +1,223 @@
+package lux
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	// The SignatureOptions type contains configuration for the signature verification
+	// middleware. Options for common webhook providers can be created using GitHubSignature,
+	// StripeSignature, SlackSignature & ShopifySignature.
+	SignatureOptions struct {
+		// Header contains the name of the request header containing the signature.
+		Header string
+
+		// Secret contains the key used to compute the HMAC of the request. All requests
+		// are rejected if it is empty, so that a missing secret cannot be exploited.
+		Secret []byte
+
+		// Hash creates the hash function used to compute the HMAC. Defaults to SHA-256.
+		Hash func() hash.Hash
+
+		// Prefix contains a prefix of the signature that is removed before comparing it, such
+		// as "sha256=".
+		Prefix string
+
+		// Base64 determines whether the signature is base64 encoded. By default, signatures
+		// are hex encoded.
+		Base64 bool
+
+		// Payload returns the signed payload for the request, given its body. An error
+		// results in a 401 response. Defaults to the body.
+		Payload func(r *Request, body []byte) ([]byte, error)
+
+		// Signatures returns the signatures contained in the header, any of which can match.
+		// Defaults to the whole header once the prefix is removed.
+		Signatures func(header string) []string
+	}
+)
+
+// signatureTolerance is how old the timestamp of a signed webhook request can be before it
+// is rejected, preventing replay attacks.
+const signatureTolerance = 5 * time.Minute
+
+var errInvalidTimestamp = errors.New("invalid signature timestamp")
+
+// VerifySignature creates a middleware function that verifies requests are signed using a
+// shared secret, such as webhooks sent by GitHub or Stripe. The HMAC of the request body, once
+// decoded from base64 if required, is compared in constant time with the signature in the
+// configured header. If the header is missing or the signature does not match, a 401 response
+// is returned, preventing execution of any further middleware & the handler.
+func VerifySignature(opts SignatureOptions) HandlerFunc {
+	if opts.Hash == nil {
+		opts.Hash = sha256.New
+	}
+
+	if opts.Payload == nil {
+		opts.Payload = func(r *Request, body []byte) ([]byte, error) {
+			return body, nil
+		}
+	}
+
+	if opts.Signatures == nil {
+		opts.Signatures = func(header string) []string {
+			return []string{header}
+		}
+	}
+
+	return func(w ResponseWriter, r *Request) {
+		if !opts.verify(r) {
+			r.router.writeError(w, r, http.StatusUnauthorized, errInvalidSignature)
+		}
+	}
+}
+
+// verify determines if the request has a valid signature.
+func (opts SignatureOptions) verify(r *Request) bool {
+	header := r.header(opts.Header)
+
+	if header == "" || len(opts.Secret) == 0 {
+		return false
+	}
+
+	body, err := r.body()
+
+	if err != nil {
+		return false
+	}
+
+	payload, err := opts.Payload(r, body)
+
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(opts.Hash, opts.Secret)
+	mac.Write(payload)
+	expected := mac.Sum(nil)
+
+	for _, signature := range opts.Signatures(header) {
+		if decoded, ok := opts.decode(signature); ok && hmac.Equal(decoded, expected) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// decode removes the prefix from the given signature and decodes it.
+func (opts SignatureOptions) decode(signature string) ([]byte, bool) {
+	signature = strings.TrimSpace(signature)
+
+	if !strings.HasPrefix(signature, opts.Prefix) {
+		return nil, false
+	}
+
+	signature = strings.TrimPrefix(signature, opts.Prefix)
+
+	var out []byte
+	var err error
+
+	if opts.Base64 {
+		out, err = base64.StdEncoding.DecodeString(signature)
+	} else {
+		out, err = hex.DecodeString(signature)
+	}
+
+	return out, err == nil
+}
+
+// GitHubSignature returns the options for verifying webhooks sent by GitHub, which are signed
+// using HMAC-SHA256 in the X-Hub-Signature-256 header.
+func GitHubSignature(secret string) SignatureOptions {
+	return SignatureOptions{
+		Header: "X-Hub-Signature-256",
+		Secret: []byte(secret),
+		Prefix: "sha256=",
+	}
+}
+
+// StripeSignature returns the options for verifying webhooks sent by Stripe, which are signed
+// using HMAC-SHA256 of the timestamp & body in the Stripe-Signature header. Requests whose
+// timestamp is more than five minutes old are rejected.
+func StripeSignature(secret string) SignatureOptions {
+	return SignatureOptions{
+		Header: "Stripe-Signature",
+		Secret: []byte(secret),
+		Payload: func(r *Request, body []byte) ([]byte, error) {
+			ts := signatureFields(r.header("Stripe-Signature"), "t")
+
+			if len(ts) == 0 {
+				return nil, errInvalidTimestamp
+			}
+
+			return timestampedPayload(ts[0], ts[0]+".", body)
+		},
+		Signatures: func(header string) []string {
+			return signatureFields(header, "v1")
+		},
+	}
+}
+
+// SlackSignature returns the options for verifying requests sent by Slack, which are signed
+// using HMAC-SHA256 of the timestamp in the X-Slack-Request-Timestamp header & body in the
+// X-Slack-Signature header. Requests whose timestamp is more than five minutes old are
+// rejected.
+func SlackSignature(secret string) SignatureOptions {
+	return SignatureOptions{
+		Header: "X-Slack-Signature",
+		Secret: []byte(secret),
+		Prefix: "v0=",
+		Payload: func(r *Request, body []byte) ([]byte, error) {
+			ts := r.header("X-Slack-Request-Timestamp")
+
+			return timestampedPayload(ts, "v0:"+ts+":", body)
+		},
+	}
+}
+
+// ShopifySignature returns the options for verifying webhooks sent by Shopify, which are
+// signed using a base64 encoded HMAC-SHA256 in the X-Shopify-Hmac-Sha256 header.
+func ShopifySignature(secret string) SignatureOptions {
+	return SignatureOptions{
+		Header: "X-Shopify-Hmac-Sha256",
+		Secret: []byte(secret),
+		Base64: true,
+	}
+}
+
+// signatureFields returns the values of the given key in a comma separated list of key value
+// pairs, such as "t=123,v1=abc".
+func signatureFields(header, key string) []string {
+	var out []string
+
+	for _, pair := range strings.Split(header, ",") {
+		if kv := strings.SplitN(strings.TrimSpace(pair), "=", 2); len(kv) == 2 && kv[0] == key {
+			out = append(out, kv[1])
+		}
+	}
+
+	return out
+}
+
+// timestampedPayload returns the payload made up of the given prefix & body, if the given unix
+// timestamp is within the tolerance of the current time.
+func timestampedPayload(ts, prefix string, body []byte) ([]byte, error) {
+	seconds, err := strconv.ParseInt(ts, 10, 64)
+
+	if err != nil || math.Abs(time.Since(time.Unix(seconds, 0)).Seconds()) > signatureTolerance.Seconds() {
+		return nil, errInvalidTimestamp
+	}
+
+	return append([]byte(prefix), body...), nil
+}
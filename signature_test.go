@@ -0,0 +1,159 @@
+package lux_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"hash"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/davidsbond/lux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifySignature(t *testing.T) {
+	t.Parallel()
+
+	body := `{"action": "opened"}`
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	old := strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10)
+
+	tt := []struct {
+		Options        lux.SignatureOptions
+		Headers        map[string]string
+		Base64         bool
+		ExpectedStatus int
+	}{
+		// Scenario 1: Request with a valid hex signature
+		{
+			Options:        lux.SignatureOptions{Header: "X-Signature", Secret: []byte("secret")},
+			Headers:        map[string]string{"x-signature": sign(sha256.New, "secret", body, false)},
+			ExpectedStatus: http.StatusOK,
+		},
+		// Scenario 2: Request with an invalid signature
+		{
+			Options:        lux.SignatureOptions{Header: "X-Signature", Secret: []byte("secret")},
+			Headers:        map[string]string{"X-Signature": sign(sha256.New, "other", body, false)},
+			ExpectedStatus: http.StatusUnauthorized,
+		},
+		// Scenario 3: Request without a signature
+		{
+			Options:        lux.SignatureOptions{Header: "X-Signature", Secret: []byte("secret")},
+			ExpectedStatus: http.StatusUnauthorized,
+		},
+		// Scenario 4: Middleware without a secret
+		{
+			Options:        lux.SignatureOptions{Header: "X-Signature"},
+			Headers:        map[string]string{"X-Signature": sign(sha256.New, "", body, false)},
+			ExpectedStatus: http.StatusUnauthorized,
+		},
+		// Scenario 5: Request with a base64 encoded body
+		{
+			Options:        lux.SignatureOptions{Header: "X-Signature", Secret: []byte("secret")},
+			Headers:        map[string]string{"X-Signature": sign(sha256.New, "secret", body, false)},
+			Base64:         true,
+			ExpectedStatus: http.StatusOK,
+		},
+		// Scenario 6: Request signed using a custom hash
+		{
+			Options:        lux.SignatureOptions{Header: "X-Signature", Secret: []byte("secret"), Hash: sha1.New},
+			Headers:        map[string]string{"X-Signature": sign(sha1.New, "secret", body, false)},
+			ExpectedStatus: http.StatusOK,
+		},
+		// Scenario 7: GitHub webhook
+		{
+			Options:        lux.GitHubSignature("secret"),
+			Headers:        map[string]string{"X-Hub-Signature-256": "sha256=" + sign(sha256.New, "secret", body, false)},
+			ExpectedStatus: http.StatusOK,
+		},
+		// Scenario 8: GitHub webhook without the signature prefix
+		{
+			Options:        lux.GitHubSignature("secret"),
+			Headers:        map[string]string{"X-Hub-Signature-256": sign(sha256.New, "secret", body, false)},
+			ExpectedStatus: http.StatusUnauthorized,
+		},
+		// Scenario 9: Stripe webhook with multiple signatures
+		{
+			Options: lux.StripeSignature("secret"),
+			Headers: map[string]string{
+				"Stripe-Signature": "t=" + now + ",v1=" + sign(sha256.New, "old", now+"."+body, false) + ",v1=" + sign(sha256.New, "secret", now+"."+body, false),
+			},
+			ExpectedStatus: http.StatusOK,
+		},
+		// Scenario 10: Stripe webhook with an expired timestamp
+		{
+			Options:        lux.StripeSignature("secret"),
+			Headers:        map[string]string{"Stripe-Signature": "t=" + old + ",v1=" + sign(sha256.New, "secret", old+"."+body, false)},
+			ExpectedStatus: http.StatusUnauthorized,
+		},
+		// Scenario 11: Slack request
+		{
+			Options: lux.SlackSignature("secret"),
+			Headers: map[string]string{
+				"X-Slack-Request-Timestamp": now,
+				"X-Slack-Signature":         "v0=" + sign(sha256.New, "secret", "v0:"+now+":"+body, false),
+			},
+			ExpectedStatus: http.StatusOK,
+		},
+		// Scenario 12: Slack request without a timestamp
+		{
+			Options:        lux.SlackSignature("secret"),
+			Headers:        map[string]string{"X-Slack-Signature": "v0=" + sign(sha256.New, "secret", "v0::"+body, false)},
+			ExpectedStatus: http.StatusUnauthorized,
+		},
+		// Scenario 13: Shopify webhook
+		{
+			Options:        lux.ShopifySignature("secret"),
+			Headers:        map[string]string{"X-Shopify-Hmac-Sha256": sign(sha256.New, "secret", body, true)},
+			ExpectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router that verifies signatures
+		router := lux.NewRouter().Middleware(lux.VerifySignature(tc.Options))
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has a handler
+		router.Handler("POST", getHandler)
+
+		req := lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+				HTTPMethod: "POST",
+				Headers:    tc.Headers,
+				Body:       body,
+			},
+		}
+
+		if tc.Base64 {
+			req.Body, req.IsBase64Encoded = base64.StdEncoding.EncodeToString([]byte(body)), true
+		}
+
+		// WHEN we perform the request
+		resp, _ := router.ServeHTTP(context.Background(), req)
+
+		// THEN the response should be what we expect
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+	}
+}
+
+// sign returns the encoded HMAC of the payload using the given hash & secret.
+func sign(fn func() hash.Hash, secret, payload string, b64 bool) string {
+	mac := hmac.New(fn, []byte(secret))
+	mac.Write([]byte(payload))
+
+	if b64 {
+		return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	}
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,88 @@
+package lux
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// StrictSlash determines how requests whose path only differs from a route by a trailing
+// slash are handled. By default, such requests are redirected to the path of the route, so
+// a request to "/users/" is redirected to "/users" and vice versa. GET & HEAD requests are
+// redirected with a 301 status code, other methods use a 308 so that clients repeat the
+// request with the same method & body. Redirects keep the stage of REST API requests in the
+// path. When strict is true, paths with & without a trailing slash are treated as distinct,
+// and requests for a path without a route result in a 404.
+func (r *Router) StrictSlash(strict bool) *Router {
+	r.strictSlash = strict
+
+	return r
+}
+
+// slashRedirect returns a route that redirects the request to the same path with the trailing
+// slash added or removed, if a route would handle the request at that path. Otherwise, nil is
+// returned.
+func (r *Router) slashRedirect(req Request) *Route {
+	if r.strictSlash || req.Path == "/" || req.Path == "" {
+		return nil
+	}
+
+	alt := req
+	alt.Path = req.Path + "/"
+
+	if strings.HasSuffix(req.Path, "/") {
+		alt.Path = strings.TrimSuffix(req.Path, "/")
+	}
+
+	if _, err := r.findRoute(alt); err != nil {
+		return nil
+	}
+
+	location := externalPath(req, alt.Path)
+
+	if query := requestQuery(req).Encode(); query != "" {
+		location += "?" + query
+	}
+
+	status := http.StatusPermanentRedirect
+
+	if req.HTTPMethod == http.MethodGet || req.HTTPMethod == http.MethodHead {
+		status = http.StatusMovedPermanently
+	}
+
+	return newFallbackRoute(req.HTTPMethod, func(w ResponseWriter, r *Request) {
+		w.Header().Set("Location", location)
+		w.WriteHeader(status)
+	})
+}
+
+// externalPath returns the given path as it is seen by the client of the request. API Gateway
+// removes the stage, and any base path of a custom domain, from the path of the request but
+// includes them in the path of the request context, so that prefix is added to the path.
+func externalPath(req Request, path string) string {
+	full := req.RequestContext.Path
+
+	if len(full) > len(req.Path) && strings.HasSuffix(full, req.Path) {
+		return strings.TrimSuffix(full, req.Path) + path
+	}
+
+	return path
+}
+
+// requestQuery returns the query string parameters of the request, preferring multi-value
+// parameters when they are available.
+func requestQuery(req Request) url.Values {
+	query := make(url.Values, len(req.QueryStringParameters))
+
+	for key, values := range req.MultiValueQueryStringParameters {
+		query[key] = values
+	}
+
+	for key, value := range req.QueryStringParameters {
+		if _, ok := query[key]; !ok {
+			query.Set(key, value)
+		}
+	}
+
+	return query
+}
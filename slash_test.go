@@ -0,0 +1,115 @@
+package lux_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/davidsbond/lux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouter_StrictSlash(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Strict           bool
+		Method           string
+		Path             string
+		ContextPath      string
+		Query            map[string]string
+		ExpectedStatus   int
+		ExpectedLocation string
+	}{
+		// Scenario 1: Request path has a trailing slash the route does not
+		{
+			Method:           "GET",
+			Path:             "/users/",
+			ExpectedStatus:   http.StatusMovedPermanently,
+			ExpectedLocation: "/users",
+		},
+		// Scenario 2: Request path is missing a trailing slash the route has
+		{
+			Method:           "GET",
+			Path:             "/docs",
+			ExpectedStatus:   http.StatusMovedPermanently,
+			ExpectedLocation: "/docs/",
+		},
+		// Scenario 3: Redirect keeps the query string
+		{
+			Method:           "GET",
+			Path:             "/users/",
+			Query:            map[string]string{"page": "2"},
+			ExpectedStatus:   http.StatusMovedPermanently,
+			ExpectedLocation: "/users?page=2",
+		},
+		// Scenario 4: Non-GET requests are redirected preserving the method
+		{
+			Method:           "POST",
+			Path:             "/users/",
+			ExpectedStatus:   http.StatusPermanentRedirect,
+			ExpectedLocation: "/users",
+		},
+		// Scenario 5: Request path matches a route exactly
+		{
+			Method:         "GET",
+			Path:           "/users",
+			ExpectedStatus: http.StatusOK,
+		},
+		// Scenario 6: Neither path has a route
+		{
+			Method:         "GET",
+			Path:           "/unknown/",
+			ExpectedStatus: http.StatusNotFound,
+		},
+		// Scenario 7: Strict matching is enabled
+		{
+			Strict:         true,
+			Method:         "GET",
+			Path:           "/users/",
+			ExpectedStatus: http.StatusNotFound,
+		},
+		// Scenario 8: Request is made to a REST API stage
+		{
+			Method:           "GET",
+			Path:             "/users/",
+			ContextPath:      "/prod/users/",
+			ExpectedStatus:   http.StatusMovedPermanently,
+			ExpectedLocation: "/prod/users",
+		},
+		// Scenario 9: Route at the other path does not allow the method
+		{
+			Method:         "DELETE",
+			Path:           "/users/",
+			ExpectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router
+		router := lux.NewRouter().StrictSlash(tc.Strict)
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has handlers registered
+		router.Handler("GET", getHandler).Path("/users")
+		router.Handler("POST", getHandler).Path("/users")
+		router.Handler("GET", getHandler).Path("/docs/")
+
+		// WHEN we perform a request
+		resp, _ := router.ServeHTTP(context.Background(), lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+				HTTPMethod:            tc.Method,
+				Path:                  tc.Path,
+				QueryStringParameters: tc.Query,
+				RequestContext:        events.APIGatewayProxyRequestContext{Path: tc.ContextPath},
+			},
+		})
+
+		// THEN the response should be what we expect
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+		assert.Equal(t, tc.ExpectedLocation, resp.Headers["Location"])
+	}
+}
@@ -0,0 +1,57 @@
+//go:build go1.21
+
+package lux
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+)
+
+type (
+	slogLogger struct {
+		log *slog.Logger
+	}
+)
+
+// NewSlogLogger creates a Logger that writes logs using the given slog logger. The fields
+// of each log entry, such as the method, status, duration & request ID of the access log,
+// are written as slog attributes.
+func NewSlogLogger(log *slog.Logger) Logger {
+	return &slogLogger{log: log}
+}
+
+// Debug writes a debug level log entry with the given fields.
+func (l *slogLogger) Debug(msg string, fields Fields) {
+	l.write(slog.LevelDebug, msg, fields)
+}
+
+// Info writes an info level log entry with the given fields.
+func (l *slogLogger) Info(msg string, fields Fields) {
+	l.write(slog.LevelInfo, msg, fields)
+}
+
+// Warn writes a warning level log entry with the given fields.
+func (l *slogLogger) Warn(msg string, fields Fields) {
+	l.write(slog.LevelWarn, msg, fields)
+}
+
+// Error writes an error level log entry with the given fields.
+func (l *slogLogger) Error(msg string, fields Fields) {
+	l.write(slog.LevelError, msg, fields)
+}
+
+// write writes a log entry, converting the fields to attributes sorted by key.
+func (l *slogLogger) write(level slog.Level, msg string, fields Fields) {
+	attrs := make([]slog.Attr, 0, len(fields))
+
+	for key, value := range fields {
+		attrs = append(attrs, slog.Any(key, value))
+	}
+
+	sort.Slice(attrs, func(i, j int) bool {
+		return attrs[i].Key < attrs[j].Key
+	})
+
+	l.log.LogAttrs(context.Background(), level, msg, attrs...)
+}
@@ -0,0 +1,56 @@
+//go:build go1.21
+
+package lux_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/davidsbond/lux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSlogLogger(t *testing.T) {
+	t.Parallel()
+
+	out := bytes.NewBuffer([]byte{})
+
+	// GIVEN that we have a router using a slog logger
+	router := lux.NewRouter().Logger(lux.NewSlogLogger(slog.New(slog.NewJSONHandler(out, nil))))
+
+	// AND that router has a handler
+	router.Handler("GET", getHandler).Path("/users/{id}")
+	out.Reset()
+
+	// WHEN we perform a request
+	router.ServeHTTP(context.Background(), lux.Request{
+		APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+			HTTPMethod: "GET",
+			Path:       "/users/42",
+			RequestContext: events.APIGatewayProxyRequestContext{
+				RequestID: "abc",
+			},
+		},
+	})
+
+	// THEN the access log should be written using slog
+	lines := bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n"))
+
+	var entry map[string]interface{}
+
+	assert.Nil(t, json.Unmarshal(lines[len(lines)-1], &entry))
+
+	// AND the fields should be written as attributes
+	assert.Equal(t, "INFO", entry["level"])
+	assert.Equal(t, "finished handling request", entry["msg"])
+	assert.Equal(t, "GET", entry["method"])
+	assert.Equal(t, "/users/{id}", entry["route"])
+	assert.Equal(t, float64(http.StatusOK), entry["status"])
+	assert.Equal(t, "abc", entry["requestId"])
+	assert.Contains(t, entry, "durationMs")
+}
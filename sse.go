@@ -0,0 +1,83 @@
+package lux
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type (
+	// EventStream writes Server-Sent Events to a response. When response streaming is enabled
+	// using Router.Streaming, each event is sent to the client as soon as it is written.
+	EventStream struct {
+		w ResponseWriter
+	}
+
+	// The Event type represents a single Server-Sent Event. Only Data is required, the
+	// remaining fields are omitted from the event when empty.
+	Event struct {
+		// ID sets the last event ID of the client, which is sent in the Last-Event-ID
+		// header when the client reconnects.
+		ID string
+		// Event is the name of the event. Clients dispatch unnamed events as "message".
+		Event string
+		// Data is the payload of the event. Data spanning multiple lines is sent as
+		// multiple data fields.
+		Data string
+		// Retry is the time in milliseconds the client should wait before reconnecting.
+		Retry int
+	}
+)
+
+// SSEWriter starts a Server-Sent Events response, writing the "text/event-stream" content
+// type and a 200 status code. Events are then written using the returned EventStream.
+func SSEWriter(w ResponseWriter) *EventStream {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	return &EventStream{w: w}
+}
+
+// Send writes the event to the response.
+func (s *EventStream) Send(event Event) error {
+	var frame strings.Builder
+
+	writeField(&frame, "id", event.ID)
+	writeField(&frame, "event", event.Event)
+
+	if event.Retry > 0 {
+		writeField(&frame, "retry", strconv.Itoa(event.Retry))
+	}
+
+	for _, line := range strings.Split(event.Data, "\n") {
+		frame.WriteString("data: " + strings.TrimSuffix(line, "\r") + "\n")
+	}
+
+	frame.WriteString("\n")
+
+	return s.write(frame.String())
+}
+
+// KeepAlive writes a comment to the response, which clients ignore. Sending comments
+// periodically prevents idle connections from being closed by intermediaries.
+func (s *EventStream) KeepAlive() error {
+	return s.write(":\n\n")
+}
+
+func (s *EventStream) write(frame string) error {
+	_, err := s.w.Write([]byte(frame))
+
+	return err
+}
+
+// writeField writes a field of an event, omitting it when the value is empty. Newlines
+// are removed as they would terminate the field early.
+func writeField(frame *strings.Builder, name, value string) {
+	if value == "" {
+		return
+	}
+
+	value = strings.NewReplacer("\r", "", "\n", "").Replace(value)
+	frame.WriteString(name + ": " + value + "\n")
+}
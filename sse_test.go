@@ -0,0 +1,83 @@
+package lux_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/davidsbond/lux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSSEWriter(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Handler      lux.HandlerFunc
+		ExpectedBody string
+	}{
+		// Scenario 1: Handler sends an event with data only
+		{
+			Handler: func(w lux.ResponseWriter, r *lux.Request) {
+				lux.SSEWriter(w).Send(lux.Event{Data: "hello"})
+			},
+			ExpectedBody: "data: hello\n\n",
+		},
+		// Scenario 2: Handler sends an event with all fields
+		{
+			Handler: func(w lux.ResponseWriter, r *lux.Request) {
+				lux.SSEWriter(w).Send(lux.Event{ID: "1", Event: "update", Data: "hello", Retry: 1000})
+			},
+			ExpectedBody: "id: 1\nevent: update\nretry: 1000\ndata: hello\n\n",
+		},
+		// Scenario 3: Handler sends an event with multi-line data
+		{
+			Handler: func(w lux.ResponseWriter, r *lux.Request) {
+				lux.SSEWriter(w).Send(lux.Event{Data: "a\nb"})
+			},
+			ExpectedBody: "data: a\ndata: b\n\n",
+		},
+		// Scenario 4: Handler sends an event name containing a newline
+		{
+			Handler: func(w lux.ResponseWriter, r *lux.Request) {
+				lux.SSEWriter(w).Send(lux.Event{Event: "up\ndate", Data: "a"})
+			},
+			ExpectedBody: "event: update\ndata: a\n\n",
+		},
+		// Scenario 5: Handler sends a keep-alive between events
+		{
+			Handler: func(w lux.ResponseWriter, r *lux.Request) {
+				s := lux.SSEWriter(w)
+				s.Send(lux.Event{Data: "a"})
+				s.KeepAlive()
+				s.Send(lux.Event{Data: "b"})
+			},
+			ExpectedBody: "data: a\n\n:\n\ndata: b\n\n",
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router
+		router := lux.NewRouter()
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has a handler that sends events
+		router.Handler("GET", tc.Handler)
+
+		// WHEN we perform a request
+		resp, _ := router.ServeHTTP(context.Background(), lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{HTTPMethod: "GET"},
+		})
+
+		// THEN the response should be an event stream
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "text/event-stream", resp.Headers["Content-Type"])
+		assert.Equal(t, "no-cache", resp.Headers["Cache-Control"])
+
+		// AND the body should contain the events we expect
+		assert.Equal(t, tc.ExpectedBody, resp.Body)
+	}
+}
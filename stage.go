@@ -0,0 +1,49 @@
+package lux
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// StageVariable returns the value of the named stage variable of the API Gateway stage that
+// received the request, allowing configuration such as backend URLs to differ between stages.
+// An empty string is returned if the variable is not set.
+func (r *Request) StageVariable(name string) string {
+	return r.StageVariables[name]
+}
+
+// StageVariableInt returns the value of the named stage variable as an integer. If the variable
+// is not set, ErrMissingParam is returned.
+func (r *Request) StageVariableInt(name string) (int, error) {
+	value, ok := r.StageVariables[name]
+
+	if !ok {
+		return 0, ErrMissingParam
+	}
+
+	out, err := strconv.Atoi(value)
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse stage variable %s, %v", name, err)
+	}
+
+	return out, nil
+}
+
+// StageVariableBool returns the value of the named stage variable as a boolean. If the variable
+// is not set, ErrMissingParam is returned.
+func (r *Request) StageVariableBool(name string) (bool, error) {
+	value, ok := r.StageVariables[name]
+
+	if !ok {
+		return false, ErrMissingParam
+	}
+
+	out, err := strconv.ParseBool(value)
+
+	if err != nil {
+		return false, fmt.Errorf("failed to parse stage variable %s, %v", name, err)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,55 @@
+package lux_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/davidsbond/lux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequest_StageVariable(t *testing.T) {
+	t.Parallel()
+
+	// GIVEN that we have a request with stage variables
+	req := lux.Request{
+		APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+			StageVariables: map[string]string{
+				"backendUrl": "https://api.example.com",
+				"retries":    "3",
+				"debug":      "true",
+				"invalid":    "yes please",
+			},
+		},
+	}
+
+	// AND a request without stage variables
+	empty := lux.Request{}
+
+	// THEN stage variables should be available as strings
+	assert.Equal(t, "https://api.example.com", req.StageVariable("backendUrl"))
+	assert.Equal(t, "", req.StageVariable("missing"))
+	assert.Equal(t, "", empty.StageVariable("backendUrl"))
+
+	// AND stage variables should be available as integers
+	retries, err := req.StageVariableInt("retries")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, retries)
+
+	_, err = req.StageVariableInt("missing")
+	assert.Equal(t, lux.ErrMissingParam, err)
+
+	_, err = req.StageVariableInt("invalid")
+	assert.EqualError(t, err, `failed to parse stage variable invalid, strconv.Atoi: parsing "yes please": invalid syntax`)
+
+	// AND stage variables should be available as booleans
+	debug, err := req.StageVariableBool("debug")
+	assert.NoError(t, err)
+	assert.True(t, debug)
+
+	_, err = empty.StageVariableBool("debug")
+	assert.Equal(t, lux.ErrMissingParam, err)
+
+	_, err = req.StageVariableBool("invalid")
+	assert.EqualError(t, err, `failed to parse stage variable invalid, strconv.ParseBool: parsing "yes please": invalid syntax`)
+}
@@ -0,0 +1,67 @@
+package lux
+
+import (
+	"github.com/aws/aws-lambda-go/lambda"
+)
+
+type (
+	// The EventType type describes the type of lambda event the router receives, which
+	// depends on how the lambda function is integrated with other AWS services.
+	EventType string
+)
+
+const (
+	// EventAPIGateway is the event type sent by API Gateway REST APIs and HTTP APIs using
+	// the version 1.0 payload format, which is handled using Router.ServeHTTP.
+	EventAPIGateway EventType = "apigateway"
+
+	// EventAPIGatewayV2 is the event type sent by API Gateway HTTP APIs using the version
+	// 2.0 payload format, which is handled using Router.ServeV2.
+	EventAPIGatewayV2 EventType = "apigatewayv2"
+
+	// EventALB is the event type sent by Application Load Balancers, which is handled using
+	// Router.ServeALB.
+	EventALB EventType = "alb"
+
+	// EventWebSocket is the event type sent by API Gateway WebSocket APIs, which is handled
+	// using Router.ServeWebSocket.
+	EventWebSocket EventType = "websocket"
+
+	// EventFunctionURLStream is the event type sent to lambda function URLs using the
+	// RESPONSE_STREAM invoke mode, which is handled using Router.ServeStream.
+	EventFunctionURLStream EventType = "functionurlstream"
+)
+
+// Events sets the type of lambda event the router receives when started using Router.Start.
+// Defaults to EventAPIGateway.
+func (r *Router) Events(event EventType) *Router {
+	r.events = event
+
+	return r
+}
+
+// LambdaHandler returns the function used to handle the type of lambda event set using
+// Router.Events, which can be passed to lambda.Start or lambda.NewHandler.
+func (r *Router) LambdaHandler() interface{} {
+	switch r.events {
+	case EventAPIGatewayV2:
+		return r.ServeV2
+	case EventALB:
+		return r.ServeALB
+	case EventWebSocket:
+		return r.ServeWebSocket
+	case EventFunctionURLStream:
+		return r.ServeStream
+	default:
+		return r.ServeHTTP
+	}
+}
+
+// Start begins handling lambda events using the router, removing the need to pass the
+// appropriate serve method to lambda.Start. The type of event is set using Router.Events,
+// and the given options are passed to lambda.StartWithOptions. Start blocks until the
+// lambda runtime terminates the function, and should be called once all routes have been
+// registered.
+func (r *Router) Start(opts ...lambda.Option) {
+	lambda.StartWithOptions(r.LambdaHandler(), opts...)
+}
@@ -0,0 +1,93 @@
+package lux_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/davidsbond/lux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouter_LambdaHandler(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Events         lux.EventType
+		Payload        string
+		ExpectedStatus int
+		ExpectedBody   string
+	}{
+		// Scenario 1: Default event type is API Gateway
+		{
+			Payload:        `{"httpMethod": "GET", "path": "/users"}`,
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   "\"hello test\"\n",
+		},
+		// Scenario 2: API Gateway event
+		{
+			Events:         lux.EventAPIGateway,
+			Payload:        `{"httpMethod": "GET", "path": "/users"}`,
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   "\"hello test\"\n",
+		},
+		// Scenario 3: API Gateway version 2.0 event
+		{
+			Events:         lux.EventAPIGatewayV2,
+			Payload:        `{"rawPath": "/users", "requestContext": {"http": {"method": "GET", "path": "/users"}}}`,
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   "\"hello test\"\n",
+		},
+		// Scenario 4: Application Load Balancer event
+		{
+			Events:         lux.EventALB,
+			Payload:        `{"httpMethod": "GET", "path": "/users", "requestContext": {"elb": {"targetGroupArn": "arn"}}}`,
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   "\"hello test\"\n",
+		},
+		// Scenario 5: Request for an unknown path
+		{
+			Events:         lux.EventAPIGateway,
+			Payload:        `{"httpMethod": "GET", "path": "/orders"}`,
+			ExpectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router
+		router := lux.NewRouter()
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router receives the event type
+		if tc.Events != "" {
+			router.Events(tc.Events)
+		}
+
+		// AND that router has a handler
+		router.Handler("GET", getHandler).Path("/users")
+
+		// WHEN the lambda runtime invokes the handler with the event
+		out, err := lambda.NewHandler(router.LambdaHandler()).Invoke(context.Background(), []byte(tc.Payload))
+
+		// THEN there should be no error
+		assert.NoError(t, err)
+
+		var resp struct {
+			StatusCode int    `json:"statusCode"`
+			Body       string `json:"body"`
+		}
+
+		assert.NoError(t, json.Unmarshal(out, &resp))
+
+		// AND the response should be what we expect
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+
+		if tc.ExpectedBody != "" {
+			assert.Equal(t, tc.ExpectedBody, resp.Body)
+		}
+	}
+}
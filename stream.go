@@ -0,0 +1,210 @@
+package lux
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+type (
+	// responseStream sends the response of a streamed request to the lambda runtime. The
+	// status code & headers are sent once the handler first writes to the body, after which
+	// each write is sent to the client as it happens.
+	responseStream struct {
+		mu      sync.Mutex
+		router  *Router
+		reader  *io.PipeReader
+		writer  *io.PipeWriter
+		head    chan events.APIGatewayV2HTTPResponse
+		started bool
+		stopped bool
+	}
+)
+
+// Streaming enables Lambda response streaming for requests handled using ServeStream. Each
+// call to ResponseWriter.Write is sent to the client as soon as it happens, once a status code
+// has been written, rather than buffering the full body. Headers set after the body is first
+// written are ignored. Response streaming is only available for Lambda function URLs using
+// the RESPONSE_STREAM invoke mode, it cannot be used with API Gateway.
+func (r *Router) Streaming() *Router {
+	r.streaming = true
+
+	return r
+}
+
+// ServeStream handles an incoming request from a Lambda function URL using the RESPONSE_STREAM
+// invoke mode. When Router.Streaming is enabled, the response body is streamed to the client
+// as the handler writes it. Otherwise, the response is buffered & returned once the handler has
+// finished. Responses to HEAD requests are never streamed. Router.ReturnErrors has no effect
+// on streamed responses, as the status code may already have been sent. The function must be
+// compiled with the lambda.norpc tag, or use the provided runtimes.
+func (r *Router) ServeStream(ctx context.Context, req events.LambdaFunctionURLRequest) (*events.LambdaFunctionURLStreamingResponse, error) {
+	in := newRequestFromFunctionURL(req)
+
+	if !r.streaming || in.HTTPMethod == http.MethodHead {
+		resp, err := r.ServeHTTP(ctx, in)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return newStreamingResponse(newV2Response(resp), bytes.NewReader(responseBody(resp))), nil
+	}
+
+	stream := newResponseStream(r)
+
+	go func() {
+		resp, _ := r.handle(ctx, in, stream)
+		stream.finish(resp)
+	}()
+
+	return newStreamingResponse(<-stream.head, stream.reader), nil
+}
+
+// newResponseStream creates a stream for the response of a request handled by the router.
+func newResponseStream(router *Router) *responseStream {
+	reader, writer := io.Pipe()
+
+	return &responseStream{
+		router: router,
+		reader: reader,
+		writer: writer,
+		head:   make(chan events.APIGatewayV2HTTPResponse, 1),
+	}
+}
+
+// write sends data written by the handler to the client, sending the status code & headers
+// of the response first if they have not already been sent. Data written once the stream has
+// been stopped is discarded.
+func (s *responseStream) write(w *responseWriter, data []byte) (int, error) {
+	s.mu.Lock()
+
+	if s.stopped {
+		s.mu.Unlock()
+		return len(data), nil
+	}
+
+	if !s.started {
+		s.started = true
+		s.head <- s.newHead(w)
+	}
+
+	s.mu.Unlock()
+
+	n, err := s.writer.Write(data)
+	w.sent += n
+
+	return n, err
+}
+
+// stop prevents any further writes by the handler from being sent to the client, such as
+// when the request has timed out while the handler is still running.
+func (s *responseStream) stop() {
+	s.mu.Lock()
+	s.stopped = true
+	s.mu.Unlock()
+}
+
+// finish completes the stream once the request has been handled. If the handler never wrote
+// to the stream, such as when the request could not be routed, the buffered response is sent
+// instead.
+func (s *responseStream) finish(resp Response) {
+	s.mu.Lock()
+	started := s.started
+	s.started = true
+	s.mu.Unlock()
+
+	if !started {
+		s.head <- newV2Response(resp)
+		s.writer.Write(responseBody(resp))
+	}
+
+	s.writer.Close()
+}
+
+// newHead creates the status code & headers sent before the first write to the stream. Any
+// default headers of the router not written by the handler are included.
+func (s *responseStream) newHead(w *responseWriter) events.APIGatewayV2HTTPResponse {
+	resp := Response{
+		StatusCode:        w.code,
+		Headers:           make(map[string]string, len(w.headers)),
+		MultiValueHeaders: make(map[string][]string, len(w.headers)),
+	}
+
+	for key, values := range w.headers {
+		if len(values) > 0 {
+			resp.Headers[key] = values[0]
+			resp.MultiValueHeaders[key] = values
+		}
+	}
+
+	for key, value := range s.router.headers {
+		if resp.header(key) == "" {
+			resp.setHeader(key, value)
+		}
+	}
+
+	return newV2Response(resp)
+}
+
+// newStreamingResponse creates a streaming response with the status code & headers of the
+// given response, whose body is read from the given reader.
+func newStreamingResponse(head events.APIGatewayV2HTTPResponse, body io.Reader) *events.LambdaFunctionURLStreamingResponse {
+	return &events.LambdaFunctionURLStreamingResponse{
+		StatusCode: head.StatusCode,
+		Headers:    head.Headers,
+		Cookies:    head.Cookies,
+		Body:       body,
+	}
+}
+
+// responseBody returns the body of the response, decoding it from base64 if required.
+func responseBody(resp Response) []byte {
+	if !resp.IsBase64Encoded {
+		return []byte(resp.Body)
+	}
+
+	body, _ := base64.StdEncoding.DecodeString(resp.Body)
+
+	return body
+}
+
+// newRequestFromFunctionURL converts a Lambda function URL request into a Request. Function URLs
+// use the same payload format as version 2.0 API Gateway HTTP APIs.
+func newRequestFromFunctionURL(req events.LambdaFunctionURLRequest) Request {
+	out := newRequestFromV2(events.APIGatewayV2HTTPRequest{
+		Version:               req.Version,
+		RawPath:               req.RawPath,
+		RawQueryString:        req.RawQueryString,
+		Cookies:               req.Cookies,
+		Headers:               req.Headers,
+		QueryStringParameters: req.QueryStringParameters,
+		Body:                  req.Body,
+		IsBase64Encoded:       req.IsBase64Encoded,
+		RequestContext: events.APIGatewayV2HTTPRequestContext{
+			AccountID:    req.RequestContext.AccountID,
+			RequestID:    req.RequestContext.RequestID,
+			APIID:        req.RequestContext.APIID,
+			DomainName:   req.RequestContext.DomainName,
+			DomainPrefix: req.RequestContext.DomainPrefix,
+			Time:         req.RequestContext.Time,
+			TimeEpoch:    req.RequestContext.TimeEpoch,
+			HTTP: events.APIGatewayV2HTTPRequestContextHTTPDescription{
+				Method:    req.RequestContext.HTTP.Method,
+				Path:      req.RequestContext.HTTP.Path,
+				Protocol:  req.RequestContext.HTTP.Protocol,
+				SourceIP:  req.RequestContext.HTTP.SourceIP,
+				UserAgent: req.RequestContext.HTTP.UserAgent,
+			},
+		},
+	})
+
+	out.raw = req
+
+	return out
+}
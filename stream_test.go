@@ -0,0 +1,145 @@
+package lux_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/davidsbond/lux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouter_ServeStream(t *testing.T) {
+	t.Parallel()
+
+	// GIVEN that we have a router with streaming enabled
+	router := lux.NewRouter().Streaming()
+	router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+	router.DefaultHeaders(map[string]string{"X-Frame-Options": "DENY"})
+
+	// AND that router has a handler that writes the body in chunks
+	read := make(chan struct{})
+
+	router.Handler("GET", func(w lux.ResponseWriter, r *lux.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Header().Add("Set-Cookie", "a=1")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("a"))
+
+		// Wait for the first chunk to be read before writing the next
+		<-read
+		w.Write([]byte("b"))
+	}).Path("/stream")
+
+	// WHEN we perform a request
+	resp, err := router.ServeStream(context.Background(), events.LambdaFunctionURLRequest{
+		RawPath: "/stream",
+		RequestContext: events.LambdaFunctionURLRequestContext{
+			HTTP: events.LambdaFunctionURLRequestContextHTTPDescription{Method: "GET", Path: "/stream"},
+		},
+	})
+
+	// THEN the status code & headers should be returned before the body is complete
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/plain", resp.Headers["Content-Type"])
+	assert.Equal(t, "DENY", resp.Headers["X-Frame-Options"])
+	assert.Equal(t, []string{"a=1"}, resp.Cookies)
+
+	// AND each chunk should be readable as soon as it is written
+	chunk := make([]byte, 1)
+	_, err = io.ReadFull(resp.Body, chunk)
+	assert.NoError(t, err)
+	assert.Equal(t, "a", string(chunk))
+
+	close(read)
+
+	rest, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, "b", string(rest))
+}
+
+func TestRouter_ServeStreamBuffered(t *testing.T) {
+	t.Parallel()
+
+	png := []byte{0x89, 'P', 'N', 'G'}
+
+	tt := []struct {
+		Streaming      bool
+		Method         string
+		Path           string
+		ExpectedStatus int
+		ExpectedBody   []byte
+	}{
+		// Scenario 1: Streaming is disabled
+		{
+			Method:         "GET",
+			Path:           "/text",
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   []byte("\"hello test\"\n"),
+		},
+		// Scenario 2: Streaming is disabled and the response is binary
+		{
+			Method:         "GET",
+			Path:           "/binary",
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   png,
+		},
+		// Scenario 3: Streaming is enabled and the request cannot be routed
+		{
+			Streaming:      true,
+			Method:         "GET",
+			Path:           "/unknown",
+			ExpectedStatus: http.StatusNotFound,
+			ExpectedBody:   []byte("\"not found\""),
+		},
+		// Scenario 4: Streaming is enabled and the handler never writes a body
+		{
+			Streaming:      true,
+			Method:         "DELETE",
+			Path:           "/text",
+			ExpectedStatus: http.StatusNoContent,
+			ExpectedBody:   []byte{},
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router
+		router := lux.NewRouter()
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		if tc.Streaming {
+			router.Streaming()
+		}
+
+		// AND that router has handlers registered
+		router.Handler("GET", getHandler).Path("/text")
+		router.Handler("DELETE", func(w lux.ResponseWriter, r *lux.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}).Path("/text")
+		router.Handler("GET", func(w lux.ResponseWriter, r *lux.Request) {
+			lux.Binary(w, http.StatusOK, "image/png", png)
+		}).Path("/binary")
+
+		// WHEN we perform a request
+		resp, err := router.ServeStream(context.Background(), events.LambdaFunctionURLRequest{
+			RawPath: tc.Path,
+			RequestContext: events.LambdaFunctionURLRequestContext{
+				HTTP: events.LambdaFunctionURLRequestContextHTTPDescription{Method: tc.Method, Path: tc.Path},
+			},
+		})
+
+		// THEN the response should be what we expect
+		assert.NoError(t, err)
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+
+		// AND the body should be decoded
+		body, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.ExpectedBody, body)
+	}
+}
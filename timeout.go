@@ -0,0 +1,95 @@
+package lux
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Timeout bounds the execution time of middleware & route handlers. If a request takes
+// longer than the given duration, a 504 response is returned to the client. The timeout
+// never exceeds the deadline of the lambda invocation. Handlers can observe the timeout
+// using the context returned by Request.Context, anything they write after the timeout
+// has elapsed is discarded.
+func (r *Router) Timeout(d time.Duration) *Router {
+	r.timeout = d
+
+	return r
+}
+
+// DeadlineMargin shortens the deadline of the context returned by Request.Context to the
+// deadline of the lambda invocation minus the given margin. This allows handlers to cancel
+// downstream operations, such as database calls, before the lambda is terminated. When
+// combined with Timeout, a 504 response is returned once the shortened deadline elapses.
+// Invocations without a deadline are unaffected.
+func (r *Router) DeadlineMargin(d time.Duration) *Router {
+	r.deadlineMargin = d
+
+	return r
+}
+
+// withDeadlineMargin returns a copy of the given context whose deadline is shortened by the
+// deadline margin of the router.
+func (r *Router) withDeadlineMargin(ctx context.Context) (context.Context, context.CancelFunc) {
+	deadline, ok := ctx.Deadline()
+
+	if !ok || r.deadlineMargin <= 0 {
+		return ctx, func() {}
+	}
+
+	return context.WithDeadline(ctx, deadline.Add(-r.deadlineMargin))
+}
+
+// timeoutRequest executes the request in a separate goroutine using its own response
+// writer. The response is only copied to the given writer if the request completes
+// before the timeout, so late writes cannot modify the timeout response.
+func (r *Router) timeoutRequest(route *Route, w *responseWriter, req Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), r.timeout)
+	defer cancel()
+
+	req.ctx = ctx
+
+	tw := &responseWriter{
+		headers: make(Headers),
+		body:    []byte{},
+		failure: w.failure,
+		stream:  w.stream,
+		codec:   w.codec,
+		log:     w.log,
+	}
+
+	for key, values := range w.headers {
+		tw.headers[key] = append([]string{}, values...)
+	}
+
+	req.response = tw
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		r.runRequest(route, tw, req)
+	}()
+
+	select {
+	case <-done:
+		*w = *tw
+	case <-ctx.Done():
+		// The handler is stopped from streaming anything it writes from now on, & the
+		// timeout response is never streamed, so that it cannot be mixed with the body
+		// of a response the handler has already started streaming.
+		if w.stream != nil {
+			w.stream.stop()
+		}
+
+		r.log.Warn("request timed out", Fields{
+			"requestId": req.RequestContext.RequestID,
+			"method":    req.HTTPMethod,
+			"timeout":   r.timeout.String(),
+		})
+
+		w.failure, w.stream = errTimeout, nil
+		r.writeError(w, &req, http.StatusGatewayTimeout, errTimeout)
+	}
+}
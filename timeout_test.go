@@ -0,0 +1,246 @@
+package lux_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/davidsbond/lux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouter_Timeout(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Timeout          time.Duration
+		Deadline         time.Duration
+		HandlerDuration  time.Duration
+		ExpectedStatus   int
+		ExpectedBody     string
+		ExpectedHeaderOK bool
+	}{
+		// Scenario 1: Handler completes before the timeout
+		{
+			Timeout:          time.Second,
+			HandlerDuration:  0,
+			ExpectedStatus:   http.StatusOK,
+			ExpectedBody:     "ok",
+			ExpectedHeaderOK: true,
+		},
+		// Scenario 2: Handler exceeds the timeout
+		{
+			Timeout:         10 * time.Millisecond,
+			HandlerDuration: 100 * time.Millisecond,
+			ExpectedStatus:  http.StatusGatewayTimeout,
+			ExpectedBody:    "\"timed out\"",
+		},
+		// Scenario 3: Handler exceeds the lambda deadline before the timeout
+		{
+			Timeout:         time.Minute,
+			Deadline:        10 * time.Millisecond,
+			HandlerDuration: 100 * time.Millisecond,
+			ExpectedStatus:  http.StatusGatewayTimeout,
+			ExpectedBody:    "\"timed out\"",
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router with a timeout
+		router := lux.NewRouter().Timeout(tc.Timeout)
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has a handler that takes a given duration & writes once finished
+		duration := tc.HandlerDuration
+
+		router.Handler("GET", func(w lux.ResponseWriter, r *lux.Request) {
+			select {
+			case <-time.After(duration):
+			case <-r.Context().Done():
+			}
+
+			w.Header().Set("X-Ok", "true")
+			lux.Text(w, http.StatusOK, "ok")
+		})
+
+		ctx := context.Background()
+
+		if tc.Deadline > 0 {
+			var cancel context.CancelFunc
+
+			ctx, cancel = context.WithTimeout(ctx, tc.Deadline)
+			defer cancel()
+		}
+
+		// WHEN we perform the request
+		resp, _ := router.ServeHTTP(ctx, lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{HTTPMethod: "GET"},
+		})
+
+		// THEN the response should be what we expect
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+		assert.Equal(t, tc.ExpectedBody, resp.Body)
+
+		// AND late writes should not modify the response
+		_, ok := resp.Headers["X-Ok"]
+		assert.Equal(t, tc.ExpectedHeaderOK, ok)
+	}
+}
+
+func TestRouter_DeadlineMargin(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Margin           time.Duration
+		Deadline         time.Duration
+		ExpectedDeadline bool
+		ExpectedMaximum  time.Duration
+	}{
+		// Scenario 1: Deadline is shortened by the margin
+		{
+			Margin:           10 * time.Second,
+			Deadline:         time.Minute,
+			ExpectedDeadline: true,
+			ExpectedMaximum:  50 * time.Second,
+		},
+		// Scenario 2: Invocation without a deadline
+		{
+			Margin: 10 * time.Second,
+		},
+		// Scenario 3: Router without a margin
+		{
+			Deadline:         time.Minute,
+			ExpectedDeadline: true,
+			ExpectedMaximum:  time.Minute,
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router with a deadline margin
+		router := lux.NewRouter().DeadlineMargin(tc.Margin)
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has a handler that observes the deadline of its context
+		var deadline time.Time
+		var ok bool
+
+		router.Handler("GET", func(w lux.ResponseWriter, r *lux.Request) {
+			deadline, ok = r.Context().Deadline()
+			w.WriteHeader(http.StatusOK)
+		})
+
+		ctx := context.Background()
+
+		if tc.Deadline > 0 {
+			var cancel context.CancelFunc
+
+			ctx, cancel = context.WithTimeout(ctx, tc.Deadline)
+			defer cancel()
+		}
+
+		// WHEN we perform the request
+		router.ServeHTTP(ctx, lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{HTTPMethod: "GET"},
+		})
+
+		// THEN the handler's context should only have a deadline when we expect
+		assert.Equal(t, tc.ExpectedDeadline, ok)
+
+		// AND the deadline should be shortened by the margin
+		if tc.ExpectedDeadline {
+			remaining := time.Until(deadline)
+			assert.True(t, remaining <= tc.ExpectedMaximum && remaining > tc.ExpectedMaximum-time.Second)
+		}
+	}
+}
+
+func TestRouter_DeadlineMarginTimeout(t *testing.T) {
+	t.Parallel()
+
+	// GIVEN that we have a router with a timeout & deadline margin
+	router := lux.NewRouter().Timeout(time.Minute).DeadlineMargin(time.Minute)
+	router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+	// AND that router has a handler that waits for its context to be cancelled
+	router.Handler("GET", func(w lux.ResponseWriter, r *lux.Request) {
+		<-r.Context().Done()
+		lux.Text(w, http.StatusOK, "ok")
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute+50*time.Millisecond)
+	defer cancel()
+
+	// WHEN we perform the request
+	resp, _ := router.ServeHTTP(ctx, lux.Request{
+		APIGatewayProxyRequest: events.APIGatewayProxyRequest{HTTPMethod: "GET"},
+	})
+
+	// THEN the request should time out before the lambda deadline
+	assert.Equal(t, http.StatusGatewayTimeout, resp.StatusCode)
+	assert.Equal(t, "\"timed out\"", resp.Body)
+}
+
+type (
+	// blockingLogger calls a function before recording each warning, allowing tests to
+	// perform actions while the router is handling a timeout.
+	blockingLogger struct {
+		recordingLogger
+		onWarn func()
+	}
+)
+
+func (l *blockingLogger) Warn(msg string, fields lux.Fields) {
+	l.onWarn()
+	l.recordingLogger.Warn(msg, fields)
+}
+
+func TestRouter_TimeoutStream(t *testing.T) {
+	t.Parallel()
+
+	warned := make(chan struct{})
+	written := make(chan struct{})
+
+	// GIVEN that we have a router with streaming & a timeout
+	router := lux.NewRouter().Streaming().Timeout(10 * time.Millisecond)
+
+	// AND that router's logger waits for the handler to write while handling the timeout
+	router.Logger(&blockingLogger{onWarn: func() {
+		close(warned)
+
+		select {
+		case <-written:
+		case <-time.After(time.Second):
+		}
+	}})
+
+	// AND that router has a handler that writes once the timeout is being handled
+	router.Handler("GET", func(w lux.ResponseWriter, r *lux.Request) {
+		<-warned
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("late"))
+		close(written)
+	}).Path("/stream")
+
+	// WHEN we perform the request
+	resp, err := router.ServeStream(context.Background(), events.LambdaFunctionURLRequest{
+		RawPath: "/stream",
+		RequestContext: events.LambdaFunctionURLRequestContext{
+			HTTP: events.LambdaFunctionURLRequestContextHTTPDescription{Method: "GET", Path: "/stream"},
+		},
+	})
+
+	// THEN the timeout response should be returned
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusGatewayTimeout, resp.StatusCode)
+
+	// AND none of the late writes should be streamed to the client
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, "\"timed out\"", string(body))
+}
@@ -0,0 +1,77 @@
+package lux
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StartedAt returns the time at which the router began handling the request. The returned
+// time carries a monotonic clock reading, so durations measured from it are unaffected by
+// changes to the system clock. The zero time is returned if the request has not been
+// handled by a router.
+func (r *Request) StartedAt() time.Time {
+	return r.startedAt
+}
+
+// Elapsed returns how long the router has been handling the request. This allows handlers
+// & middleware to measure & report timing, such as in logs or a Server-Timing header,
+// without recording their own start time. Zero is returned if the request has not been
+// handled by a router.
+func (r *Request) Elapsed() time.Duration {
+	if r.startedAt.IsZero() {
+		return 0
+	}
+
+	return time.Since(r.startedAt)
+}
+
+// Timing records a timed operation, such as a database query, in the Server-Timing header of
+// the response so that it can be inspected using browser developer tools. Entries are added
+// in the order they are recorded, and names are restricted to the characters allowed in a
+// header token, with any others replaced by an underscore. Entries recorded once a streamed
+// response has begun are not sent.
+func (r *Request) Timing(name string, d time.Duration) {
+	if r.response == nil {
+		return
+	}
+
+	name = serverTimingName(name)
+
+	if name == "" {
+		return
+	}
+
+	entry := name + ";dur=" + strconv.FormatFloat(float64(d)/float64(time.Millisecond), 'f', -1, 64)
+
+	// Entries are combined into a single value as only the first value of each header is
+	// returned to API Gateway integrations that do not support multi-value headers
+	if existing := r.response.headers.Get("Server-Timing"); existing != "" {
+		entry = existing + ", " + entry
+	}
+
+	r.response.headers.Set("Server-Timing", entry)
+}
+
+// serverTimingName replaces any characters in the given name that are not valid in a header
+// token with an underscore.
+func serverTimingName(name string) string {
+	return strings.Map(func(c rune) rune {
+		if isTokenChar(c) {
+			return c
+		}
+
+		return '_'
+	}, strings.TrimSpace(name))
+}
+
+// isTokenChar determines if the given character can be used in a header token, as defined in
+// RFC 7230.
+func isTokenChar(c rune) bool {
+	switch {
+	case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		return true
+	default:
+		return strings.ContainsRune("!#$%&'*+-.^_`|~", c)
+	}
+}
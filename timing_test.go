@@ -0,0 +1,115 @@
+package lux_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/davidsbond/lux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequest_Elapsed(t *testing.T) {
+	t.Parallel()
+
+	var started time.Time
+	var elapsed time.Duration
+
+	before := time.Now()
+
+	// GIVEN that we have a router
+	router := lux.NewRouter()
+	router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+	// AND that router has a handler that reads the request timing
+	router.Handler("GET", func(w lux.ResponseWriter, r *lux.Request) {
+		time.Sleep(10 * time.Millisecond)
+
+		started = r.StartedAt()
+		elapsed = r.Elapsed()
+		w.WriteHeader(http.StatusOK)
+	})
+
+	// WHEN we perform the request
+	router.ServeHTTP(context.Background(), lux.Request{
+		APIGatewayProxyRequest: events.APIGatewayProxyRequest{HTTPMethod: "GET"},
+	})
+
+	// THEN the start time should be when the router began handling the request
+	assert.False(t, started.Before(before))
+	assert.False(t, started.After(time.Now()))
+
+	// AND the elapsed time should include the time spent in the handler
+	assert.True(t, elapsed >= 10*time.Millisecond)
+}
+
+func TestRequest_ElapsedUnhandled(t *testing.T) {
+	t.Parallel()
+
+	// GIVEN that we have a request that has not been handled by a router
+	req := lux.Request{}
+
+	// THEN the start time & elapsed time should be zero
+	assert.True(t, req.StartedAt().IsZero())
+	assert.Equal(t, time.Duration(0), req.Elapsed())
+}
+
+func TestRequest_Timing(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Timings  map[string]time.Duration
+		Names    []string
+		Expected string
+	}{
+		// Scenario 1: Request without any timings
+		{},
+		// Scenario 2: Request with a single timing
+		{
+			Names:    []string{"db"},
+			Timings:  map[string]time.Duration{"db": 12 * time.Millisecond},
+			Expected: "db;dur=12",
+		},
+		// Scenario 3: Request with multiple timings
+		{
+			Names:    []string{"db", "cache"},
+			Timings:  map[string]time.Duration{"db": 1500 * time.Microsecond, "cache": 250 * time.Microsecond},
+			Expected: "db;dur=1.5, cache;dur=0.25",
+		},
+		// Scenario 4: Request with a timing whose name contains invalid characters
+		{
+			Names:    []string{"db query;\"users\"", " "},
+			Timings:  map[string]time.Duration{"db query;\"users\"": time.Millisecond, " ": time.Millisecond},
+			Expected: "db_query__users_;dur=1",
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router
+		router := lux.NewRouter()
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has a handler that records timings
+		names, timings := tc.Names, tc.Timings
+
+		router.Handler("GET", func(w lux.ResponseWriter, r *lux.Request) {
+			for _, name := range names {
+				r.Timing(name, timings[name])
+			}
+
+			w.WriteHeader(http.StatusOK)
+		})
+
+		// WHEN we perform the request
+		resp, _ := router.ServeHTTP(context.Background(), lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{HTTPMethod: "GET"},
+		})
+
+		// THEN the response should contain the timings
+		assert.Equal(t, tc.Expected, resp.Headers["Server-Timing"])
+	}
+}
@@ -0,0 +1,85 @@
+package lux
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-xray-sdk-go/xray"
+)
+
+// Tracing enables AWS X-Ray tracing (https://aws.amazon.com/xray/). Each request that
+// matches a route is wrapped in a subsegment named after the route & annotated with the
+// method, path and status of the request. The subsegment is added to the segment created
+// by the lambda runtime, falling back to the trace given in the X-Amzn-Trace-Id header
+// of the request. The subsegment is made available via Request.Context, allowing your
+// handlers to create their own subsegments.
+func (r *Router) Tracing() *Router {
+	r.tracing = true
+
+	return r
+}
+
+// traceRequest performs the request within an X-Ray subsegment. If no segment can be
+// found for the request, it is performed without tracing.
+func (r *Router) traceRequest(route *Route, w *responseWriter, req Request) {
+	ctx, seg := xray.BeginSubsegment(traceContext(req), route.traceName(req.HTTPMethod))
+
+	if seg == nil {
+		r.performRequest(route, w, req)
+		return
+	}
+
+	req.ctx = ctx
+	r.performRequest(route, w, req)
+
+	seg.AddAnnotation("method", req.HTTPMethod)
+	seg.AddAnnotation("path", req.Path)
+	seg.AddAnnotation("status", w.code)
+
+	seg.Lock()
+	seg.GetHTTP().GetRequest().Method = req.HTTPMethod
+	seg.GetHTTP().GetRequest().URL = req.Path
+	seg.GetHTTP().GetResponse().Status = w.code
+	seg.GetHTTP().GetResponse().ContentLength = len(w.body)
+
+	switch {
+	case w.code == http.StatusTooManyRequests:
+		seg.Error, seg.Throttle = true, true
+	case w.code >= 400 && w.code < 500:
+		seg.Error = true
+	case w.code >= 500:
+		seg.Fault = true
+	}
+
+	seg.Unlock()
+	seg.Close(nil)
+}
+
+// traceContext returns the context used to begin the subsegment for a request. When
+// the context contains neither a segment nor a trace header, the X-Amzn-Trace-Id
+// header of the request is used.
+func traceContext(req Request) context.Context {
+	ctx := req.Context()
+
+	if xray.GetSegment(ctx) != nil || ctx.Value(xray.LambdaTraceHeaderKey) != nil {
+		return ctx
+	}
+
+	if header := req.header("X-Amzn-Trace-Id"); header != "" {
+		return context.WithValue(ctx, xray.LambdaTraceHeaderKey, header)
+	}
+
+	return ctx
+}
+
+// traceName returns the name of the X-Ray subsegment for a request with the given method
+// handled by the route. This is the name of the route if it has one, otherwise the method
+// & path pattern.
+func (r *Route) traceName(method string) string {
+	if r.name != "" {
+		return r.name
+	}
+
+	return strings.TrimSpace(method + " " + r.pattern())
+}
@@ -0,0 +1,126 @@
+package lux_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-xray-sdk-go/xray"
+	"github.com/davidsbond/lux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouter_Tracing(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Context         func() (context.Context, *xray.Segment)
+		Request         lux.Request
+		RouteName       string
+		ExpectedName    string
+		ExpectedStatus  int
+		ExpectedTraceID string
+		ExpectsSegment  bool
+	}{
+		// Scenario 1: Request within an existing segment
+		{
+			Context: func() (context.Context, *xray.Segment) {
+				return xray.BeginSegment(context.Background(), "test")
+			},
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					HTTPMethod: "GET",
+					Path:       "/users/1",
+				},
+			},
+			ExpectedName:   "GET /users/{id}",
+			ExpectedStatus: http.StatusOK,
+			ExpectsSegment: true,
+		},
+		// Scenario 2: Request for a named route with a trace header
+		{
+			Context: func() (context.Context, *xray.Segment) {
+				return context.Background(), nil
+			},
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					HTTPMethod: "GET",
+					Path:       "/users/1",
+					Headers: map[string]string{
+						"X-Amzn-Trace-Id": "Root=1-5759e988-bd862e3fe1be46a994272793;Parent=53995c3f42cd8ad8;Sampled=1",
+					},
+				},
+			},
+			RouteName:       "user",
+			ExpectedName:    "user",
+			ExpectedStatus:  http.StatusOK,
+			ExpectedTraceID: "1-5759e988-bd862e3fe1be46a994272793",
+			ExpectsSegment:  true,
+		},
+		// Scenario 3: Request without a segment
+		{
+			Context: func() (context.Context, *xray.Segment) {
+				return context.Background(), nil
+			},
+			Request: lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					HTTPMethod: "GET",
+					Path:       "/users/1",
+				},
+			},
+			ExpectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range tt {
+		var actual *xray.Segment
+
+		// GIVEN that we have a router with tracing enabled
+		router := lux.NewRouter().Tracing()
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has a handler that obtains the current segment
+		route := router.Handler("GET", func(w lux.ResponseWriter, r *lux.Request) {
+			actual = xray.GetSegment(r.Context())
+			w.WriteHeader(http.StatusOK)
+		}).Path("/users/{id}")
+
+		if tc.RouteName != "" {
+			route.Name(tc.RouteName)
+		}
+
+		ctx, parent := tc.Context()
+
+		// WHEN we perform the request
+		resp, _ := router.ServeHTTP(ctx, tc.Request)
+
+		// THEN the response should have the expected status
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+
+		if !tc.ExpectsSegment {
+			assert.Nil(t, actual)
+			continue
+		}
+
+		// AND the handler should have been executed within a subsegment
+		if assert.NotNil(t, actual) {
+			assert.Equal(t, tc.ExpectedName, actual.Name)
+			assert.Equal(t, tc.Request.HTTPMethod, actual.Annotations["method"])
+			assert.Equal(t, tc.Request.Path, actual.Annotations["path"])
+			assert.EqualValues(t, tc.ExpectedStatus, actual.Annotations["status"])
+			assert.False(t, actual.InProgress)
+		}
+
+		if parent != nil {
+			assert.Equal(t, parent.ID, actual.ParentID)
+			parent.Close(nil)
+		}
+
+		if tc.ExpectedTraceID != "" {
+			assert.Equal(t, tc.ExpectedTraceID, actual.TraceID)
+		}
+	}
+}
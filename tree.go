@@ -0,0 +1,147 @@
+package lux
+
+import (
+	"sort"
+	"strings"
+)
+
+type (
+	// routeTree indexes routes by the segments of their path patterns, so that the routes
+	// whose path matches a request can be found in time proportional to the length of the
+	// path, rather than the number of registered routes.
+	routeTree struct {
+		root    *routeNode
+		anyPath []*Route
+		order   map[*Route]int
+	}
+
+	// routeNode is a node within the route tree. Each node has children for the static
+	// segments that follow it and a single child for any named parameter, along with the
+	// routes whose path pattern ends at the node. Routes whose pattern ends with a catch-all
+	// parameter following the node are stored separately, as they match any number of the
+	// remaining segments.
+	routeNode struct {
+		static   map[string]*routeNode
+		param    *routeNode
+		catchAll []*Route
+		routes   []*Route
+	}
+)
+
+// newRouteTree creates a tree indexing the given routes.
+func newRouteTree(routes []*Route) *routeTree {
+	tree := &routeTree{
+		root:  newRouteNode(),
+		order: make(map[*Route]int, len(routes)),
+	}
+
+	for i, route := range routes {
+		tree.order[route] = i
+		tree.insert(route)
+	}
+
+	return tree
+}
+
+// newRouteNode creates an empty node.
+func newRouteNode() *routeNode {
+	return &routeNode{static: make(map[string]*routeNode)}
+}
+
+// insert adds the route to the tree. Routes without a path match every request path, so
+// they are stored separately.
+func (t *routeTree) insert(route *Route) {
+	if route.path == nil {
+		t.anyPath = append(t.anyPath, route)
+		return
+	}
+
+	node := t.root
+
+	for _, seg := range route.path.segments {
+		switch {
+		case seg.catchAll:
+			node.catchAll = append(node.catchAll, route)
+			return
+		case seg.param && node.param == nil:
+			node.param = newRouteNode()
+			fallthrough
+		case seg.param:
+			node = node.param
+		default:
+			child, ok := node.static[seg.value]
+
+			if !ok {
+				child = newRouteNode()
+				node.static[seg.value] = child
+			}
+
+			node = child
+		}
+	}
+
+	node.routes = append(node.routes, route)
+}
+
+// lookup returns the routes whose path matches the given path, in the order they were
+// registered. Routes without a path are always returned.
+func (t *routeTree) lookup(path string) []*Route {
+	out := t.root.collect(splitPath(path), nil)
+	out = append(out, t.anyPath...)
+
+	// Candidates are checked in the order they were registered, regardless of whether
+	// they matched on a static segment or a named parameter.
+	if len(out) > 1 {
+		sort.Slice(out, func(i, j int) bool {
+			return t.order[out[i]] < t.order[out[j]]
+		})
+	}
+
+	return out
+}
+
+// collect appends the routes matching the remaining path segments to out. Both the static
+// & parameter children are followed, as either may lead to a match.
+func (n *routeNode) collect(parts []string, out []*Route) []*Route {
+	if len(parts) == 0 {
+		return append(out, n.routes...)
+	}
+
+	if child, ok := n.static[parts[0]]; ok {
+		out = child.collect(parts[1:], out)
+	}
+
+	// Named parameters only match non-empty segments
+	if n.param != nil && parts[0] != "" {
+		out = n.param.collect(parts[1:], out)
+	}
+
+	// Catch-all parameters match the remaining segments, as long as they are not empty
+	if len(n.catchAll) > 0 && strings.Join(parts, "") != "" {
+		out = append(out, n.catchAll...)
+	}
+
+	return out
+}
+
+// index returns the tree of the router's routes, building it if routes have changed since
+// it was last built.
+func (r *Router) index() *routeTree {
+	r.treeMu.Lock()
+	defer r.treeMu.Unlock()
+
+	if r.tree == nil {
+		r.tree = newRouteTree(r.routes)
+	}
+
+	return r.tree
+}
+
+// reindex discards the tree of the router's routes, so that it is rebuilt to include any
+// changes on the next request.
+func (r *Router) reindex() {
+	r.treeMu.Lock()
+	defer r.treeMu.Unlock()
+
+	r.tree = nil
+}
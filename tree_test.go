@@ -0,0 +1,244 @@
+package lux_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/davidsbond/lux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouter_RoutesOverlappingPaths(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Method         string
+		Path           string
+		ExpectedBody   string
+		ExpectedStatus int
+	}{
+		// Scenario 1: Static path registered after a parameterised one
+		{
+			Method:         "GET",
+			Path:           "/users/new",
+			ExpectedBody:   "/users/{id}",
+			ExpectedStatus: http.StatusOK,
+		},
+		// Scenario 2: Parameterised path
+		{
+			Method:         "GET",
+			Path:           "/users/42",
+			ExpectedBody:   "/users/{id}",
+			ExpectedStatus: http.StatusOK,
+		},
+		// Scenario 3: Static path for a method only it handles
+		{
+			Method:         "POST",
+			Path:           "/users/new",
+			ExpectedBody:   "/users/new",
+			ExpectedStatus: http.StatusOK,
+		},
+		// Scenario 4: Nested parameterised path sharing a prefix with a static one
+		{
+			Method:         "GET",
+			Path:           "/users/42/posts/7",
+			ExpectedBody:   "/users/{id}/posts/{post}",
+			ExpectedStatus: http.StatusOK,
+		},
+		// Scenario 5: Root path
+		{
+			Method:         "GET",
+			Path:           "/",
+			ExpectedBody:   "/",
+			ExpectedStatus: http.StatusOK,
+		},
+		// Scenario 6: Path that only matches the route without a path
+		{
+			Method:         "PUT",
+			Path:           "/anything/at/all",
+			ExpectedBody:   "PUT",
+			ExpectedStatus: http.StatusOK,
+		},
+		// Scenario 7: Path that does not match a route
+		{
+			Method:         "GET",
+			Path:           "/users/42/comments",
+			ExpectedStatus: http.StatusMethodNotAllowed,
+		},
+		// Scenario 8: Path with a trailing slash
+		{
+			Method:         "GET",
+			Path:           "/users/",
+			ExpectedStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router
+		router := lux.NewRouter()
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has handlers that write the pattern they matched
+		handler := func(w lux.ResponseWriter, r *lux.Request) {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(r.RoutePattern()))
+		}
+
+		router.Handler("GET", handler).Path("/users/{id}")
+		router.Handler("GET", handler).Path("/users/new")
+		router.Handler("POST", handler).Path("/users/new")
+		router.Handler("GET", handler).Path("/users/{id}/posts/{post}")
+		router.Handler("GET", handler).Path("/")
+		router.Handler("PUT", handler)
+
+		// WHEN we perform the request
+		resp, _ := router.ServeHTTP(context.Background(), lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+				HTTPMethod: tc.Method,
+				Path:       tc.Path,
+			},
+		})
+
+		// THEN the request should be handled by the expected route
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+
+		if tc.ExpectedStatus == http.StatusOK {
+			assert.Equal(t, tc.ExpectedBody, resp.Body)
+		}
+	}
+}
+
+func BenchmarkRouter_Register(b *testing.B) {
+	for _, n := range []int{10, 100, 1000} {
+		b.Run(fmt.Sprintf("%d routes", n), func(b *testing.B) {
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				router := lux.NewRouter().Logger(lux.NopLogger())
+
+				for j := 0; j < n; j++ {
+					router.Handler("GET", getHandler).Path(fmt.Sprintf("/resource%d/{id}/items", j))
+				}
+
+				// Routes are indexed when the first request is served
+				router.ServeHTTP(context.Background(), lux.Request{})
+			}
+		})
+	}
+}
+
+func BenchmarkRouter_Lookup(b *testing.B) {
+	for _, n := range []int{10, 100, 1000} {
+		b.Run(fmt.Sprintf("%d routes", n), func(b *testing.B) {
+			router := lux.NewRouter().Logger(lux.NopLogger())
+
+			for j := 0; j < n; j++ {
+				router.Handler("GET", getHandler).Path(fmt.Sprintf("/resource%d/{id}/items", j))
+			}
+
+			// Request the last registered route, the worst case for a linear scan
+			req := lux.Request{
+				APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+					HTTPMethod: "GET",
+					Path:       fmt.Sprintf("/resource%d/42/items", n-1),
+				},
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				router.ServeHTTP(context.Background(), req)
+			}
+		})
+	}
+}
+
+func TestRouter_DispatchesByPathAndMethod(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Method         string
+		Path           string
+		ExpectedStatus int
+		ExpectedBody   string
+		ExpectedAllow  string
+	}{
+		// Scenario 1: Request matches the GET handler of a path with multiple methods
+		{
+			Method:         "GET",
+			Path:           "/a",
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   "GET /a",
+		},
+		// Scenario 2: Request matches the POST handler of a path with multiple methods
+		{
+			Method:         "POST",
+			Path:           "/a",
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   "POST /a",
+		},
+		// Scenario 3: Request matches the same method on a different path
+		{
+			Method:         "GET",
+			Path:           "/b",
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   "GET /b",
+		},
+		// Scenario 4: Request matches a path but not a method registered on another path
+		{
+			Method:         "POST",
+			Path:           "/b",
+			ExpectedStatus: http.StatusMethodNotAllowed,
+			ExpectedAllow:  "GET, HEAD, OPTIONS",
+		},
+		// Scenario 5: Request matches a path but none of its methods
+		{
+			Method:         "DELETE",
+			Path:           "/a",
+			ExpectedStatus: http.StatusMethodNotAllowed,
+			ExpectedAllow:  "GET, HEAD, POST, OPTIONS",
+		},
+		// Scenario 6: Request matches no path
+		{
+			Method:         "GET",
+			Path:           "/c",
+			ExpectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router
+		router := lux.NewRouter()
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has handlers registered for combinations of paths & methods
+		for _, route := range [][2]string{{"GET", "/a"}, {"POST", "/a"}, {"GET", "/b"}} {
+			body := route[0] + " " + route[1]
+
+			router.Handler(route[0], func(w lux.ResponseWriter, r *lux.Request) {
+				lux.Text(w, http.StatusOK, body)
+			}).Path(route[1])
+		}
+
+		// WHEN we perform a request
+		resp, _ := router.ServeHTTP(context.Background(), lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{HTTPMethod: tc.Method, Path: tc.Path},
+		})
+
+		// THEN the handler for the path & method should be used
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+
+		if tc.ExpectedStatus == http.StatusOK {
+			assert.Equal(t, tc.ExpectedBody, resp.Body)
+		}
+
+		// AND unsupported methods should list those allowed for the path
+		assert.Equal(t, tc.ExpectedAllow, resp.Headers["Allow"])
+	}
+}
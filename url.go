@@ -0,0 +1,46 @@
+package lux
+
+import "fmt"
+
+// URL generates a URL for the route registered with the given name using Route.Name.
+// The pairs are used as the values of the path parameters in the route's pattern, for
+// example:
+//
+//	router.Handler("GET", getUser).Path("/users/{id}").Name("getUser")
+//
+//	url, err := router.URL("getUser", "id", "42") // "/users/42"
+//
+// An error is returned if no route has the given name, the route has no path or a path
+// parameter has no value.
+func (r *Router) URL(name string, pairs ...string) (string, error) {
+	route, ok := r.named[name]
+
+	if !ok {
+		return "", fmt.Errorf("no route named %s", name)
+	}
+
+	if route.path == nil {
+		return "", fmt.Errorf("route %s has no path", name)
+	}
+
+	if len(pairs)%2 != 0 {
+		return "", fmt.Errorf("odd number of path parameters for route %s", name)
+	}
+
+	params := make(map[string]string)
+
+	for i := 0; i < len(pairs); i += 2 {
+		params[pairs[i]] = pairs[i+1]
+	}
+
+	return route.path.build(params)
+}
+
+// URL generates a URL for the named route of the router handling the request. See Router.URL.
+func (r *Request) URL(name string, pairs ...string) (string, error) {
+	if r.router == nil {
+		return "", fmt.Errorf("no route named %s", name)
+	}
+
+	return r.router.URL(name, pairs...)
+}
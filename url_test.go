@@ -0,0 +1,85 @@
+package lux_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/davidsbond/lux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouter_URL(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Name          string
+		Pairs         []string
+		ExpectedURL   string
+		ExpectedError string
+	}{
+		// Scenario 1: Route with path parameters
+		{
+			Name:        "getPost",
+			Pairs:       []string{"id", "42", "post", "hello world"},
+			ExpectedURL: "/users/42/posts/hello%20world",
+		},
+		// Scenario 2: Route without path parameters
+		{
+			Name:        "listUsers",
+			ExpectedURL: "/v1/users",
+		},
+		// Scenario 3: Missing path parameter
+		{
+			Name:          "getPost",
+			Pairs:         []string{"id", "42"},
+			ExpectedError: "missing value for path parameter post",
+		},
+		// Scenario 4: Unknown route
+		{
+			Name:          "unknown",
+			ExpectedError: "no route named unknown",
+		},
+		// Scenario 5: Route without a path
+		{
+			Name:          "noPath",
+			ExpectedError: "route noPath has no path",
+		},
+		// Scenario 6: Odd number of pairs
+		{
+			Name:          "getPost",
+			Pairs:         []string{"id"},
+			ExpectedError: "odd number of path parameters for route getPost",
+		},
+		// Scenario 7: Route with a catch-all parameter
+		{
+			Name:        "getFile",
+			Pairs:       []string{"path", "docs/annual report.pdf"},
+			ExpectedURL: "/files/docs/annual%20report.pdf",
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router
+		router := lux.NewRouter()
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has named routes
+		router.Handler("GET", getHandler).Path("/users/{id}/posts/{post}").Name("getPost")
+		router.Handler("GET", getHandler).Path("/files/{path...}").Name("getFile")
+		router.Group("/v1").Handler("GET", getHandler).Path("/users").Name("listUsers")
+		router.Handler("GET", getHandler).Name("noPath")
+
+		// WHEN we generate a URL
+		url, err := router.URL(tc.Name, tc.Pairs...)
+
+		// THEN the URL & any errors should be what we expect
+		if tc.ExpectedError != "" {
+			assert.EqualError(t, err, tc.ExpectedError)
+			continue
+		}
+
+		assert.Nil(t, err)
+		assert.Equal(t, tc.ExpectedURL, url)
+	}
+}
@@ -0,0 +1,149 @@
+package lux
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// ServeV2 handles an incoming HTTP request from an AWS API Gateway HTTP API using
+// the version 2.0 payload format. The request is converted so that it can be routed
+// using the same handlers & middleware as ServeHTTP, and the response is converted
+// back into the version 2.0 format. Requests to named stages are routed without the
+// stage at the start of their path, as they are for REST APIs.
+func (r *Router) ServeV2(ctx context.Context, req events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
+	resp, err := r.ServeHTTP(ctx, newRequestFromV2(req))
+
+	if err != nil {
+		return events.APIGatewayV2HTTPResponse{}, err
+	}
+
+	return newV2Response(resp), nil
+}
+
+// v2Path returns the path of a version 2.0 API Gateway request. Unlike REST APIs, the raw path
+// of requests to a named stage begins with the stage, which is removed so that routes do not
+// depend on the stage they are deployed to. The $default stage is never part of the path.
+func v2Path(req events.APIGatewayV2HTTPRequest) string {
+	stage := req.RequestContext.Stage
+
+	if stage == "" || stage == "$default" {
+		return req.RawPath
+	}
+
+	prefix := "/" + stage
+
+	switch {
+	case req.RawPath == prefix:
+		return "/"
+	case strings.HasPrefix(req.RawPath, prefix+"/"):
+		return strings.TrimPrefix(req.RawPath, prefix)
+	default:
+		return req.RawPath
+	}
+}
+
+// newRequestFromV2 converts a version 2.0 API Gateway request into a Request.
+func newRequestFromV2(req events.APIGatewayV2HTTPRequest) Request {
+	out := Request{
+		APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+			Resource:              req.RouteKey,
+			Path:                  v2Path(req),
+			HTTPMethod:            req.RequestContext.HTTP.Method,
+			Headers:               make(map[string]string),
+			QueryStringParameters: req.QueryStringParameters,
+			PathParameters:        req.PathParameters,
+			StageVariables:        req.StageVariables,
+			Body:                  req.Body,
+			IsBase64Encoded:       req.IsBase64Encoded,
+			RequestContext: events.APIGatewayProxyRequestContext{
+				AccountID:        req.RequestContext.AccountID,
+				Stage:            req.RequestContext.Stage,
+				DomainName:       req.RequestContext.DomainName,
+				DomainPrefix:     req.RequestContext.DomainPrefix,
+				RequestID:        req.RequestContext.RequestID,
+				Protocol:         req.RequestContext.HTTP.Protocol,
+				Path:             req.RequestContext.HTTP.Path,
+				HTTPMethod:       req.RequestContext.HTTP.Method,
+				RequestTime:      req.RequestContext.Time,
+				RequestTimeEpoch: req.RequestContext.TimeEpoch,
+				APIID:            req.RequestContext.APIID,
+				Identity: events.APIGatewayRequestIdentity{
+					SourceIP:  req.RequestContext.HTTP.SourceIP,
+					UserAgent: req.RequestContext.HTTP.UserAgent,
+				},
+			},
+		},
+		raw: req,
+	}
+
+	for key, value := range req.Headers {
+		out.Headers[key] = value
+	}
+
+	// Version 2.0 payloads provide cookies separately from the headers
+	if len(req.Cookies) > 0 {
+		out.Headers["cookie"] = strings.Join(req.Cookies, "; ")
+	}
+
+	// Version 2.0 payloads join multiple query values with commas, so the raw query
+	// string is used to obtain each individual value.
+	if query, err := url.ParseQuery(req.RawQueryString); err == nil && len(query) > 0 {
+		out.MultiValueQueryStringParameters = query
+	}
+
+	if auth := req.RequestContext.Authorizer; auth != nil && auth.Lambda != nil {
+		out.RequestContext.Authorizer = auth.Lambda
+	}
+
+	// JWT claims are provided in the same format as those of Cognito authorizers for REST APIs
+	if auth := req.RequestContext.Authorizer; auth != nil && auth.JWT != nil {
+		claims := make(map[string]interface{}, len(auth.JWT.Claims))
+
+		for key, value := range auth.JWT.Claims {
+			claims[key] = value
+		}
+
+		out.RequestContext.Authorizer = map[string]interface{}{
+			"claims": claims,
+			"scopes": auth.JWT.Scopes,
+		}
+	}
+
+	return out
+}
+
+// newV2Response converts a Response into the version 2.0 API Gateway response format. Version
+// 2.0 payloads do not support multi-value headers, so multiple values are joined with commas
+// and cookies are returned separately.
+func newV2Response(resp Response) events.APIGatewayV2HTTPResponse {
+	out := events.APIGatewayV2HTTPResponse{
+		StatusCode:      resp.StatusCode,
+		Headers:         make(map[string]string),
+		Body:            resp.Body,
+		IsBase64Encoded: resp.IsBase64Encoded,
+	}
+
+	headers := make(map[string][]string)
+
+	for key, value := range resp.Headers {
+		headers[key] = []string{value}
+	}
+
+	for key, values := range resp.MultiValueHeaders {
+		headers[key] = values
+	}
+
+	for key, values := range headers {
+		if strings.EqualFold(key, "Set-Cookie") {
+			out.Cookies = append(out.Cookies, values...)
+			continue
+		}
+
+		out.Headers[key] = strings.Join(values, ",")
+	}
+
+	return out
+}
@@ -0,0 +1,152 @@
+package lux_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/davidsbond/lux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouter_ServesV2Requests(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Request        events.APIGatewayV2HTTPRequest
+		ExpectedBody   string
+		ExpectedStatus int
+	}{
+		// Scenario 1: Valid GET request with correct headers & path.
+		{
+			Request: events.APIGatewayV2HTTPRequest{
+				RawPath:        "/users/42",
+				RawQueryString: "key=value",
+				Headers:        map[string]string{"content-type": "application/json"},
+				RequestContext: events.APIGatewayV2HTTPRequestContext{
+					HTTP: events.APIGatewayV2HTTPRequestContextHTTPDescription{
+						Method: "GET",
+					},
+				},
+				QueryStringParameters: map[string]string{"key": "value"},
+			},
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   "\"hello test\"\n",
+		},
+		// Scenario 2: Request with an unsupported method
+		{
+			Request: events.APIGatewayV2HTTPRequest{
+				RawPath: "/users/42",
+				Headers: map[string]string{"content-type": "application/json"},
+				RequestContext: events.APIGatewayV2HTTPRequestContext{
+					HTTP: events.APIGatewayV2HTTPRequestContextHTTPDescription{
+						Method: "DELETE",
+					},
+				},
+			},
+			ExpectedStatus: http.StatusMethodNotAllowed,
+			ExpectedBody:   "\"not allowed\"",
+		},
+		// Scenario 3: Request with a missing query parameter
+		{
+			Request: events.APIGatewayV2HTTPRequest{
+				RawPath: "/users/42",
+				Headers: map[string]string{"content-type": "application/json"},
+				RequestContext: events.APIGatewayV2HTTPRequestContext{
+					HTTP: events.APIGatewayV2HTTPRequestContextHTTPDescription{
+						Method: "GET",
+					},
+				},
+			},
+			ExpectedStatus: http.StatusNotAcceptable,
+			ExpectedBody:   "\"not acceptable\"",
+		},
+		// Scenario 4: Request to a named stage
+		{
+			Request: events.APIGatewayV2HTTPRequest{
+				RawPath: "/prod/users/42",
+				Headers: map[string]string{"content-type": "application/json"},
+				RequestContext: events.APIGatewayV2HTTPRequestContext{
+					Stage: "prod",
+					HTTP: events.APIGatewayV2HTTPRequestContextHTTPDescription{
+						Method: "GET",
+						Path:   "/prod/users/42",
+					},
+				},
+				QueryStringParameters: map[string]string{"key": "value"},
+			},
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   "\"hello test\"\n",
+		},
+		// Scenario 5: Request to the default stage
+		{
+			Request: events.APIGatewayV2HTTPRequest{
+				RawPath: "/users/42",
+				Headers: map[string]string{"content-type": "application/json"},
+				RequestContext: events.APIGatewayV2HTTPRequestContext{
+					Stage: "$default",
+					HTTP: events.APIGatewayV2HTTPRequestContextHTTPDescription{
+						Method: "GET",
+					},
+				},
+				QueryStringParameters: map[string]string{"key": "value"},
+			},
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   "\"hello test\"\n",
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router
+		router := lux.NewRouter()
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has a handler registered
+		router.Handler("GET", getHandler).
+			Path("/users/{id}").
+			Headers("content-type", "application/json").
+			Queries("key", "value")
+
+		// WHEN we perform the request
+		resp, err := router.ServeV2(context.Background(), tc.Request)
+
+		// THEN there should be no error
+		assert.Nil(t, err)
+
+		// AND the status code & body should be what we expect.
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+		assert.Equal(t, tc.ExpectedBody, resp.Body)
+	}
+}
+
+func TestRouter_ServesV2Cookies(t *testing.T) {
+	t.Parallel()
+
+	// GIVEN that we have a router
+	router := lux.NewRouter()
+	router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+	// AND that router has a handler that reads & writes cookies
+	router.Handler("GET", func(w lux.ResponseWriter, r *lux.Request) {
+		w.Header().Add("Set-Cookie", r.Headers["cookie"])
+		w.Header().Add("Set-Cookie", "c=3")
+		w.WriteHeader(http.StatusOK)
+	})
+
+	// WHEN we perform a request with cookies
+	resp, _ := router.ServeV2(context.Background(), events.APIGatewayV2HTTPRequest{
+		Cookies: []string{"a=1", "b=2"},
+		RequestContext: events.APIGatewayV2HTTPRequestContext{
+			HTTP: events.APIGatewayV2HTTPRequestContextHTTPDescription{
+				Method: "GET",
+			},
+		},
+	})
+
+	// THEN the cookies should be returned separately from the headers
+	assert.Equal(t, []string{"a=1; b=2", "c=3"}, resp.Cookies)
+	assert.Empty(t, resp.Headers["Set-Cookie"])
+}
@@ -0,0 +1,256 @@
+package lux
+
+import (
+	"fmt"
+	"net/mail"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+type (
+	// The FieldError type describes a struct field that failed validation.
+	FieldError struct {
+		// Field contains the name of the field, using its JSON name where available.
+		// Nested fields are separated by a period.
+		Field string `json:"field"`
+
+		// Rule contains the validation rule that failed, such as "required".
+		Rule string `json:"rule"`
+
+		// Param contains the parameter of the validation rule, such as the "3" in
+		// "min=3".
+		Param string `json:"param,omitempty"`
+	}
+
+	// The ValidationErrors type is the error returned when a value fails validation.
+	// It contains an entry for each field that failed.
+	ValidationErrors []FieldError
+)
+
+// Validate checks the fields of the given struct against the rules specified in their
+// "validate" tags. Rules are separated by commas and parameters are provided after an
+// equals sign, for example `validate:"required,min=3"`. The supported rules are:
+//
+//	required  - the field must not be its zero value
+//	omitempty - skip the remaining rules when the field is its zero value
+//	min=n     - strings, slices & maps must have a length of at least n, numbers must be at least n
+//	max=n     - strings, slices & maps must have a length of at most n, numbers must be at most n
+//	len=n     - strings, slices & maps must have a length of exactly n
+//	email     - the field must be a valid email address
+//	oneof=a b - the field must be one of the space separated values
+//
+// Nested structs are also validated. If any fields fail validation, a ValidationErrors
+// is returned.
+func Validate(v interface{}) error {
+	val := reflect.ValueOf(v)
+
+	for val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return fmt.Errorf("cannot validate nil value")
+		}
+
+		val = val.Elem()
+	}
+
+	if val.Kind() != reflect.Struct {
+		return fmt.Errorf("cannot validate non-struct type %s", val.Type())
+	}
+
+	var errs ValidationErrors
+
+	if err := validateStruct(val, "", &errs); err != nil {
+		return err
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// BindValidate decodes the JSON request body into the value pointed to by v using
+// Request.Bind, then validates it using Validate.
+func (r *Request) BindValidate(v interface{}) error {
+	if err := r.Bind(v); err != nil {
+		return err
+	}
+
+	return Validate(v)
+}
+
+// Error returns a description of each field that failed validation.
+func (v ValidationErrors) Error() string {
+	fields := make([]string, len(v))
+
+	for i, field := range v {
+		fields[i] = fmt.Sprintf("%s (%s)", field.Field, field.Rule)
+	}
+
+	return "validation failed for " + strings.Join(fields, ", ")
+}
+
+// validateStruct validates each field of the given struct, appending any failures to
+// errs.
+func validateStruct(val reflect.Value, prefix string, errs *ValidationErrors) error {
+	typ := val.Type()
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+
+		// Unexported fields cannot be validated
+		if field.PkgPath != "" {
+			continue
+		}
+
+		name := prefix + fieldName(field)
+		value := val.Field(i)
+
+		if tag, ok := field.Tag.Lookup("validate"); ok {
+			if err := validateField(value, name, tag, errs); err != nil {
+				return err
+			}
+		}
+
+		// Validate any nested structs
+		for value.Kind() == reflect.Ptr && !value.IsNil() {
+			value = value.Elem()
+		}
+
+		if value.Kind() == reflect.Struct {
+			if err := validateStruct(value, name+".", errs); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateField checks a single field against the rules in its tag.
+func validateField(value reflect.Value, name, tag string, errs *ValidationErrors) error {
+	empty := value.IsZero()
+
+	for value.Kind() == reflect.Ptr && !value.IsNil() {
+		value = value.Elem()
+	}
+
+	for _, rule := range strings.Split(tag, ",") {
+		rule, param := splitRule(rule)
+
+		if rule == "omitempty" {
+			if empty {
+				return nil
+			}
+
+			continue
+		}
+
+		// Nil pointers can only be checked for presence
+		if value.Kind() == reflect.Ptr && rule != "required" {
+			continue
+		}
+
+		ok, err := checkRule(value, empty, rule, param)
+
+		if err != nil {
+			return fmt.Errorf("invalid validation rule for field %s, %v", name, err)
+		}
+
+		if !ok {
+			*errs = append(*errs, FieldError{Field: name, Rule: rule, Param: param})
+		}
+	}
+
+	return nil
+}
+
+// checkRule determines if the given value satisfies a validation rule.
+func checkRule(value reflect.Value, empty bool, rule, param string) (bool, error) {
+	switch rule {
+	case "required":
+		return !empty, nil
+	case "min", "max", "len":
+		limit, err := strconv.ParseFloat(param, 64)
+
+		if err != nil {
+			return false, fmt.Errorf("%s requires a numeric parameter", rule)
+		}
+
+		actual, ok := measure(value)
+
+		if !ok {
+			return false, fmt.Errorf("%s cannot be used with type %s", rule, value.Type())
+		}
+
+		switch rule {
+		case "min":
+			return actual >= limit, nil
+		case "max":
+			return actual <= limit, nil
+		default:
+			return actual == limit, nil
+		}
+	case "email":
+		if value.Kind() != reflect.String {
+			return false, fmt.Errorf("email cannot be used with type %s", value.Type())
+		}
+
+		addr, err := mail.ParseAddress(value.String())
+
+		return err == nil && addr.Address == value.String(), nil
+	case "oneof":
+		actual := fmt.Sprint(value.Interface())
+
+		for _, option := range strings.Fields(param) {
+			if option == actual {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	default:
+		return false, fmt.Errorf("unknown rule %s", rule)
+	}
+}
+
+// measure returns the length of strings, slices, arrays & maps or the value of numbers.
+func measure(value reflect.Value) (float64, bool) {
+	switch value.Kind() {
+	case reflect.String:
+		return float64(utf8.RuneCountInString(value.String())), true
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return float64(value.Len()), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(value.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+// splitRule splits a validation rule into its name and parameter.
+func splitRule(rule string) (string, string) {
+	parts := strings.SplitN(strings.TrimSpace(rule), "=", 2)
+
+	if len(parts) == 1 {
+		return parts[0], ""
+	}
+
+	return parts[0], parts[1]
+}
+
+// fieldName returns the JSON name of a struct field, falling back to the field's
+// name if it has no JSON tag.
+func fieldName(field reflect.StructField) string {
+	if tag := strings.Split(field.Tag.Get("json"), ",")[0]; tag != "" && tag != "-" {
+		return tag
+	}
+
+	return field.Name
+}
@@ -0,0 +1,133 @@
+package lux_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/davidsbond/lux"
+	"github.com/stretchr/testify/assert"
+)
+
+type (
+	testAddress struct {
+		City string `json:"city" validate:"required"`
+	}
+
+	testUser struct {
+		Name    string       `json:"name" validate:"required,min=3,max=10"`
+		Email   string       `json:"email" validate:"required,email"`
+		Role    string       `json:"role" validate:"omitempty,oneof=admin user"`
+		Age     int          `json:"age" validate:"min=18"`
+		Tags    []string     `json:"tags" validate:"max=2"`
+		Address *testAddress `json:"address"`
+	}
+)
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Value          interface{}
+		ExpectedErrors lux.ValidationErrors
+	}{
+		// Scenario 1: Valid struct
+		{
+			Value: &testUser{
+				Name:    "test",
+				Email:   "test@example.com",
+				Role:    "admin",
+				Age:     18,
+				Address: &testAddress{City: "London"},
+			},
+		},
+		// Scenario 2: Missing required fields
+		{
+			Value: &testUser{Age: 18},
+			ExpectedErrors: lux.ValidationErrors{
+				{Field: "name", Rule: "required"},
+				{Field: "name", Rule: "min", Param: "3"},
+				{Field: "email", Rule: "required"},
+				{Field: "email", Rule: "email"},
+			},
+		},
+		// Scenario 3: Invalid field values
+		{
+			Value: testUser{
+				Name:    "a very long name",
+				Email:   "not an email",
+				Role:    "guest",
+				Age:     12,
+				Tags:    []string{"a", "b", "c"},
+				Address: &testAddress{},
+			},
+			ExpectedErrors: lux.ValidationErrors{
+				{Field: "name", Rule: "max", Param: "10"},
+				{Field: "email", Rule: "email"},
+				{Field: "role", Rule: "oneof", Param: "admin user"},
+				{Field: "age", Rule: "min", Param: "18"},
+				{Field: "tags", Rule: "max", Param: "2"},
+				{Field: "address.city", Rule: "required"},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		// WHEN we validate the value
+		err := lux.Validate(tc.Value)
+
+		// THEN the errors should be what we expect
+		if tc.ExpectedErrors == nil {
+			assert.Nil(t, err)
+			continue
+		}
+
+		assert.Equal(t, tc.ExpectedErrors, err)
+	}
+}
+
+func TestRequest_BindValidate(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		Body          string
+		ExpectedError string
+	}{
+		// Scenario 1: Valid body
+		{
+			Body: `{"name":"test","email":"test@example.com","age":20}`,
+		},
+		// Scenario 2: Body that fails validation
+		{
+			Body:          `{"name":"test","email":"test","age":20}`,
+			ExpectedError: "validation failed for email (email)",
+		},
+		// Scenario 3: Malformed body
+		{
+			Body:          `{"name":`,
+			ExpectedError: "failed to decode request body, unexpected end of JSON input",
+		},
+	}
+
+	for _, tc := range tt {
+		var user testUser
+
+		// GIVEN that we have a request with a JSON body
+		req := lux.Request{
+			APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+				Headers: map[string]string{"Content-Type": "application/json"},
+				Body:    tc.Body,
+			},
+		}
+
+		// WHEN we bind & validate the body
+		err := req.BindValidate(&user)
+
+		// THEN any errors should be what we expect
+		if tc.ExpectedError == "" {
+			assert.Nil(t, err)
+			continue
+		}
+
+		assert.EqualError(t, err, tc.ExpectedError)
+	}
+}
@@ -0,0 +1,135 @@
+package lux
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// WSRoute adds a handler for messages from an API Gateway WebSocket API with the given route
+// key, such as "$connect", "$disconnect" or a custom route key. Messages whose route key has
+// no handler are handled by the "$default" route, if one is registered. WebSocket routes are
+// only used by ServeWebSocket, but share the router's middleware, logging & error handling.
+// Use Request.ConnectionID within the handler to post messages back to the client using the
+// API Gateway management API. Only the first handler registered for a route key is used, any
+// others are reported by Err.
+func (r *Router) WSRoute(key string, fn HandlerFunc) *Route {
+	route := &Route{
+		handler:    fn,
+		routeKey:   key,
+		headers:    make(map[string]string),
+		queries:    make(map[string]string),
+		middleware: []HandlerFunc{},
+		router:     r,
+	}
+
+	if _, ok := r.wsRoutes[key]; ok {
+		r.duplicates = append(r.duplicates, fmt.Sprintf("route key %s is registered more than once", key))
+		return route
+	}
+
+	r.wsRoutes[key] = route
+
+	return route
+}
+
+// ServeWebSocket handles an incoming message from an API Gateway WebSocket API, dispatching it
+// to the handler registered for its route key using WSRoute. Messages without a matching route
+// result in a 404 response.
+func (r *Router) ServeWebSocket(ctx context.Context, req events.APIGatewayWebsocketProxyRequest) (events.APIGatewayProxyResponse, error) {
+	resp, err := r.ServeHTTP(ctx, newRequestFromWebSocket(req))
+
+	if err != nil {
+		return events.APIGatewayProxyResponse{}, err
+	}
+
+	return events.APIGatewayProxyResponse(resp), nil
+}
+
+// ConnectionID returns the identifier of the WebSocket connection that sent the request, or
+// an empty string if the request was not received from a WebSocket API.
+func (r *Request) ConnectionID() string {
+	if r.websocket == nil {
+		return ""
+	}
+
+	return r.websocket.ConnectionID
+}
+
+// RouteKey returns the route key of the WebSocket message, such as "$connect", or an empty
+// string if the request was not received from a WebSocket API.
+func (r *Request) RouteKey() string {
+	if r.websocket == nil {
+		return ""
+	}
+
+	return r.websocket.RouteKey
+}
+
+// findWebSocketRoute locates the route registered for the route key of a WebSocket request,
+// falling back to the "$default" route.
+func (r *Router) findWebSocketRoute(req Request) (routeMatch, error) {
+	out := routeMatch{params: map[string]string{}}
+
+	route, ok := r.wsRoutes[req.websocket.RouteKey]
+
+	if !ok {
+		route, ok = r.wsRoutes["$default"]
+	}
+
+	if !ok {
+		return out, errNotFound
+	}
+
+	if err := route.canRoute(req); err != nil {
+		return out, err
+	}
+
+	out.route = route
+
+	return out, nil
+}
+
+// newRequestFromWebSocket converts an API Gateway WebSocket request into a Request.
+func newRequestFromWebSocket(req events.APIGatewayWebsocketProxyRequest) Request {
+	wsCtx := req.RequestContext
+
+	out := Request{
+		APIGatewayProxyRequest: events.APIGatewayProxyRequest{
+			Resource:                        req.Resource,
+			Path:                            req.Path,
+			HTTPMethod:                      req.HTTPMethod,
+			Headers:                         req.Headers,
+			MultiValueHeaders:               req.MultiValueHeaders,
+			QueryStringParameters:           req.QueryStringParameters,
+			MultiValueQueryStringParameters: req.MultiValueQueryStringParameters,
+			PathParameters:                  req.PathParameters,
+			StageVariables:                  req.StageVariables,
+			Body:                            req.Body,
+			IsBase64Encoded:                 req.IsBase64Encoded,
+			RequestContext: events.APIGatewayProxyRequestContext{
+				AccountID:         wsCtx.AccountID,
+				ResourceID:        wsCtx.ResourceID,
+				Stage:             wsCtx.Stage,
+				DomainName:        wsCtx.DomainName,
+				RequestID:         wsCtx.RequestID,
+				ExtendedRequestID: wsCtx.ExtendedRequestID,
+				Identity:          wsCtx.Identity,
+				ResourcePath:      wsCtx.ResourcePath,
+				HTTPMethod:        wsCtx.HTTPMethod,
+				RequestTime:       wsCtx.RequestTime,
+				RequestTimeEpoch:  wsCtx.RequestTimeEpoch,
+				APIID:             wsCtx.APIID,
+			},
+		},
+		websocket: &wsCtx,
+		raw:       req,
+	}
+
+	if auth, ok := wsCtx.Authorizer.(map[string]interface{}); ok {
+		out.RequestContext.Authorizer = auth
+	}
+
+	return out
+}
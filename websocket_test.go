@@ -0,0 +1,105 @@
+package lux_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/davidsbond/lux"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouter_ServesWebSocketRequests(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		RouteKey       string
+		Default        bool
+		ExpectedStatus int
+		ExpectedBody   string
+	}{
+		// Scenario 1: Message for the connect route
+		{
+			RouteKey:       "$connect",
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   "$connect abc123 $connect",
+		},
+		// Scenario 2: Message for a custom route
+		{
+			RouteKey:       "sendMessage",
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   "sendMessage abc123 sendMessage",
+		},
+		// Scenario 3: Message for an unknown route with a default route
+		{
+			RouteKey:       "unknown",
+			Default:        true,
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   "unknown abc123 $default",
+		},
+		// Scenario 4: Message for an unknown route without a default route
+		{
+			RouteKey:       "unknown",
+			ExpectedStatus: http.StatusNotFound,
+			ExpectedBody:   `"not found"`,
+		},
+	}
+
+	for _, tc := range tt {
+		// GIVEN that we have a router
+		router := lux.NewRouter()
+		router.Logging(bytes.NewBuffer([]byte{}), &logrus.JSONFormatter{})
+
+		// AND that router has global middleware
+		router.Middleware(func(w lux.ResponseWriter, r *lux.Request) {
+			w.Header().Set("X-Middleware", "true")
+		})
+
+		// AND that router has WebSocket routes
+		handler := func(w lux.ResponseWriter, r *lux.Request) {
+			lux.Text(w, http.StatusOK, r.RouteKey()+" "+r.ConnectionID()+" "+r.RoutePattern())
+		}
+
+		router.WSRoute("$connect", handler)
+		router.WSRoute("sendMessage", handler)
+
+		if tc.Default {
+			router.WSRoute("$default", handler)
+		}
+
+		// AND that router has an HTTP route that should not be used
+		router.Handler("", getHandler)
+
+		// WHEN we perform a WebSocket request
+		resp, err := router.ServeWebSocket(context.Background(), events.APIGatewayWebsocketProxyRequest{
+			RequestContext: events.APIGatewayWebsocketProxyRequestContext{
+				RouteKey:     tc.RouteKey,
+				ConnectionID: "abc123",
+			},
+		})
+
+		// THEN the message should be handled by the route for its route key
+		assert.Nil(t, err)
+		assert.Equal(t, tc.ExpectedStatus, resp.StatusCode)
+		assert.Equal(t, tc.ExpectedBody, resp.Body)
+
+		// AND the global middleware should have been executed for routed messages
+		if tc.ExpectedStatus == http.StatusOK {
+			assert.Equal(t, "true", resp.Headers["X-Middleware"])
+		}
+	}
+}
+
+func TestRequest_WebSocket(t *testing.T) {
+	t.Parallel()
+
+	// GIVEN that we have a request that was not received from a WebSocket API
+	req := lux.Request{}
+
+	// THEN the WebSocket details should be empty
+	assert.Equal(t, "", req.ConnectionID())
+	assert.Equal(t, "", req.RouteKey())
+}